@@ -49,6 +49,9 @@ type Vscode mg.Namespace
 // Constitution groups constitution preview targets.
 type Constitution mg.Namespace
 
+// Config groups configuration inspection targets.
+type Config mg.Namespace
+
 // baseCfg holds the configuration loaded from configuration.yaml.
 var baseCfg orchestrator.Config
 
@@ -103,13 +106,31 @@ func Credentials() error { return newOrch().ExtractCredentials() }
 // Analyze performs cross-artifact consistency checks (PRDs, use cases, test suites, roadmap).
 func Analyze() error { return newOrch().Analyze() }
 
+// Validate checks configuration that is otherwise only exercised at run time, such as prompt templates.
+func Validate() error { return newOrch().ConfigValidate() }
+
+// VerifySpecs checks that PRD, use case, and test suite IDs are unique and match their file names.
+func VerifySpecs() error { return newOrch().VerifySpecIntegrity().Err() }
+
 // Status reports code implementation status per use case and release,
 // comparing road-map.yaml spec status with test file presence.
 func Status() error { return newOrch().CodeStatus() }
 
+// StatusRelease reports code implementation status for one road-map.yaml
+// release only, e.g. mage statusRelease 02.0.
+func StatusRelease(version string) error { return newOrch().CodeStatusForRelease(version) }
+
+// Roadmap prints each road-map.yaml release with its spec status and use
+// case count, without scanning tests/.
+func Roadmap() error { return newOrch().RoadmapSummary() }
+
 // Tag creates a documentation release tag (v0.YYYYMMDD.N) and builds the container image.
 func Tag() error { return newOrch().Tag() }
 
+// TagAt creates the next documentation release tag on the given commit
+// instead of HEAD. The commit must be reachable from the base branch.
+func TagAt(commit string) error { return newOrch().TagCommit(commit) }
+
 // --- Scaffold targets ---
 
 // Push scaffolds the orchestrator into a target Go repository. The argument
@@ -232,12 +253,42 @@ func (Test) Benchmark() error {
 // Measure assesses project state and proposes new tasks via Claude.
 func (Cobbler) Measure() error { return newOrch().Measure() }
 
+// MeasureForce runs measure even when require_measure_context finds no roadmap, specs, or source.
+func (Cobbler) MeasureForce() error { return newOrch().MeasureForce() }
+
+// Created prints the URLs of the issues the last measure run created, opening them when cobbler.open_created_issues is set outside CI.
+func (Cobbler) Created() error { return newOrch().CreatedIssues() }
+
+// MeasureDryRun runs one measure iteration and prints the issues it would create, without touching GitHub.
+func (Cobbler) MeasureDryRun() error { return newOrch().MeasureDryRun() }
+
 // Stitch picks ready tasks and invokes Claude to execute them.
 func (Cobbler) Stitch() error { return newOrch().Stitch() }
 
 // Reset removes the cobbler scratch directory.
 func (Cobbler) Reset() error { return newOrch().CobblerReset() }
 
+// Status prints pending issues, the last analysis, recent stitch results, and the active generation.
+func (Cobbler) Status() error { return newOrch().CobblerStatus() }
+
+// Precycle runs the pre-cycle analysis and writes analysis.yaml to the cobbler directory.
+func (Cobbler) Precycle() { newOrch().RunPreCycleAnalysis() }
+
+// PrecycleJson runs the pre-cycle analysis and prints a JSON summary of its counts.
+func (Cobbler) PrecycleJson() error { return newOrch().RunPreCycleAnalysisJSON() }
+
+// Log prints the proposed issues from the last measure run as a table.
+func (Cobbler) Log() error { return newOrch().PrintMeasureLog() }
+
+// Replay re-runs a recorded stitch invocation of an issue with its saved prompt (index 0 is the oldest).
+func (Cobbler) Replay(issue string, index int) error {
+	_, err := newOrch().ReplayInvocation(issue, index)
+	return err
+}
+
+// Revalidate re-runs measure validation over all recorded measure issues.
+func (Cobbler) Revalidate() error { return newOrch().RevalidateMeasure() }
+
 // --- Generator targets ---
 
 // Start begins a new generation trail.
@@ -266,22 +317,59 @@ func (Generator) Switch() error { return newOrch().GeneratorSwitch() }
 // Reset destroys generation branches, worktrees, and Go source directories.
 func (Generator) Reset() error { return newOrch().GeneratorReset() }
 
+// StaleSeeds lists previously seeded files no longer configured in seed_files, without deleting them.
+func (Generator) StaleSeeds() error { return newOrch().UnreferencedSeedFiles() }
+
+// Log prints a chronological table of all Claude invocations in a generation
+// (e.g., mage generator:log generation-2026-03-01-10-00-00).
+func (Generator) Log(gen string) error { return newOrch().GenerationLog(gen) }
+
 // --- Stats targets ---
 
 // Loc prints Go lines of code and documentation word counts.
 func (Stats) Loc() error { return newOrch().Stats() }
 
+// Json prints Go lines of code and documentation word counts as timestamped JSON.
+func (Stats) Json() error { return newOrch().StatsJSON() }
+
+// TestRatio fails when Go test LOC divided by production LOC is below cobbler.min_test_ratio.
+func (Stats) TestRatio() error { return newOrch().CheckTestRatio() }
+
 // Tokens enumerates prompt-attached files and counts tokens via the Anthropic API.
 func (Stats) Tokens() error { return newOrch().TokenStats() }
 
+// Context lists the files the orchestrator treats as prompt context, grouped by category with byte sizes.
+func (Stats) Context() error { return newOrch().ContextInventory() }
+
 // Outcomes prints a summary table of task outcome trailers from git history.
 func (Stats) Outcomes() error { return newOrch().Outcomes() }
 
+// Csv writes all Claude invocations of a generation to a CSV file
+// (e.g., mage stats:csv generation-2026-03-01-10-00-00 invocations.csv).
+func (Stats) Csv(gen, path string) error { return newOrch().ExportInvocationsCSV(gen, path) }
+
+// Generation prints a summary of a generation: issues proposed and
+// implemented, tokens, wall-clock time, LOC added, and cost
+// (e.g., mage stats:generation generation-2026-03-01-10-00-00).
+func (Stats) Generation(gen string) error {
+	stats, err := newOrch().GenerationStats(gen)
+	if err != nil {
+		return err
+	}
+	return orchestrator.PrintGenerationStats(stats)
+}
+
+// Throughput prints a timeline of issues created per generation across all measure runs.
+func (Stats) Throughput() error { return newOrch().IssueThroughput() }
+
 // --- Prompt targets ---
 
 // Measure prints the assembled measure prompt to stdout.
 func (Prompt) Measure() error { return newOrch().DumpMeasurePrompt() }
 
+// MeasureFile writes the assembled measure prompt to the given file path.
+func (Prompt) MeasureFile(path string) error { return newOrch().WriteMeasurePrompt(path) }
+
 // Stitch prints the assembled stitch prompt to stdout.
 func (Prompt) Stitch() error { return newOrch().DumpStitchPrompt() }
 
@@ -327,3 +415,8 @@ func (Vscode) PopProfile(profile string) error { return newOrch().VscodePop(prof
 // Preview reads a constitution YAML file and prints its sections as markdown to stdout.
 // Pass the path to a constitution YAML file (e.g., mage constitution:preview pkg/orchestrator/constitutions/execution.yaml).
 func (Constitution) Preview(file string) error { return newOrch().ConstitutionPreviewFile(file) }
+
+// --- Config targets ---
+
+// Show prints the effective configuration (defaults and overrides applied) as YAML.
+func (Config) Show() error { return newOrch().ConfigShow() }
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -27,6 +28,11 @@ type AnalyzeResult struct {
 	BrokenCitations                []string // Touchpoints citing non-existent requirement groups in PRDs
 	InvalidReleases                []string // Configured releases not found in road-map.yaml
 	PRDsSpanningMultipleReleases   []string // PRDs referenced by use cases from more than one release
+	DuplicateTouchpointTargets     []string // Use cases whose touchpoints cite the same PRD requirement more than once
+	UnorderedReleases              []string // Adjacent roadmap releases not in ascending version order
+	UncitedRequirements            []string // PRD requirement groups no use case touchpoint cites
+	CollidingUseCaseIDs            []string // Roadmap use case IDs sharing a rel/uc prefix (e.g. rel01.0-uc001)
+	DanglingTestSuiteTraces        []string // Test suite traces naming use cases that don't exist
 }
 
 // analyzeCounts holds the artifact counts discovered during analysis.
@@ -45,7 +51,7 @@ func (o *Orchestrator) collectAnalyzeResult() (AnalyzeResult, analyzeCounts, err
 	result := AnalyzeResult{}
 
 	// 1. Load all PRDs
-	prdFiles, err := filepath.Glob("docs/specs/product-requirements/prd*.yaml")
+	prdFiles, err := filepath.Glob(filepath.Join(o.cfg.EffectivePRDDir(), "prd*.yaml"))
 	if err != nil {
 		return result, analyzeCounts{}, fmt.Errorf("globbing PRDs: %w", err)
 	}
@@ -56,7 +62,7 @@ func (o *Orchestrator) collectAnalyzeResult() (AnalyzeResult, analyzeCounts, err
 		if id != "" {
 			prdIDs[id] = true
 		}
-		if prd := loadYAML[PRDDoc](path); prd != nil {
+		if prd := loadSpecYAML[PRDDoc](path, o.cfg.EffectiveMaxSpecFileSize()); prd != nil {
 			groups := make(map[string]bool)
 			for groupKey := range prd.Requirements {
 				groups[groupKey] = true
@@ -67,7 +73,7 @@ func (o *Orchestrator) collectAnalyzeResult() (AnalyzeResult, analyzeCounts, err
 	logf("analyze: found %d PRDs", len(prdIDs))
 
 	// 2. Load all use cases
-	ucFiles, err := filepath.Glob("docs/specs/use-cases/rel*.yaml")
+	ucFiles, err := filepath.Glob(filepath.Join(o.cfg.EffectiveUseCaseDir(), "rel*.yaml"))
 	if err != nil {
 		return result, analyzeCounts{}, fmt.Errorf("globbing use cases: %w", err)
 	}
@@ -97,7 +103,7 @@ func (o *Orchestrator) collectAnalyzeResult() (AnalyzeResult, analyzeCounts, err
 	logf("analyze: found %d use cases", len(ucIDs))
 
 	// 3. Load all test suites (per-release YAML specs)
-	testFiles, err := filepath.Glob("docs/specs/test-suites/test-rel*.yaml")
+	testFiles, err := filepath.Glob(filepath.Join(o.cfg.EffectiveTestSuiteDir(), "test-rel*.yaml"))
 	if err != nil {
 		return result, analyzeCounts{}, fmt.Errorf("globbing test suites: %w", err)
 	}
@@ -117,7 +123,9 @@ func (o *Orchestrator) collectAnalyzeResult() (AnalyzeResult, analyzeCounts, err
 	// 4. Load road-map.yaml — collect release IDs and use case IDs
 	roadmapUCs := make(map[string]bool)
 	roadmapReleaseIDs := make(map[string]bool)
-	if data, err := os.ReadFile("docs/road-map.yaml"); err == nil {
+	var roadmapOrder []string   // all release versions in file order
+	var roadmapUCOrder []string // all use case IDs in file order
+	if data, err := os.ReadFile(o.cfg.EffectiveRoadmapFile()); err == nil {
 		var roadmap struct {
 			Releases []struct {
 				ID       string `yaml:"version"`
@@ -128,6 +136,7 @@ func (o *Orchestrator) collectAnalyzeResult() (AnalyzeResult, analyzeCounts, err
 		}
 		if err := yaml.Unmarshal(data, &roadmap); err == nil {
 			for _, release := range roadmap.Releases {
+				roadmapOrder = append(roadmapOrder, release.ID)
 				// Only track releases that have use cases; empty
 				// buckets (e.g. 99.0 Unscheduled) don't need test suites.
 				if len(release.UseCases) > 0 {
@@ -135,6 +144,7 @@ func (o *Orchestrator) collectAnalyzeResult() (AnalyzeResult, analyzeCounts, err
 				}
 				for _, uc := range release.UseCases {
 					roadmapUCs[uc.ID] = true
+					roadmapUCOrder = append(roadmapUCOrder, uc.ID)
 				}
 			}
 			logf("analyze: found %d releases, %d use cases in roadmap", len(roadmapReleaseIDs), len(roadmapUCs))
@@ -142,12 +152,11 @@ func (o *Orchestrator) collectAnalyzeResult() (AnalyzeResult, analyzeCounts, err
 	}
 
 	// Check 0: Configured releases exist in road-map.yaml
-	for _, r := range o.cfg.Project.Releases {
-		if !roadmapReleaseIDs[r] {
-			result.InvalidReleases = append(result.InvalidReleases,
-				fmt.Sprintf("configured release %q not found in road-map.yaml", r))
-		}
+	roadmapVersions := make(map[string]bool, len(roadmapOrder))
+	for _, v := range roadmapOrder {
+		roadmapVersions[v] = true
 	}
+	result.InvalidReleases = invalidReleases(o.cfg.Project, roadmapVersions, o.cfg.EffectiveRoadmapFile())
 
 	// Check 1: Orphaned PRDs (no use case references them)
 	prdReferencedByUC := make(map[string]bool)
@@ -216,6 +225,14 @@ func (o *Orchestrator) collectAnalyzeResult() (AnalyzeResult, analyzeCounts, err
 	}
 	logf("analyze: broken citations found %d", len(result.BrokenCitations))
 
+	// Check 12: Uncited requirements (requirement group in a PRD that no
+	// use case cites, opt-in). Orphaned PRDs are skipped; Check 1
+	// reports them.
+	if o.cfg.Project.CheckUncitedRequirements {
+		result.UncitedRequirements = detectUncitedRequirements(prdReqGroups, ucTouchpoints, prdReferencedByUC)
+		logf("analyze: uncited requirements found %d", len(result.UncitedRequirements))
+	}
+
 	// Check 9: PRDs spanning multiple releases
 	for prdID, releases := range prdToReleases {
 		if len(releases) > 1 {
@@ -231,6 +248,34 @@ func (o *Orchestrator) collectAnalyzeResult() (AnalyzeResult, analyzeCounts, err
 	sort.Strings(result.PRDsSpanningMultipleReleases)
 	logf("analyze: PRDs spanning multiple releases found %d", len(result.PRDsSpanningMultipleReleases))
 
+	// Check 10: Duplicate touchpoint targets within a use case (opt-in)
+	if o.cfg.Project.CheckDuplicateTouchpoints {
+		for ucID, tps := range ucTouchpoints {
+			for _, dup := range detectDuplicateTouchpointTargets(tps) {
+				result.DuplicateTouchpointTargets = append(result.DuplicateTouchpointTargets,
+					fmt.Sprintf("%s: %s", ucID, dup))
+			}
+		}
+		sort.Strings(result.DuplicateTouchpointTargets)
+		logf("analyze: duplicate touchpoint targets found %d", len(result.DuplicateTouchpointTargets))
+	}
+
+	// Check 11: Roadmap releases in ascending version order (opt-in)
+	if o.cfg.Project.CheckRoadmapOrder {
+		result.UnorderedReleases = detectUnorderedReleases(roadmapOrder)
+		logf("analyze: out-of-order roadmap releases found %d", len(result.UnorderedReleases))
+	}
+
+	// Check 13: Roadmap use case IDs that share a rel/uc prefix map to the
+	// same tests/ directory and are double-counted by CodeStatus.
+	result.CollidingUseCaseIDs = detectUCPrefixCollisions(roadmapUCOrder)
+	logf("analyze: colliding use case IDs found %d", len(result.CollidingUseCaseIDs))
+
+	// Check 14: Test suite traces naming use cases that don't exist. Check 3
+	// only catches suites where no trace resolves.
+	result.DanglingTestSuiteTraces = detectDanglingTraces(testSuiteToUCs, ucIDs)
+	logf("analyze: dangling test suite traces found %d", len(result.DanglingTestSuiteTraces))
+
 	// Check 7: YAML schema validation — load all docs into typed structs
 	// with strict field checking. Unknown YAML fields indicate a schema
 	// mismatch that will cause data loss during measure prompt assembly.
@@ -286,6 +331,11 @@ func (r AnalyzeResult) printReport(prdCount, ucCount, tsCount int) error {
 	hasIssues = printSection("Broken citations (touchpoint cites non-existent requirement group)", r.BrokenCitations) || hasIssues
 	hasIssues = printSection("Invalid configured releases (not found in road-map.yaml)", r.InvalidReleases) || hasIssues
 	hasIssues = printSection("PRDs spanning multiple releases (each PRD must belong to exactly one release)", r.PRDsSpanningMultipleReleases) || hasIssues
+	hasIssues = printSection("Duplicate touchpoint targets (same PRD requirement cited by more than one touchpoint)", r.DuplicateTouchpointTargets) || hasIssues
+	hasIssues = printSection("Roadmap releases out of order (releases must be listed in ascending version order)", r.UnorderedReleases) || hasIssues
+	hasIssues = printSection("Uncited requirements (PRD requirement group no use case cites)", r.UncitedRequirements) || hasIssues
+	hasIssues = printSection("Colliding use case IDs (different IDs with the same rel/uc number share a test directory)", r.CollidingUseCaseIDs) || hasIssues
+	hasIssues = printSection("Dangling test suite traces (trace names a use case that does not exist)", r.DanglingTestSuiteTraces) || hasIssues
 
 	if !hasIssues {
 		fmt.Printf("\n✅ All consistency checks passed\n")
@@ -455,6 +505,197 @@ func extractCitationsFromTouchpoints(touchpoints []string) []prdCitation {
 	return citations
 }
 
+// invalidReleases returns a finding for each release in the configured
+// scope whose version is not in known. Project.Release is checked only
+// when Project.Releases is empty, matching measureReleasesConstraint.
+// Analyze Check 0 and the measure prompt warning share it.
+func invalidReleases(project ProjectConfig, known map[string]bool, roadmapPath string) []string {
+	releases, field := project.Releases, "project.releases"
+	if len(releases) == 0 && project.Release != "" {
+		releases, field = []string{project.Release}, "project.release"
+	}
+	var findings []string
+	for _, r := range releases {
+		if !known[r] {
+			findings = append(findings, fmt.Sprintf("%s: release %q not found in %s", field, r, roadmapPath))
+		}
+	}
+	return findings
+}
+
+// detectUncitedRequirements returns "prdID group" for every requirement
+// group declared in a PRD that no use case touchpoint cites, sorted. PRDs
+// not in referenced (no use case cites them at all) are skipped so an
+// orphaned PRD is reported once, not once per group.
+func detectUncitedRequirements(prdReqGroups map[string]map[string]bool, ucTouchpoints map[string][]string, referenced map[string]bool) []string {
+	cited := make(map[string]map[string]bool)
+	for _, tps := range ucTouchpoints {
+		for _, cite := range extractCitationsFromTouchpoints(tps) {
+			if cited[cite.PRDID] == nil {
+				cited[cite.PRDID] = make(map[string]bool)
+			}
+			for _, group := range cite.Groups {
+				cited[cite.PRDID][group] = true
+			}
+		}
+	}
+	var uncited []string
+	for prdID, groups := range prdReqGroups {
+		if !referenced[prdID] {
+			continue
+		}
+		for group := range groups {
+			if !cited[prdID][group] {
+				uncited = append(uncited, prdID+" "+group)
+			}
+		}
+	}
+	sort.Strings(uncited)
+	return uncited
+}
+
+// reqRefRe matches a full requirement reference like "R1" or "R2.8".
+var reqRefRe = regexp.MustCompile(`^R\d+(\.\d+)*`)
+
+// detectDuplicateTouchpointTargets reports PRD requirement references
+// that appear in more than one touchpoint of the same use case, e.g.
+// both T1 and T2 citing "prd001-core R1". Targets are compared on the
+// full reference, so "prd001-core R1" and "prd001-core R2" (or "R1.1" and
+// "R1.2") are distinct. Each result has the form
+// "prd001-core R1 (T1, T2)", in order of first appearance.
+func detectDuplicateTouchpointTargets(touchpoints []string) []string {
+	seenIn := make(map[string][]string) // target -> touchpoint labels
+	var order []string
+	for i, tp := range touchpoints {
+		label := fmt.Sprintf("T%d", i+1)
+		if idx := strings.Index(tp, ":"); idx > 0 && !strings.ContainsAny(tp[:idx], " \t") {
+			label = tp[:idx]
+		}
+		prdID := ""
+		var targets []string
+		inTouchpoint := make(map[string]bool)
+		for _, part := range strings.Fields(tp) {
+			cleaned := strings.TrimLeft(part, "(")
+			cleaned = strings.TrimRight(cleaned, "),.")
+			if strings.HasPrefix(cleaned, "prd") {
+				prdID = cleaned
+				continue
+			}
+			if prdID == "" {
+				continue
+			}
+			ref := reqRefRe.FindString(cleaned)
+			if ref == "" {
+				continue
+			}
+			target := prdID + " " + ref
+			if !inTouchpoint[target] {
+				inTouchpoint[target] = true
+				targets = append(targets, target)
+			}
+		}
+		for _, target := range targets {
+			if _, ok := seenIn[target]; !ok {
+				order = append(order, target)
+			}
+			seenIn[target] = append(seenIn[target], label)
+		}
+	}
+
+	var dups []string
+	for _, target := range order {
+		labels := seenIn[target]
+		if len(labels) > 1 {
+			dups = append(dups, fmt.Sprintf("%s (%s)", target, strings.Join(labels, ", ")))
+		}
+	}
+	return dups
+}
+
+// compareReleaseVersions compares two release versions such as "02.0"
+// and "10.0" segment by segment, numerically where both segments are
+// integers, so "2.0" < "10.0" regardless of zero padding. Returns -1, 0,
+// or 1.
+func compareReleaseVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y string
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		xn, xerr := strconv.Atoi(orDefault(x, "0"))
+		yn, yerr := strconv.Atoi(orDefault(y, "0"))
+		switch {
+		case xerr == nil && yerr == nil:
+			if xn != yn {
+				if xn < yn {
+					return -1
+				}
+				return 1
+			}
+		case x != y:
+			return strings.Compare(x, y)
+		}
+	}
+	return 0
+}
+
+// detectUnorderedReleases reports each adjacent pair of roadmap release
+// versions where the earlier entry has a higher version than the later.
+func detectUnorderedReleases(versions []string) []string {
+	var out []string
+	for i := 1; i < len(versions); i++ {
+		if compareReleaseVersions(versions[i-1], versions[i]) > 0 {
+			out = append(out, fmt.Sprintf("release %s listed before %s", versions[i-1], versions[i]))
+		}
+	}
+	return out
+}
+
+// detectUCPrefixCollisions groups the distinct use case IDs by
+// ucPrefixFromID and reports each prefix claimed by more than one ID, as
+// "rel01.0-uc001: rel01.0-uc001-init, rel01.0-uc001-setup". IDs without a
+// rel/uc prefix are ignored.
+func detectUCPrefixCollisions(ucIDs []string) []string {
+	byPrefix := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, id := range ucIDs {
+		prefix := ucPrefixFromID(id)
+		if prefix == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		byPrefix[prefix] = append(byPrefix[prefix], id)
+	}
+	var out []string
+	for prefix, ids := range byPrefix {
+		if len(ids) > 1 {
+			out = append(out, fmt.Sprintf("%s: %s", prefix, strings.Join(ids, ", ")))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// detectDanglingTraces reports every use case ID traced by a test suite
+// that is not in ucIDs, as "test-rel01.0 -> rel01.0-uc009-gone (missing)",
+// sorted.
+func detectDanglingTraces(testSuiteToUCs map[string][]string, ucIDs map[string]bool) []string {
+	var out []string
+	for testSuiteID, traces := range testSuiteToUCs {
+		for _, ucID := range traces {
+			if !ucIDs[ucID] {
+				out = append(out, fmt.Sprintf("%s -> %s (missing)", testSuiteID, ucID))
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
 // validateDocSchemas resolves configured context sources and validates
 // each file against its typed struct using strict YAML decoding
 // (KnownFields). Any YAML key that doesn't map to a struct field is
@@ -7,6 +7,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -262,6 +264,41 @@ func TestExtractCitationsFromTouchpoints_NoPRD(t *testing.T) {
 	}
 }
 
+// --- detectDuplicateTouchpointTargets ---
+
+func TestDetectDuplicateTouchpointTargets_Duplicate(t *testing.T) {
+	tps := []string{
+		"T1: Config: prd001-core R1",
+		"T2: Loader: prd001-core R1, R2",
+	}
+	got := detectDuplicateTouchpointTargets(tps)
+	if len(got) != 1 {
+		t.Fatalf("got %v, want 1 duplicate", got)
+	}
+	if got[0] != "prd001-core R1 (T1, T2)" {
+		t.Errorf("got %q, want %q", got[0], "prd001-core R1 (T1, T2)")
+	}
+}
+
+func TestDetectDuplicateTouchpointTargets_DistinctTargets(t *testing.T) {
+	tps := []string{
+		"T1: Config: prd001-core R1",
+		"T2: Loader: prd001-core R2",
+		"T3: Tags: prd001-core R3.1, prd002-lifecycle R1",
+		"T4: Tags: prd001-core R3.2",
+	}
+	if got := detectDuplicateTouchpointTargets(tps); len(got) != 0 {
+		t.Errorf("expected no duplicates, got %v", got)
+	}
+}
+
+func TestDetectDuplicateTouchpointTargets_RepeatWithinOneTouchpoint(t *testing.T) {
+	tps := []string{"T1: Start: prd002-lifecycle R2 (see R2)"}
+	if got := detectDuplicateTouchpointTargets(tps); len(got) != 0 {
+		t.Errorf("repeat within a single touchpoint should not be flagged, got %v", got)
+	}
+}
+
 // --- detectConstitutionDrift ---
 
 func TestDetectConstitutionDrift_Matching(t *testing.T) {
@@ -1086,3 +1123,279 @@ func TestAnalyze_EmptyDocs(t *testing.T) {
 		o.Analyze()
 	})
 }
+
+func TestCollectAnalyzeResult_DuplicateTouchpointTargets(t *testing.T) {
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	defer os.Chdir(orig)
+
+	os.MkdirAll("docs/specs/product-requirements", 0o755)
+	os.MkdirAll("docs/specs/use-cases", 0o755)
+	os.MkdirAll("docs/specs/test-suites", 0o755)
+
+	os.WriteFile("docs/specs/product-requirements/prd001-core.yaml",
+		[]byte("id: prd001-core\ntitle: Core\nrequirements:\n  R1:\n    title: Req 1\n  R2:\n    title: Req 2\n"), 0o644)
+	os.WriteFile("docs/specs/use-cases/rel01.0-uc001-dup.yaml",
+		[]byte("id: rel01.0-uc001-dup\ntitle: Dup\ntouchpoints:\n  - T1: prd001-core R1\n  - T2: prd001-core R1\n"), 0o644)
+	os.WriteFile("docs/specs/use-cases/rel01.0-uc002-ok.yaml",
+		[]byte("id: rel01.0-uc002-ok\ntitle: OK\ntouchpoints:\n  - T1: prd001-core R1\n  - T2: prd001-core R2\n"), 0o644)
+	os.WriteFile("docs/road-map.yaml", []byte("id: rm\ntitle: RM\nreleases: []\n"), 0o644)
+
+	o := &Orchestrator{cfg: Config{Project: ProjectConfig{CheckDuplicateTouchpoints: true}}}
+	result, _, err := o.collectAnalyzeResult()
+	if err != nil {
+		t.Fatalf("collectAnalyzeResult: %v", err)
+	}
+	want := []string{"rel01.0-uc001-dup: prd001-core R1 (T1, T2)"}
+	if len(result.DuplicateTouchpointTargets) != 1 || result.DuplicateTouchpointTargets[0] != want[0] {
+		t.Errorf("DuplicateTouchpointTargets = %v, want %v", result.DuplicateTouchpointTargets, want)
+	}
+
+	details := collectConsistencyDetails(&result)
+	found := false
+	for _, d := range details {
+		if d == "duplicate touchpoint target: "+want[0] {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("consistency details missing duplicate touchpoint entry: %v", details)
+	}
+}
+
+func TestCollectAnalyzeResult_TouchpointCoverageDisabledByDefault(t *testing.T) {
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	defer os.Chdir(orig)
+
+	os.MkdirAll("docs/specs/product-requirements", 0o755)
+	os.MkdirAll("docs/specs/use-cases", 0o755)
+	os.MkdirAll("docs/specs/test-suites", 0o755)
+
+	os.WriteFile("docs/specs/product-requirements/prd001-core.yaml",
+		[]byte("id: prd001-core\ntitle: Core\nrequirements:\n  R1:\n    title: Req 1\n  R2:\n    title: Req 2\n"), 0o644)
+	os.WriteFile("docs/specs/use-cases/rel01.0-uc001-dup.yaml",
+		[]byte("id: rel01.0-uc001-dup\ntitle: Dup\ntouchpoints:\n  - T1: prd001-core R1\n  - T2: prd001-core R1\n"), 0o644)
+	os.WriteFile("docs/road-map.yaml", []byte("id: rm\ntitle: RM\nreleases: []\n"), 0o644)
+
+	o := &Orchestrator{cfg: Config{}}
+	result, _, err := o.collectAnalyzeResult()
+	if err != nil {
+		t.Fatalf("collectAnalyzeResult: %v", err)
+	}
+	if len(result.DuplicateTouchpointTargets) != 0 {
+		t.Errorf("DuplicateTouchpointTargets = %v, want none when CheckDuplicateTouchpoints is off", result.DuplicateTouchpointTargets)
+	}
+	if len(result.UncitedRequirements) != 0 {
+		t.Errorf("UncitedRequirements = %v, want none when CheckUncitedRequirements is off", result.UncitedRequirements)
+	}
+}
+
+func TestCollectAnalyzeResult_UncitedRequirements(t *testing.T) {
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	defer os.Chdir(orig)
+
+	os.MkdirAll("docs/specs/product-requirements", 0o755)
+	os.MkdirAll("docs/specs/use-cases", 0o755)
+	os.MkdirAll("docs/specs/test-suites", 0o755)
+
+	os.WriteFile("docs/specs/product-requirements/prd001-core.yaml",
+		[]byte("id: prd001-core\ntitle: Core\nrequirements:\n  R1:\n    title: Req 1\n  R2:\n    title: Req 2\n"), 0o644)
+	os.WriteFile("docs/specs/product-requirements/prd002-orphan.yaml",
+		[]byte("id: prd002-orphan\ntitle: Orphan\nrequirements:\n  R1:\n    title: Req 1\n"), 0o644)
+	os.WriteFile("docs/specs/use-cases/rel01.0-uc001-core.yaml",
+		[]byte("id: rel01.0-uc001-core\ntitle: Core\ntouchpoints:\n  - T1: prd001-core R1.2\n"), 0o644)
+	os.WriteFile("docs/road-map.yaml", []byte("id: rm\ntitle: RM\nreleases: []\n"), 0o644)
+
+	o := &Orchestrator{cfg: Config{Project: ProjectConfig{CheckUncitedRequirements: true}}}
+	result, _, err := o.collectAnalyzeResult()
+	if err != nil {
+		t.Fatalf("collectAnalyzeResult: %v", err)
+	}
+	want := "prd001-core R2"
+	if len(result.UncitedRequirements) != 1 || result.UncitedRequirements[0] != want {
+		t.Errorf("UncitedRequirements = %v, want [%s] (orphaned prd002 reported only as orphaned)", result.UncitedRequirements, want)
+	}
+
+	details := collectConsistencyDetails(&result)
+	if !slices.Contains(details, "uncited requirement: "+want) {
+		t.Errorf("consistency details missing uncited requirement entry: %v", details)
+	}
+}
+
+// --- roadmap release order ---
+
+func TestCompareReleaseVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"01.0", "02.0", -1},
+		{"02.0", "10.0", -1},
+		{"2.0", "10.0", -1},
+		{"10.0", "02.0", 1},
+		{"01.0", "01.0", 0},
+		{"01.1", "01.0", 1},
+		{"01", "01.0", 0},
+	}
+	for _, tc := range cases {
+		if got := compareReleaseVersions(tc.a, tc.b); got != tc.want {
+			t.Errorf("compareReleaseVersions(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestCollectAnalyzeResult_RoadmapOrder(t *testing.T) {
+	cases := []struct {
+		name    string
+		roadmap string
+		want    []string
+	}{
+		{
+			name:    "ordered",
+			roadmap: "releases:\n  - version: \"01.0\"\n  - version: \"02.0\"\n  - version: \"10.0\"\n",
+		},
+		{
+			name:    "misordered",
+			roadmap: "releases:\n  - version: \"01.0\"\n  - version: \"10.0\"\n  - version: \"02.0\"\n",
+			want:    []string{"release 10.0 listed before 02.0"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			orig, _ := os.Getwd()
+			os.Chdir(dir)
+			defer os.Chdir(orig)
+
+			os.MkdirAll("docs", 0o755)
+			os.WriteFile("docs/road-map.yaml", []byte(tc.roadmap), 0o644)
+
+			o := &Orchestrator{cfg: Config{Project: ProjectConfig{CheckRoadmapOrder: true}}}
+			result, _, err := o.collectAnalyzeResult()
+			if err != nil {
+				t.Fatalf("collectAnalyzeResult: %v", err)
+			}
+			if !reflect.DeepEqual(result.UnorderedReleases, tc.want) {
+				t.Errorf("UnorderedReleases = %v, want %v", result.UnorderedReleases, tc.want)
+			}
+		})
+	}
+}
+
+func TestCollectAnalyzeResult_RoadmapOrderDisabledByDefault(t *testing.T) {
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	defer os.Chdir(orig)
+
+	os.MkdirAll("docs", 0o755)
+	os.WriteFile("docs/road-map.yaml", []byte("releases:\n  - version: \"02.0\"\n  - version: \"01.0\"\n"), 0o644)
+
+	o := &Orchestrator{cfg: Config{}}
+	result, _, err := o.collectAnalyzeResult()
+	if err != nil {
+		t.Fatalf("collectAnalyzeResult: %v", err)
+	}
+	if len(result.UnorderedReleases) != 0 {
+		t.Errorf("expected check disabled by default, got %v", result.UnorderedReleases)
+	}
+}
+
+func TestDetectUCPrefixCollisions(t *testing.T) {
+	t.Parallel()
+	got := detectUCPrefixCollisions([]string{
+		"rel01.0-uc001-init",
+		"rel01.0-uc002-run",
+		"rel01.0-uc001-setup",
+		"rel01.0-uc002-run", // same ID listed twice is not a collision
+		"not-a-uc",
+	})
+	want := []string{"rel01.0-uc001: rel01.0-uc001-init, rel01.0-uc001-setup"}
+	if !slices.Equal(got, want) {
+		t.Errorf("detectUCPrefixCollisions() = %v, want %v", got, want)
+	}
+}
+
+func TestCollectAnalyzeResult_CollidingUseCaseIDs(t *testing.T) {
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	defer os.Chdir(orig)
+
+	os.MkdirAll("docs/specs/product-requirements", 0o755)
+	os.MkdirAll("docs/specs/use-cases", 0o755)
+	os.MkdirAll("docs/specs/test-suites", 0o755)
+	os.WriteFile("docs/road-map.yaml", []byte(`id: rm
+title: RM
+releases:
+  - version: "01.0"
+    name: Core
+    status: done
+    use_cases:
+      - id: rel01.0-uc001-init
+        status: done
+      - id: rel01.0-uc001-setup
+        status: done
+`), 0o644)
+
+	o := &Orchestrator{cfg: Config{}}
+	result, _, err := o.collectAnalyzeResult()
+	if err != nil {
+		t.Fatalf("collectAnalyzeResult: %v", err)
+	}
+	want := "rel01.0-uc001: rel01.0-uc001-init, rel01.0-uc001-setup"
+	if len(result.CollidingUseCaseIDs) != 1 || result.CollidingUseCaseIDs[0] != want {
+		t.Errorf("CollidingUseCaseIDs = %v, want [%s]", result.CollidingUseCaseIDs, want)
+	}
+
+	details := collectConsistencyDetails(&result)
+	if !slices.Contains(details, "colliding use case ID: "+want) {
+		t.Errorf("consistency details missing colliding use case entry: %v", details)
+	}
+}
+
+func TestCollectAnalyzeResult_DanglingTestSuiteTraces(t *testing.T) {
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	defer os.Chdir(orig)
+
+	os.MkdirAll("docs/specs/product-requirements", 0o755)
+	os.MkdirAll("docs/specs/use-cases", 0o755)
+	os.MkdirAll("docs/specs/test-suites", 0o755)
+	os.WriteFile("docs/specs/use-cases/rel01.0-uc001-init.yaml",
+		[]byte("id: rel01.0-uc001-init\ntitle: Init\n"), 0o644)
+	// One trace resolves, so the suite is not orphaned; the other names a
+	// use case that does not exist.
+	os.WriteFile("docs/specs/test-suites/test-rel01.0.yaml",
+		[]byte("id: test-rel01.0\ntitle: Release 01.0\ntraces:\n  - rel01.0-uc001-init\n  - rel01.0-uc009-gone\n"), 0o644)
+	os.WriteFile("docs/road-map.yaml", []byte("id: rm\ntitle: RM\nreleases: []\n"), 0o644)
+
+	o := &Orchestrator{cfg: Config{}}
+	result, _, err := o.collectAnalyzeResult()
+	if err != nil {
+		t.Fatalf("collectAnalyzeResult: %v", err)
+	}
+	want := "test-rel01.0 -> rel01.0-uc009-gone (missing)"
+	if len(result.DanglingTestSuiteTraces) != 1 || result.DanglingTestSuiteTraces[0] != want {
+		t.Errorf("DanglingTestSuiteTraces = %v, want [%s]", result.DanglingTestSuiteTraces, want)
+	}
+	if len(result.OrphanedTestSuites) != 0 {
+		t.Errorf("OrphanedTestSuites = %v, want none (one trace resolves)", result.OrphanedTestSuites)
+	}
+
+	details := collectConsistencyDetails(&result)
+	if !slices.Contains(details, "dangling test suite trace: "+want) {
+		t.Errorf("consistency details missing dangling trace entry: %v", details)
+	}
+	byFile := o.ConsistencyDetailsByFile(&result)
+	key := filepath.Join(o.cfg.EffectiveTestSuiteDir(), "test-rel01.0.yaml")
+	if !slices.Contains(byFile[key], "dangling test suite trace: "+want) {
+		t.Errorf("dangling trace not grouped under %s: %v", key, byFile)
+	}
+}
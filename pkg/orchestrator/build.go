@@ -83,6 +83,25 @@ func (o *Orchestrator) DumpMeasurePrompt() error {
 	return nil
 }
 
+// WriteMeasurePrompt assembles the measure prompt for the current config
+// and writes it to path, creating parent directories as needed. Saving
+// prompts to files allows golden-file review of prompt changes across
+// config variations. Template errors from buildMeasurePrompt are returned.
+func (o *Orchestrator) WriteMeasurePrompt(path string) error {
+	prompt, err := o.buildMeasurePrompt("", "[]", 1)
+	if err != nil {
+		return fmt.Errorf("building measure prompt: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, []byte(prompt), 0o644); err != nil {
+		return fmt.Errorf("writing measure prompt: %w", err)
+	}
+	logf("measure prompt written to %s (%d bytes)", path, len(prompt))
+	return nil
+}
+
 // DumpStitchPrompt assembles and prints the stitch prompt to stdout.
 // Uses a placeholder task so the template structure is visible.
 func (o *Orchestrator) DumpStitchPrompt() error {
@@ -132,6 +132,41 @@ func TestDumpMeasurePrompt_ReturnsError_WhenPromptBuildFails(t *testing.T) {
 	}
 }
 
+func TestWriteMeasurePrompt_WritesFileInNestedDir(t *testing.T) {
+	dir := t.TempDir()
+	cfg := Config{}
+	cfg.Cobbler.Dir = dir
+	o := New(cfg)
+
+	path := filepath.Join(dir, "golden", "variant-a", "measure.yaml")
+	if err := o.WriteMeasurePrompt(path); err != nil {
+		t.Fatalf("WriteMeasurePrompt: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading written prompt: %v", err)
+	}
+	want, _ := o.buildMeasurePrompt("", "[]", 1)
+	if string(data) != want {
+		t.Error("written prompt differs from buildMeasurePrompt output")
+	}
+}
+
+func TestWriteMeasurePrompt_ReturnsTemplateError(t *testing.T) {
+	dir := t.TempDir()
+	cfg := Config{}
+	cfg.Cobbler.MeasurePrompt = "role: [unclosed bracket"
+	o := New(cfg)
+
+	path := filepath.Join(dir, "measure.yaml")
+	if err := o.WriteMeasurePrompt(path); err == nil {
+		t.Error("WriteMeasurePrompt() expected error for invalid template, got nil")
+	}
+	if _, err := os.Stat(path); err == nil {
+		t.Error("no file should be written when the template is invalid")
+	}
+}
+
 func TestDumpStitchPrompt_ProducesOutput(t *testing.T) {
 	// DumpStitchPrompt should succeed with default embedded templates.
 	o := New(Config{})
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Claude output formats accepted by ClaudeConfig.OutputFormat.
+const (
+	claudeOutputStreamJSON = "stream-json"
+	claudeOutputText       = "text"
+	claudeOutputAuto       = "auto"
+)
+
+// parseClaudeOutput extracts token usage from Claude's output in the given
+// format. "stream-json" (and "", the default) uses parseClaudeTokens,
+// "text" uses parseClaudeTextTokens, and "auto" picks one with
+// detectClaudeOutputFormat. Config.Validate rejects other formats.
+func parseClaudeOutput(output []byte, format string) ClaudeResult {
+	if resolveClaudeOutputFormat(output, format) == claudeOutputText {
+		return parseClaudeTextTokens(output)
+	}
+	return parseClaudeTokens(output)
+}
+
+// extractClaudeText returns the response text of Claude's output in the
+// given format: the assistant text blocks of stream-json output
+// (extractTextFromStreamJSON), or the whole output in text format.
+func extractClaudeText(output []byte, format string) string {
+	if resolveClaudeOutputFormat(output, format) == claudeOutputText {
+		return string(output)
+	}
+	return extractTextFromStreamJSON(output)
+}
+
+// resolveClaudeOutputFormat returns format, with "auto" replaced by the
+// format detectClaudeOutputFormat finds in output.
+func resolveClaudeOutputFormat(output []byte, format string) string {
+	if format == claudeOutputAuto {
+		return detectClaudeOutputFormat(output)
+	}
+	return format
+}
+
+// detectClaudeOutputFormat reports "stream-json" when any line of output
+// is a JSON object with a "type" field, and "text" otherwise.
+func detectClaudeOutputFormat(output []byte) string {
+	for line := range bytes.SplitSeq(output, []byte("\n")) {
+		var event struct {
+			Type string `json:"type"`
+		}
+		if json.Unmarshal(bytes.TrimSpace(line), &event) == nil && event.Type != "" {
+			return claudeOutputStreamJSON
+		}
+	}
+	return claudeOutputText
+}
+
+var (
+	// claudeTextUsageRe matches one count in Claude's human-readable usage
+	// summary, e.g. "12.3k input" in
+	// "Usage: 12.3k input, 1,024 output, 8k cache read, 0 cache write".
+	claudeTextUsageRe = regexp.MustCompile(`(?i)([\d.,]+[km]?)\s+(input|output|cache read|cache write)`)
+	// claudeTextCostRe matches the summary cost line, e.g.
+	// "Total cost: $0.0325".
+	claudeTextCostRe = regexp.MustCompile(`(?i)total cost:\s*\$([\d.]+)`)
+)
+
+// parseClaudeTextTokens extracts token usage from Claude's plain-text
+// output. It uses the last "Usage:" summary line and the last "Total
+// cost:" line; counts may carry a k or m suffix. As with stream-json,
+// InputTokens includes the cache creation and cache read tokens. Output
+// without a usage line yields a zero result.
+func parseClaudeTextTokens(output []byte) ClaudeResult {
+	var usage, cost string
+	for line := range strings.SplitSeq(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(strings.ToLower(line), "usage:") {
+			usage = line
+		}
+		if m := claudeTextCostRe.FindStringSubmatch(line); m != nil {
+			cost = m[1]
+		}
+	}
+	if usage == "" {
+		return ClaudeResult{}
+	}
+
+	var r ClaudeResult
+	base := 0
+	for _, m := range claudeTextUsageRe.FindAllStringSubmatch(usage, -1) {
+		n := parseTokenCount(m[1])
+		switch strings.ToLower(m[2]) {
+		case "input":
+			base = n
+		case "output":
+			r.OutputTokens = n
+		case "cache read":
+			r.CacheReadTokens = n
+		case "cache write":
+			r.CacheCreationTokens = n
+		}
+	}
+	r.InputTokens = base + r.CacheCreationTokens + r.CacheReadTokens
+	r.CostUSD, _ = strconv.ParseFloat(cost, 64)
+
+	logf("parseClaudeTextTokens: in=%d (base=%d cache_create=%d cache_read=%d) out=%d cost=$%.4f",
+		r.InputTokens, base, r.CacheCreationTokens, r.CacheReadTokens, r.OutputTokens, r.CostUSD)
+	return r
+}
+
+// parseTokenCount parses a token count such as "1,024", "12.3k", or
+// "1.5m". Unparsable input yields 0.
+func parseTokenCount(s string) int {
+	s = strings.ToLower(strings.ReplaceAll(s, ",", ""))
+	mult := 1.0
+	switch {
+	case strings.HasSuffix(s, "k"):
+		mult, s = 1e3, strings.TrimSuffix(s, "k")
+	case strings.HasSuffix(s, "m"):
+		mult, s = 1e6, strings.TrimSuffix(s, "m")
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return int(f*mult + 0.5)
+}
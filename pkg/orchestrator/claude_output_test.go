@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import "testing"
+
+const streamJSONSample = `{"type":"system","message":"ready"}
+{"type":"result","total_cost_usd":0.0325,"usage":{"input_tokens":1000,"output_tokens":500,"cache_creation_input_tokens":200,"cache_read_input_tokens":300}}
+`
+
+const textSample = `I updated the parser and added tests.
+
+Total cost:            $0.0325
+Total duration (API):  41.2s
+Usage:                 1,000 input, 500 output, 300 cache read, 200 cache write
+`
+
+// --- parseClaudeOutput ---
+
+func TestParseClaudeOutput_Formats(t *testing.T) {
+	t.Parallel()
+	want := ClaudeResult{InputTokens: 1500, OutputTokens: 500, CacheCreationTokens: 200, CacheReadTokens: 300, CostUSD: 0.0325}
+	tests := []struct {
+		name, format, output string
+	}{
+		{"stream-json", claudeOutputStreamJSON, streamJSONSample},
+		{"text", claudeOutputText, textSample},
+		{"auto stream-json", claudeOutputAuto, streamJSONSample},
+		{"auto text", claudeOutputAuto, textSample},
+	}
+	for _, tc := range tests {
+		got := parseClaudeOutput([]byte(tc.output), tc.format)
+		if got.InputTokens != want.InputTokens || got.OutputTokens != want.OutputTokens ||
+			got.CacheCreationTokens != want.CacheCreationTokens || got.CacheReadTokens != want.CacheReadTokens ||
+			got.CostUSD != want.CostUSD {
+			t.Errorf("%s: got %+v, want %+v", tc.name, got, want)
+		}
+	}
+}
+
+func TestParseClaudeOutput_TextUnderStreamJSONIsZero(t *testing.T) {
+	t.Parallel()
+	if got := parseClaudeOutput([]byte(textSample), claudeOutputStreamJSON); got.InputTokens != 0 {
+		t.Errorf("stream-json parser on text output: InputTokens = %d, want 0", got.InputTokens)
+	}
+}
+
+// --- extractClaudeText ---
+
+func TestExtractClaudeText_Formats(t *testing.T) {
+	t.Parallel()
+	streamJSON := `{"type":"assistant","message":{"content":[{"type":"text","text":"hello"}]}}` + "\n" + streamJSONSample
+	tests := []struct {
+		name, format, output, want string
+	}{
+		{"stream-json", claudeOutputStreamJSON, streamJSON, "hello"},
+		{"default", "", streamJSON, "hello"},
+		{"text", claudeOutputText, textSample, textSample},
+		{"auto stream-json", claudeOutputAuto, streamJSON, "hello"},
+		{"auto text", claudeOutputAuto, textSample, textSample},
+	}
+	for _, tc := range tests {
+		if got := extractClaudeText([]byte(tc.output), tc.format); got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+// --- parseClaudeTextTokens ---
+
+func TestParseClaudeTextTokens_SuffixedCounts(t *testing.T) {
+	t.Parallel()
+	got := parseClaudeTextTokens([]byte("Usage: 12.3k input, 1.5k output, 0 cache read, 0 cache write\n"))
+	if got.InputTokens != 12300 || got.OutputTokens != 1500 {
+		t.Errorf("got in=%d out=%d, want in=12300 out=1500", got.InputTokens, got.OutputTokens)
+	}
+}
+
+func TestParseClaudeTextTokens_NoUsageLine(t *testing.T) {
+	t.Parallel()
+	got := parseClaudeTextTokens([]byte("done\nTotal cost: $1.00\n"))
+	if got.InputTokens != 0 || got.OutputTokens != 0 || got.CostUSD != 0 {
+		t.Errorf("got %+v, want zero result without a usage line", got)
+	}
+}
@@ -52,8 +52,8 @@ func (o *Orchestrator) captureLOC() LocSnapshot {
 // every Claude invocation.
 type InvocationRecord struct {
 	Caller    string       `json:"caller"`
-	StartedAt string      `json:"started_at"`
-	DurationS int         `json:"duration_s"`
+	StartedAt string       `json:"started_at"`
+	DurationS int          `json:"duration_s"`
 	Tokens    claudeTokens `json:"tokens"`
 	LOCBefore LocSnapshot  `json:"loc_before"`
 	LOCAfter  LocSnapshot  `json:"loc_after"`
@@ -77,19 +77,21 @@ type diffRecord struct {
 // HistoryStats is the YAML-serializable stats file saved alongside prompt
 // and log artifacts in the history directory.
 type HistoryStats struct {
-	Caller    string       `yaml:"caller"`
-	TaskID    string       `yaml:"task_id,omitempty"`
-	TaskTitle string       `yaml:"task_title,omitempty"`
-	Status    string       `yaml:"status,omitempty"`
-	Error     string       `yaml:"error,omitempty"`
-	StartedAt string       `yaml:"started_at"`
-	Duration  string       `yaml:"duration"`
-	DurationS int          `yaml:"duration_s"`
-	Tokens    historyTokens `yaml:"tokens"`
-	CostUSD   float64      `yaml:"cost_usd"`
-	LOCBefore LocSnapshot  `yaml:"loc_before"`
-	LOCAfter  LocSnapshot  `yaml:"loc_after"`
-	Diff      historyDiff  `yaml:"diff"`
+	Caller     string        `yaml:"caller"`
+	Generation string        `yaml:"generation,omitempty"`
+	RunID      string        `yaml:"run_id,omitempty"`
+	TaskID     string        `yaml:"task_id,omitempty"`
+	TaskTitle  string        `yaml:"task_title,omitempty"`
+	Status     string        `yaml:"status,omitempty"`
+	Error      string        `yaml:"error,omitempty"`
+	StartedAt  string        `yaml:"started_at"`
+	Duration   string        `yaml:"duration"`
+	DurationS  int           `yaml:"duration_s"`
+	Tokens     historyTokens `yaml:"tokens"`
+	CostUSD    float64       `yaml:"cost_usd"`
+	LOCBefore  LocSnapshot   `yaml:"loc_before"`
+	LOCAfter   LocSnapshot   `yaml:"loc_after"`
+	Diff       historyDiff   `yaml:"diff"`
 }
 
 type historyTokens struct {
@@ -120,14 +122,21 @@ type StitchReport struct {
 }
 
 // historyDir returns the resolved history directory path. When HistoryDir is
-// relative it is joined with Cobbler.Dir so that history files live under the
-// cobbler scratch directory (e.g. ".cobbler/history").
+// relative it is joined with the artifacts directory (Cobbler.Dir unless
+// ArtifactsDir is set) so that history files live under it (e.g.
+// ".cobbler/history").
 func (o *Orchestrator) historyDir() string {
 	d := o.cfg.Cobbler.HistoryDir
 	if d == "" || filepath.IsAbs(d) {
 		return d
 	}
-	return filepath.Join(o.cfg.Cobbler.Dir, d)
+	return filepath.Join(o.cfg.EffectiveArtifactsDir(), d)
+}
+
+// measureLogPath returns the path of measure.yaml, the persistent list of
+// proposed issues, in the artifacts directory.
+func (o *Orchestrator) measureLogPath() string {
+	return filepath.Join(o.cfg.EffectiveArtifactsDir(), "measure.yaml")
 }
 
 // saveHistoryReport writes a stitch report YAML file to the history directory.
@@ -158,12 +167,27 @@ func (o *Orchestrator) saveHistoryReport(ts string, report StitchReport) {
 }
 
 // saveHistoryStats writes a stats YAML file to the history directory.
-// The file is named {ts}-{phase}-stats.yaml.
+// The file is named {ts}-{phase}-stats.yaml. The active generation is
+// recorded when stats.Generation is empty. An empty stats.Caller is
+// backfilled with a warning from the active workflow phase, or from phase
+// when none is active, so every record stays attributable.
 func (o *Orchestrator) saveHistoryStats(ts, phase string, stats HistoryStats) {
 	dir := o.historyDir()
 	if dir == "" {
 		return
 	}
+	if stats.Generation == "" {
+		stats.Generation = o.CurrentGeneration()
+	}
+	if stats.Caller == "" {
+		phaseMu.RLock()
+		stats.Caller = currentPhase
+		phaseMu.RUnlock()
+		if stats.Caller == "" {
+			stats.Caller = phase
+		}
+		logf("saveHistoryStats: warning: empty caller, recording as %q", stats.Caller)
+	}
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		logf("saveHistoryStats: mkdir %s: %v", dir, err)
 		return
@@ -459,24 +483,65 @@ func (o *Orchestrator) checkClaude() error {
 	return o.ensureCredentials()
 }
 
-// ensureCredentials checks that the credential file exists in SecretsDir.
-// If missing, it attempts to extract credentials from the macOS Keychain.
-// Returns an error if the file still does not exist after the attempt.
-func (o *Orchestrator) ensureCredentials() error {
-	credPath := filepath.Join(o.cfg.Claude.SecretsDir, o.cfg.EffectiveTokenFile())
+// containerTokenEnv is the environment variable the Claude CLI reads an
+// OAuth token from. Tokens taken from ClaudeConfig.TokenEnv are forwarded
+// into the container under this name.
+const containerTokenEnv = "CLAUDE_CODE_OAUTH_TOKEN"
+
+// credentialSource describes where Claude credentials come from: a file
+// on disk (Path) or the value of an environment variable (Token). At most
+// one field is set.
+type credentialSource struct {
+	Path  string
+	Token string
+}
+
+// selectCredentialSource picks the credential source. The file at credPath
+// wins when it exists; otherwise a non-empty value of the envName variable,
+// looked up through getenv, is used. The second return value is false when
+// neither is available.
+func selectCredentialSource(credPath, envName string, getenv func(string) string) (credentialSource, bool) {
 	if _, err := os.Stat(credPath); err == nil {
+		return credentialSource{Path: credPath}, true
+	}
+	if envName != "" {
+		if tok := getenv(envName); tok != "" {
+			return credentialSource{Token: tok}, true
+		}
+	}
+	return credentialSource{}, false
+}
+
+// credentialSource returns the credential source for this configuration.
+func (o *Orchestrator) credentialSource() (credentialSource, bool) {
+	credPath := filepath.Join(o.cfg.Claude.SecretsDir, o.cfg.EffectiveTokenFile())
+	return selectCredentialSource(credPath, o.cfg.Claude.TokenEnv, os.Getenv)
+}
+
+// ensureCredentials checks that the credential file exists in SecretsDir
+// or that the TokenEnv environment variable is set. If neither is
+// available, it attempts to extract credentials from the macOS Keychain.
+// Returns an error if no credentials are available after the attempt.
+func (o *Orchestrator) ensureCredentials() error {
+	if src, ok := o.credentialSource(); ok {
+		if src.Token != "" {
+			logf("ensureCredentials: using token from $%s", o.cfg.Claude.TokenEnv)
+		}
 		return nil
 	}
 
-	logf("ensureCredentials: %s not found, attempting keychain extraction", credPath)
+	credPath := filepath.Join(o.cfg.Claude.SecretsDir, o.cfg.EffectiveTokenFile())
+	logf("ensureCredentials: %s not found and $%s unset, attempting keychain extraction",
+		credPath, o.cfg.Claude.TokenEnv)
 	if err := o.ExtractCredentials(); err != nil {
 		logf("ensureCredentials: keychain extraction failed: %v", err)
 	}
 
 	if _, err := os.Stat(credPath); err != nil {
 		return fmt.Errorf("claude credentials not found at %s; "+
-			"run 'mage credentials' on the host or place a valid credential file at %s",
-			credPath, credPath)
+			"run 'mage credentials' on the host, place a valid credential file at %s, "+
+			"or set $%s",
+			credPath, credPath, o.cfg.Claude.TokenEnv)
 	}
 	return nil
 }
@@ -591,8 +656,10 @@ func (o *Orchestrator) runClaude(prompt, dir string, silence bool, extraClaudeAr
 		cmd.Stdout = newProgressWriter(&stdoutBuf, time.Now())
 	} else {
 		cmd.Stdout = io.MultiWriter(os.Stdout, &stdoutBuf)
-		cmd.Stderr = os.Stderr
 	}
+	stderr, closeStderr := o.claudeStderr(silence, time.Now())
+	defer closeStderr()
+	cmd.Stderr = stderr
 
 	start := time.Now()
 	err := cmd.Run()
@@ -603,7 +670,7 @@ func (o *Orchestrator) runClaude(prompt, dir string, silence bool, extraClaudeAr
 	}
 
 	rawOutput := stdoutBuf.Bytes()
-	result := parseClaudeTokens(rawOutput)
+	result := parseClaudeOutput(rawOutput, o.cfg.Claude.OutputFormat)
 	result.RawOutput = make([]byte, len(rawOutput))
 	copy(result.RawOutput, rawOutput)
 	logf("runClaude: finished in %s in=%d (cache_create=%d cache_read=%d) out=%d cost=$%.4f (err=%v)",
@@ -613,6 +680,72 @@ func (o *Orchestrator) runClaude(prompt, dir string, silence bool, extraClaudeAr
 	return result, err
 }
 
+// checkClaudeOrFixture is checkClaude for the operations that may replay
+// Claude.Fixture (measure, MeasureDryRun, ReplayInvocation): with a
+// fixture configured the podman and credential checks are skipped.
+func (o *Orchestrator) checkClaudeOrFixture() error {
+	if fixture := o.cfg.ClaudeFixture(); fixture != "" {
+		logf("checkClaude: using fixture %s, skipping podman and credential checks", fixture)
+		return nil
+	}
+	return o.checkClaude()
+}
+
+// runClaudeOrFixture is runClaude for the operations that may replay
+// Claude.Fixture: with a fixture configured it returns the fixture
+// content instead of launching the container.
+func (o *Orchestrator) runClaudeOrFixture(prompt, dir string, silence bool, extraClaudeArgs ...string) (ClaudeResult, error) {
+	if fixture := o.cfg.ClaudeFixture(); fixture != "" {
+		logf("runClaude: promptLen=%d dir=%q silence=%v", len(prompt), dir, silence)
+		return runClaudeFixture(fixture, o.cfg.Claude.OutputFormat)
+	}
+	return o.runClaude(prompt, dir, silence, extraClaudeArgs...)
+}
+
+// runClaudeFixture returns the canned Claude output stored at path as if
+// Claude had produced it, with token counts parsed in the given format.
+func runClaudeFixture(path, format string) (ClaudeResult, error) {
+	rawOutput, err := os.ReadFile(path)
+	if err != nil {
+		return ClaudeResult{}, fmt.Errorf("reading claude fixture: %w", err)
+	}
+	result := parseClaudeOutput(rawOutput, format)
+	result.RawOutput = rawOutput
+	logf("runClaude: replayed fixture %s (%d bytes) in=%d out=%d", path, len(rawOutput), result.InputTokens, result.OutputTokens)
+	return result, nil
+}
+
+// claudeStderr returns the writer for Claude's stderr and a function that
+// closes any file it opened. Without CaptureStderr the writer is
+// os.Stderr, or nil (discard) when silence is set. With CaptureStderr,
+// output is appended to {Cobbler.Dir}/claude-stderr-{timestamp}.log and,
+// when not silent, also copied to os.Stderr. If the log file cannot be
+// opened, capture is skipped with a warning.
+func (o *Orchestrator) claudeStderr(silence bool, now time.Time) (io.Writer, func()) {
+	var term io.Writer
+	if !silence {
+		term = os.Stderr
+	}
+	if !o.cfg.Cobbler.CaptureStderr {
+		return term, func() {}
+	}
+	path := filepath.Join(o.cfg.Cobbler.Dir, "claude-stderr-"+now.Format("2006-01-02-15-04-05")+".log")
+	if err := os.MkdirAll(o.cfg.Cobbler.Dir, 0o755); err != nil {
+		logf("runClaude: stderr capture disabled: %v", err)
+		return term, func() {}
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		logf("runClaude: stderr capture disabled: %v", err)
+		return term, func() {}
+	}
+	logf("runClaude: capturing stderr to %s", path)
+	if term == nil {
+		return f, func() { f.Close() }
+	}
+	return io.MultiWriter(term, f), func() { f.Close() }
+}
+
 // buildPodmanCmd constructs the exec.Cmd for running Claude inside a
 // podman container. It mounts the working directory and the credential
 // file so Claude Code can authenticate.
@@ -622,13 +755,18 @@ func (o *Orchestrator) buildPodmanCmd(ctx context.Context, workDir string, extra
 		"-w", workDir,
 	}
 
-	// Mount credentials into the container at the path Claude Code expects.
-	credPath := filepath.Join(o.cfg.Claude.SecretsDir, o.cfg.EffectiveTokenFile())
-	if absCredPath, err := filepath.Abs(credPath); err == nil {
-		if _, err := os.Stat(absCredPath); err == nil {
+	// Mount credentials into the container at the path Claude Code expects,
+	// or forward the token by name so its value never appears in the args.
+	src, _ := o.credentialSource()
+	var env []string
+	if src.Path != "" {
+		if absCredPath, err := filepath.Abs(src.Path); err == nil {
 			args = append(args,
 				"-v", absCredPath+":"+o.cfg.Claude.ContainerCredentialsPath+":ro")
 		}
+	} else if src.Token != "" {
+		args = append(args, "-e", containerTokenEnv)
+		env = append(os.Environ(), containerTokenEnv+"="+src.Token)
 	}
 
 	args = append(args, o.cfg.Podman.Args...)
@@ -638,7 +776,9 @@ func (o *Orchestrator) buildPodmanCmd(ctx context.Context, workDir string, extra
 	args = append(args, extraClaudeArgs...)
 
 	logf("runClaude: exec %s %v (timeout=%s)", binPodman, args, o.cfg.ClaudeTimeout())
-	return exec.CommandContext(ctx, binPodman, args...)
+	cmd := exec.CommandContext(ctx, binPodman, args...)
+	cmd.Env = env
+	return cmd
 }
 
 // logConfig prints the resolved configuration for debugging.
@@ -650,19 +790,33 @@ func (o *Orchestrator) logConfig(target string) {
 	}
 }
 
+// gitCommonDir returns the absolute path of the shared .git directory of
+// the repository containing dir ("" for the working directory), which is
+// the same from the main repo root and from any of its git worktrees.
+func gitCommonDir(dir string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--git-common-dir")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	gitDir := filepath.Clean(strings.TrimSpace(string(out)))
+	if !filepath.IsAbs(gitDir) {
+		if dir == "" {
+			dir, _ = os.Getwd()
+		}
+		gitDir = filepath.Join(dir, gitDir)
+	}
+	return gitDir, nil
+}
+
 // worktreeBasePath returns the directory used for stitch worktrees.
-// It uses git rev-parse --git-common-dir to resolve the shared .git directory
-// so the path is identical whether the orchestrator is invoked from the main
-// repo root or from a git worktree of the same repository (prd003 R3.16).
+// It uses gitCommonDir to resolve the shared .git directory so the path
+// is identical whether the orchestrator is invoked from the main repo
+// root or from a git worktree of the same repository (prd003 R3.16).
 // Falls back to filepath.Base(os.Getwd()) when git is unavailable.
 func worktreeBasePath() string {
-	out, err := exec.Command("git", "rev-parse", "--git-common-dir").Output()
-	if err == nil {
-		gitDir := filepath.Clean(strings.TrimSpace(string(out)))
-		if !filepath.IsAbs(gitDir) {
-			cwd, _ := os.Getwd()
-			gitDir = filepath.Join(cwd, gitDir)
-		}
+	if gitDir, err := gitCommonDir(""); err == nil {
 		repoRoot := filepath.Dir(gitDir)
 		return filepath.Join(os.TempDir(), filepath.Base(repoRoot)+"-worktrees")
 	}
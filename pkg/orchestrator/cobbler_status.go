@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// cobblerStatusMaxStitches caps how many recent stitch reports the
+// Stitch section of CobblerStatus lists.
+const cobblerStatusMaxStitches = 5
+
+// CobblerStatus prints a summary of the cobbler scratch directory to
+// stdout: the active generation and phase, the proposed issues recorded
+// in measure.yaml, the last pre-cycle analysis, and the most recent stitch
+// reports from the history directory. Returns an error if the cobbler
+// directory does not exist.
+//
+// Exposed as a mage target (e.g., mage cobbler:status).
+func (o *Orchestrator) CobblerStatus() error {
+	return o.writeCobblerStatus(os.Stdout)
+}
+
+// writeCobblerStatus writes the CobblerStatus summary to w.
+func (o *Orchestrator) writeCobblerStatus(w io.Writer) error {
+	dir := o.cfg.Cobbler.Dir
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("no cobbler directory at %s", dir)
+	}
+
+	fmt.Fprintln(w, "Cobbler Status")
+	fmt.Fprintln(w, "==============")
+
+	phaseMu.RLock()
+	gen, phase := currentGeneration, currentPhase
+	phaseMu.RUnlock()
+	fmt.Fprintln(w, "\nGeneration")
+	fmt.Fprintf(w, "  Generation: %s\n", orDefault(gen, "none"))
+	fmt.Fprintf(w, "  Phase:      %s\n", orDefault(phase, "none"))
+
+	fmt.Fprintln(w, "\nMeasure")
+	if issues := loadYAML[[]ProposedIssue](o.measureLogPath()); issues != nil {
+		fmt.Fprintf(w, "  Proposed issues: %d\n", len(*issues))
+	} else {
+		fmt.Fprintln(w, "  no measure run yet")
+	}
+
+	fmt.Fprintln(w, "\nAnalysis")
+	if doc := loadAnalysisDoc(dir, o.cfg.EffectiveAnalysisFileName()); doc != nil {
+		gaps := 0
+		if doc.CodeStatus != nil {
+			gaps = len(doc.CodeStatus.Gaps)
+		}
+		fmt.Fprintf(w, "  Consistency errors: %d\n", doc.ConsistencyErrors)
+		fmt.Fprintf(w, "  Defects:            %d\n", len(doc.Defects))
+		fmt.Fprintf(w, "  Code gaps:          %d\n", gaps)
+	} else {
+		fmt.Fprintln(w, "  no analysis run yet")
+	}
+
+	fmt.Fprintln(w, "\nStitch")
+	reports := o.recentStitchReports(cobblerStatusMaxStitches)
+	if len(reports) == 0 {
+		fmt.Fprintln(w, "  no stitch reports yet")
+	}
+	for _, r := range reports {
+		fmt.Fprintf(w, "  %-8s %s %s\n", r.Status, r.TaskID, r.TaskTitle)
+	}
+	return nil
+}
+
+// measureLogDescWidth is how many characters of each issue description
+// PrintMeasureLog shows.
+const measureLogDescWidth = 80
+
+// PrintMeasureLog prints the proposed issues appendMeasureLog recorded in
+// {Cobbler.Dir}/measure.yaml as a table sorted by index: title,
+// deliverable type, requirement count, and the start of the description.
+// Returns an error if measure.yaml does not exist or cannot be parsed.
+//
+// Exposed as a mage target (e.g., mage cobbler:log).
+func (o *Orchestrator) PrintMeasureLog() error {
+	return o.writeMeasureLog(os.Stdout)
+}
+
+// writeMeasureLog writes the PrintMeasureLog table to w.
+func (o *Orchestrator) writeMeasureLog(w io.Writer) error {
+	path := o.measureLogPath()
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("no measure log: %w", err)
+	}
+	issues := loadYAML[[]ProposedIssue](path)
+	if issues == nil {
+		return fmt.Errorf("cannot load %s", path)
+	}
+	sorted := slices.Clone(*issues)
+	slices.SortStableFunc(sorted, func(a, b ProposedIssue) int { return cmp.Compare(a.Index, b.Index) })
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "INDEX\tTITLE\tTYPE\tREQS\tDESCRIPTION")
+	for _, issue := range sorted {
+		var desc issueDescription
+		_ = yaml.Unmarshal([]byte(issue.Description), &desc) // non-YAML descriptions show no type or requirements
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%s\n",
+			issue.Index, issue.Title, orDefault(desc.DeliverableType, "-"), len(desc.Requirements),
+			truncate(strings.Join(strings.Fields(issue.Description), " "), measureLogDescWidth))
+	}
+	return tw.Flush()
+}
+
+// recentStitchReports loads up to limit stitch reports from the history
+// directory, newest first. File names start with a sortable timestamp, so
+// lexical order is chronological.
+func (o *Orchestrator) recentStitchReports(limit int) []StitchReport {
+	dir := o.historyDir()
+	if dir == "" {
+		return nil
+	}
+	paths, _ := filepath.Glob(filepath.Join(dir, "*-stitch-report.yaml"))
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+	var reports []StitchReport
+	for _, p := range paths {
+		if len(reports) == limit {
+			break
+		}
+		if r := loadYAML[StitchReport](p); r != nil {
+			reports = append(reports, *r)
+		}
+	}
+	return reports
+}
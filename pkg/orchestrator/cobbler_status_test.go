@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// --- CobblerStatus ---
+
+func TestCobblerStatus_MissingDir(t *testing.T) {
+	t.Parallel()
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: filepath.Join(t.TempDir(), "missing")}}}
+	if err := o.writeCobblerStatus(&bytes.Buffer{}); err == nil {
+		t.Fatal("expected error for missing cobbler directory, got nil")
+	}
+}
+
+func TestCobblerStatus_AllSections(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	histDir := filepath.Join(dir, "history")
+	os.MkdirAll(histDir, 0o755)
+	os.WriteFile(filepath.Join(dir, "measure.yaml"),
+		[]byte("- index: 1\n  title: first\n- index: 2\n  title: second\n"), 0o644)
+	writeAnalysisDoc(&AnalysisDoc{
+		ConsistencyErrors: 3,
+		Defects:           []string{"schema"},
+		CodeStatus:        &CodeStatusReport{Gaps: []string{"gap"}},
+	}, filepath.Join(dir, defaultAnalysisFileName))
+	os.WriteFile(filepath.Join(histDir, "2026-03-01-10-00-00-stitch-report.yaml"),
+		[]byte("task_id: \"41\"\ntask_title: older task\nstatus: failed\n"), 0o644)
+	os.WriteFile(filepath.Join(histDir, "2026-03-02-10-00-00-stitch-report.yaml"),
+		[]byte("task_id: \"42\"\ntask_title: newer task\nstatus: success\n"), 0o644)
+
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: dir, HistoryDir: "history"}}}
+	var buf bytes.Buffer
+	if err := o.writeCobblerStatus(&buf); err != nil {
+		t.Fatalf("writeCobblerStatus: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"Generation", "Measure", "Analysis", "Stitch",
+		"Proposed issues: 2",
+		"Consistency errors: 3",
+		"Code gaps:          1",
+		"newer task", "older task",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "newer task") > strings.Index(out, "older task") {
+		t.Errorf("stitch reports should be listed newest first:\n%s", out)
+	}
+}
+
+func TestCobblerStatus_NoAnalysis(t *testing.T) {
+	t.Parallel()
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: t.TempDir()}}}
+	var buf bytes.Buffer
+	if err := o.writeCobblerStatus(&buf); err != nil {
+		t.Fatalf("writeCobblerStatus: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"no analysis run yet", "no measure run yet", "no stitch reports yet"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+// --- PrintMeasureLog ---
+
+func TestWriteMeasureLog_MissingFile(t *testing.T) {
+	t.Parallel()
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: t.TempDir()}}}
+	if err := o.writeMeasureLog(&bytes.Buffer{}); err == nil {
+		t.Fatal("expected error for missing measure.yaml, got nil")
+	}
+}
+
+func TestWriteMeasureLog_Table(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	longDesc := "deliverable_type: code\nrequirements:\n  - id: R1\n    text: " + strings.Repeat("a", 100) + "\n"
+	issues := []ProposedIssue{
+		{Index: 2, Title: "second issue", Description: longDesc},
+		{Index: 1, Title: "first issue", Description: "deliverable_type: documentation\nrequirements:\n  - id: R1\n    text: one\n  - id: R2\n    text: two\n"},
+	}
+	data, err := yaml.Marshal(issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, "measure.yaml"), data, 0o644)
+
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: dir}}}
+	var buf bytes.Buffer
+	if err := o.writeMeasureLog(&buf); err != nil {
+		t.Fatalf("writeMeasureLog: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want header and 2 rows:\n%s", len(lines), buf.String())
+	}
+	for _, col := range []string{"INDEX", "TITLE", "TYPE", "REQS", "DESCRIPTION"} {
+		if !strings.Contains(lines[0], col) {
+			t.Errorf("header %q missing column %q", lines[0], col)
+		}
+	}
+	if f := strings.Fields(lines[1]); f[0] != "1" || !strings.Contains(lines[1], "first issue") || !strings.Contains(lines[1], "documentation") || f[4] != "2" {
+		t.Errorf("row 1 = %q, want index 1, first issue, documentation, 2 requirements", lines[1])
+	}
+	if f := strings.Fields(lines[2]); f[0] != "2" || !strings.Contains(lines[2], "second issue") || f[3] != "code" || f[4] != "1" {
+		t.Errorf("row 2 = %q, want index 2, second issue, code, 1 requirement", lines[2])
+	}
+
+	flat := strings.Join(strings.Fields(longDesc), " ")
+	want := flat[:measureLogDescWidth] + "..."
+	if !strings.HasSuffix(lines[2], want) {
+		t.Errorf("row 2 = %q, want description truncated to %q", lines[2], want)
+	}
+	if strings.Contains(lines[2], flat[:measureLogDescWidth+1]) {
+		t.Errorf("row 2 description not truncated at %d characters: %q", measureLogDescWidth, lines[2])
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -571,6 +572,30 @@ func TestSaveHistoryStats_WritesFile(t *testing.T) {
 	}
 }
 
+// Not parallel: sets the global workflow phase.
+func TestSaveHistoryStats_BackfillsEmptyCaller(t *testing.T) {
+	dir := t.TempDir()
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{HistoryDir: dir}}}
+
+	setPhase("measure")
+	o.saveHistoryStats("2026-02-26-10-00-00", "stitch", HistoryStats{Status: "success"})
+	clearPhase()
+	o.saveHistoryStats("2026-02-26-11-00-00", "stitch", HistoryStats{Status: "success"})
+
+	for ts, want := range map[string]string{
+		"2026-02-26-10-00-00": "measure", // active phase
+		"2026-02-26-11-00-00": "stitch",  // no phase: file phase
+	} {
+		s := loadYAML[HistoryStats](filepath.Join(dir, ts+"-stitch-stats.yaml"))
+		if s == nil {
+			t.Fatalf("stats file for %s not written", ts)
+		}
+		if s.Caller != want {
+			t.Errorf("%s: Caller = %q, want %q", ts, s.Caller, want)
+		}
+	}
+}
+
 func TestSaveHistoryStats_NoOpWhenEmpty(t *testing.T) {
 	t.Parallel()
 	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{HistoryDir: ""}}}
@@ -633,6 +658,69 @@ func TestSaveHistoryLog_NoOpWhenEmpty(t *testing.T) {
 	o.saveHistoryLog("ts", "phase", []byte("data"))
 }
 
+// --- claudeStderr ---
+
+func TestClaudeStderr_CaptureSilent(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: dir, CaptureStderr: true}}}
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	w, closeFn := o.claudeStderr(true, now)
+	if w == nil {
+		t.Fatal("expected a writer when CaptureStderr is set")
+	}
+	w.Write([]byte("tool error: boom\n"))
+	closeFn()
+
+	data, err := os.ReadFile(filepath.Join(dir, "claude-stderr-2026-03-01-10-00-00.log"))
+	if err != nil {
+		t.Fatalf("stderr log not created: %v", err)
+	}
+	if string(data) != "tool error: boom\n" {
+		t.Errorf("stderr log = %q, want %q", data, "tool error: boom\n")
+	}
+}
+
+// Not parallel: replaces os.Stderr.
+func TestClaudeStderr_CaptureNotSilentTees(t *testing.T) {
+	dir := t.TempDir()
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: dir, CaptureStderr: true}}}
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	term := captureStderr(t, func() {
+		w, closeFn := o.claudeStderr(false, now)
+		w.Write([]byte("api warning\n"))
+		closeFn()
+	})
+	if !strings.Contains(term, "api warning") {
+		t.Errorf("expected stderr on terminal, got %q", term)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "claude-stderr-2026-03-01-10-00-00.log"))
+	if string(data) != "api warning\n" {
+		t.Errorf("stderr log = %q, want %q", data, "api warning\n")
+	}
+}
+
+func TestClaudeStderr_Disabled(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: dir}}}
+
+	w, closeFn := o.claudeStderr(true, time.Now())
+	closeFn()
+	if w != nil {
+		t.Errorf("expected nil writer when silent without capture, got %T", w)
+	}
+	if w, _ := o.claudeStderr(false, time.Now()); w != os.Stderr {
+		t.Errorf("expected os.Stderr when not silent without capture, got %T", w)
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "claude-stderr-*.log"))
+	if len(matches) != 0 {
+		t.Errorf("expected no stderr log when disabled, got %v", matches)
+	}
+}
+
 // --- buildPodmanCmd ---
 
 func TestBuildPodmanCmd_ContainsWorkdirMount(t *testing.T) {
@@ -1176,3 +1264,99 @@ func TestCobblerReset_NonExistentDir(t *testing.T) {
 		t.Fatalf("CobblerReset on nonexistent dir: %v", err)
 	}
 }
+
+// --- Claude fixture ---
+
+func TestRunClaude_FixtureReplaysOutput(t *testing.T) {
+	path := writeClaudeFixture(t, "hello")
+	o := New(Config{Claude: ClaudeConfig{Fixture: path}})
+	if err := o.checkClaudeOrFixture(); err != nil {
+		t.Fatalf("checkClaudeOrFixture with fixture: %v", err)
+	}
+	res, err := o.runClaudeOrFixture("prompt", "", true)
+	if err != nil {
+		t.Fatalf("runClaude: %v", err)
+	}
+	if got := extractTextFromStreamJSON(res.RawOutput); got != "hello" {
+		t.Errorf("replayed text = %q, want %q", got, "hello")
+	}
+	if res.InputTokens != 120 || res.OutputTokens != 30 || res.CostUSD != 0.5 {
+		t.Errorf("tokens = %+v, want in=120 out=30 cost=0.5", res)
+	}
+}
+
+func TestRunClaude_FixtureMissing(t *testing.T) {
+	o := New(Config{Claude: ClaudeConfig{Fixture: filepath.Join(t.TempDir(), "none.jsonl")}})
+	if _, err := o.runClaudeOrFixture("prompt", "", true); err == nil {
+		t.Error("expected error for missing fixture")
+	}
+}
+
+// Not parallel: uses t.Setenv.
+func TestClaudeFixture_EnvOverridesConfig(t *testing.T) {
+	cfg := Config{Claude: ClaudeConfig{Fixture: "from-config.jsonl"}}
+	if got := cfg.ClaudeFixture(); got != "from-config.jsonl" {
+		t.Errorf("ClaudeFixture() = %q, want config value", got)
+	}
+	t.Setenv(claudeFixtureEnv, "from-env.jsonl")
+	var got string
+	stderr := captureStderr(t, func() { got = cfg.ClaudeFixture() })
+	if got != "from-env.jsonl" {
+		t.Errorf("ClaudeFixture() = %q, want env value", got)
+	}
+	if !strings.Contains(stderr, "config warning: "+claudeFixtureEnv+"=from-env.jsonl overrides claude.fixture") {
+		t.Errorf("stderr = %q, want an override warning", stderr)
+	}
+}
+
+// --- selectCredentialSource ---
+
+func TestSelectCredentialSource(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	present := filepath.Join(dir, "claude.json")
+	os.WriteFile(present, []byte("{}"), 0o644)
+	missing := filepath.Join(dir, "absent.json")
+	env := map[string]string{"CI_TOKEN": "tok-123"}
+	getenv := func(k string) string { return env[k] }
+
+	tests := []struct {
+		name     string
+		credPath string
+		envName  string
+		want     credentialSource
+		wantOK   bool
+	}{
+		{"file wins over env", present, "CI_TOKEN", credentialSource{Path: present}, true},
+		{"env when file absent", missing, "CI_TOKEN", credentialSource{Token: "tok-123"}, true},
+		{"unset env", missing, "OTHER_TOKEN", credentialSource{}, false},
+		{"no env name", missing, "", credentialSource{}, false},
+	}
+	for _, tc := range tests {
+		got, ok := selectCredentialSource(tc.credPath, tc.envName, getenv)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("%s: got (%+v, %v), want (%+v, %v)", tc.name, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func TestBuildPodmanCmd_ForwardsEnvToken(t *testing.T) {
+	// Not parallel: sets an environment variable.
+	cfg := Config{}
+	cfg.Claude.SecretsDir = t.TempDir()
+	cfg.Claude.TokenEnv = "TEST_CLAUDE_TOKEN"
+	t.Setenv("TEST_CLAUDE_TOKEN", "secret-token")
+	o := New(cfg)
+	cmd := o.buildPodmanCmd(context.TODO(), "/work")
+
+	joined := strings.Join(cmd.Args, " ")
+	if !strings.Contains(joined, "-e "+containerTokenEnv) {
+		t.Errorf("buildPodmanCmd args missing -e %s; args=%v", containerTokenEnv, cmd.Args)
+	}
+	if strings.Contains(joined, "secret-token") {
+		t.Errorf("buildPodmanCmd leaked token into args; args=%v", cmd.Args)
+	}
+	if !slices.Contains(cmd.Env, containerTokenEnv+"=secret-token") {
+		t.Errorf("buildPodmanCmd env missing %s", containerTokenEnv)
+	}
+}
@@ -4,35 +4,51 @@
 package orchestrator
 
 import (
+	"encoding/json"
 	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"maps"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
 )
 
 // UCCodeStatus holds the code implementation status for a single use case.
 type UCCodeStatus struct {
-	ID         string
-	SpecStatus string // from road-map.yaml (e.g. "done", "not started")
-	CodeStatus string // "implemented" or "not started"
-	TestDir    string // path to test directory, empty if none
-	TestFiles  int    // number of _test.go files found
+	ID         string `yaml:"id" json:"id"`
+	SpecStatus string `yaml:"spec_status" json:"spec_status"` // from road-map.yaml (e.g. "done", "not started")
+	CodeStatus string `yaml:"code_status" json:"code_status"` // "implemented" or "not started"
+	TestDir    string `yaml:"test_dir" json:"test_dir"`       // path to test directory, empty if none
+	TestFiles  int    `yaml:"test_files" json:"test_files"`   // number of _test.go files found
 }
 
 // ReleaseCodeStatus holds the code implementation status for a release.
 type ReleaseCodeStatus struct {
-	Version       string
-	Name          string
-	SpecStatus    string // from road-map.yaml
-	CodeReadiness string // "all implemented", "partial", "none"
-	UseCases      []UCCodeStatus
+	Version       string         `yaml:"version" json:"version"`
+	Name          string         `yaml:"name" json:"name"`
+	SpecStatus    string         `yaml:"spec_status" json:"spec_status"`       // from road-map.yaml
+	CodeReadiness string         `yaml:"code_readiness" json:"code_readiness"` // "all implemented", "ready", "partial", "none"
+	UseCases      []UCCodeStatus `yaml:"use_cases" json:"use_cases"`
+
+	// ReadinessThreshold is the implemented fraction at which a partial
+	// release is labeled "ready"; zero when no threshold applies.
+	ReadinessThreshold float64 `yaml:"readiness_threshold,omitempty" json:"readiness_threshold,omitempty"`
 }
 
 // CodeStatusReport holds the full spec-vs-code comparison report.
 type CodeStatusReport struct {
-	Releases []ReleaseCodeStatus
-	Gaps     []string
+	Releases []ReleaseCodeStatus `yaml:"releases" json:"releases"`
+	Gaps     []string            `yaml:"gaps" json:"gaps"`
+	Warnings []string            `yaml:"warnings,omitempty" json:"warnings,omitempty"`
 }
 
 // ucIDRe extracts release version and UC number from a use case ID.
@@ -55,15 +71,39 @@ func testDirForUC(ucID string) string {
 	return filepath.Join("tests", "rel"+m[1], "uc"+m[2])
 }
 
+// defaultTestFileSuffixes are the file name suffixes counted as test
+// files when Cobbler.TestFileSuffixes is empty.
+var defaultTestFileSuffixes = []string{"_test.go"}
+
+// isTestFile reports whether name ends in one of suffixes, or in a
+// defaultTestFileSuffixes entry when suffixes is empty.
+func isTestFile(name string, suffixes []string) bool {
+	if len(suffixes) == 0 {
+		suffixes = defaultTestFileSuffixes
+	}
+	for _, s := range suffixes {
+		if strings.HasSuffix(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
 // countTestFiles counts _test.go files in a directory.
 func countTestFiles(dir string) int {
-	entries, err := os.ReadDir(dir)
+	return countTestFilesFS(os.DirFS(dir), ".", nil)
+}
+
+// countTestFilesFS counts the test files in dir within fsys: files whose
+// names end in one of suffixes (see isTestFile).
+func countTestFilesFS(fsys fs.FS, dir string, suffixes []string) int {
+	entries, err := fs.ReadDir(fsys, dir)
 	if err != nil {
 		return 0
 	}
 	count := 0
 	for _, e := range entries {
-		if !e.IsDir() && strings.HasSuffix(e.Name(), "_test.go") {
+		if !e.IsDir() && isTestFile(e.Name(), suffixes) {
 			count++
 		}
 	}
@@ -73,17 +113,76 @@ func countTestFiles(dir string) int {
 // scanTestDirectories walks the tests root and returns a map from UC
 // prefix (e.g. "rel01.0-uc001") to the number of _test.go files found.
 func scanTestDirectories(testsRoot string) map[string]int {
+	return scanTestDirectoriesFS(os.DirFS(testsRoot), ".", nil)
+}
+
+// scanTestDirectoriesFS is scanTestDirectories reading testsRoot from fsys
+// and counting files that end in one of suffixes.
+func scanTestDirectoriesFS(fsys fs.FS, testsRoot string, suffixes []string) map[string]int {
 	result := make(map[string]int)
-	relDirs, err := os.ReadDir(testsRoot)
+	walkUCTestDirsFS(fsys, testsRoot, func(prefix, ucPath string) {
+		if testCount := countTestFilesFS(fsys, ucPath, suffixes); testCount > 0 {
+			result[prefix] = testCount
+		}
+	})
+	return result
+}
+
+// scanMixedTestPackagesFS walks the tests root in fsys and returns a map
+// from UC prefix to the sorted package names found in that directory's
+// _test.go files, for directories whose files disagree. An external test
+// package ("uc001_test") counts as its base package. A mixed directory
+// usually means a test file was placed under the wrong use case.
+func scanMixedTestPackagesFS(fsys fs.FS, testsRoot string) map[string][]string {
+	result := make(map[string][]string)
+	walkUCTestDirsFS(fsys, testsRoot, func(prefix, ucPath string) {
+		if pkgs := testPackagesFS(fsys, ucPath); len(pkgs) > 1 {
+			result[prefix] = pkgs
+		}
+	})
+	return result
+}
+
+// testPackagesFS returns the sorted, distinct package names declared by
+// the _test.go files in dir, with any "_test" suffix removed. Files whose
+// package clause cannot be parsed are skipped.
+func testPackagesFS(fsys fs.FS, dir string) []string {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), "_test.go") {
+			continue
+		}
+		src, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), e.Name(), src, parser.PackageClauseOnly)
+		if err != nil {
+			continue
+		}
+		seen[strings.TrimSuffix(f.Name.Name, "_test")] = true
+	}
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// walkUCTestDirsFS calls fn for every tests/relXX.Y/ucNNN directory under
+// testsRoot in fsys, passing the UC prefix (e.g. "rel01.0-uc001") and the
+// directory path.
+func walkUCTestDirsFS(fsys fs.FS, testsRoot string, fn func(prefix, ucPath string)) {
+	relDirs, err := fs.ReadDir(fsys, testsRoot)
 	if err != nil {
-		return result
+		return
 	}
 	for _, relEntry := range relDirs {
 		if !relEntry.IsDir() || !strings.HasPrefix(relEntry.Name(), "rel") {
 			continue
 		}
-		relPath := filepath.Join(testsRoot, relEntry.Name())
-		ucDirs, err := os.ReadDir(relPath)
+		relPath := path.Join(testsRoot, relEntry.Name())
+		ucDirs, err := fs.ReadDir(fsys, relPath)
 		if err != nil {
 			continue
 		}
@@ -91,15 +190,37 @@ func scanTestDirectories(testsRoot string) map[string]int {
 			if !ucEntry.IsDir() || !strings.HasPrefix(ucEntry.Name(), "uc") {
 				continue
 			}
-			ucPath := filepath.Join(relPath, ucEntry.Name())
-			prefix := relEntry.Name() + "-" + ucEntry.Name()
-			testCount := countTestFiles(ucPath)
-			if testCount > 0 {
-				result[prefix] = testCount
-			}
+			fn(relEntry.Name()+"-"+ucEntry.Name(), path.Join(relPath, ucEntry.Name()))
 		}
 	}
-	return result
+}
+
+// resolveFS returns the first non-nil entry of an optional fs.FS
+// argument, or os.DirFS(".") when none is given.
+func resolveFS(fsys []fs.FS) fs.FS {
+	if len(fsys) > 0 && fsys[0] != nil {
+		return fsys[0]
+	}
+	return os.DirFS(".")
+}
+
+// fsPath converts a config path such as "./docs/road-map.yaml" to the
+// slash-separated, unrooted form fs.FS expects.
+func fsPath(p string) string {
+	return path.Clean(filepath.ToSlash(p))
+}
+
+// readRoadmap loads the roadmap at path from the injected fs.FS, or from
+// the current directory when none is given. An absolute or "../" path has
+// no fs.FS form, so without an injected fs.FS it is read from the OS path
+// as configured. Returns nil when the file cannot be loaded or is larger
+// than maxFileSize bytes (0 means no limit).
+func readRoadmap(fsys []fs.FS, p string, maxFileSize int64) *RoadmapDoc {
+	fp := fsPath(p)
+	if (len(fsys) == 0 || fsys[0] == nil) && !fs.ValidPath(fp) {
+		return loadSpecYAML[RoadmapDoc](p, maxFileSize)
+	}
+	return loadSpecYAMLFS[RoadmapDoc](resolveFS(fsys), fp, maxFileSize)
 }
 
 // computeCodeStatus builds the code status report from the roadmap and
@@ -155,12 +276,53 @@ func computeCodeStatus(roadmap *RoadmapDoc, testDirScan map[string]int) CodeStat
 	return report
 }
 
+// readinessThreshold returns the "ready" threshold for release version:
+// the Cobbler.ReleaseReadinessThresholds entry when present, otherwise
+// Cobbler.ReadinessThreshold.
+func (o *Orchestrator) readinessThreshold(version string) float64 {
+	if t, ok := o.cfg.Cobbler.ReleaseReadinessThresholds[version]; ok {
+		return t
+	}
+	return o.cfg.Cobbler.ReadinessThreshold
+}
+
+// applyReadinessThresholds relabels each "partial" release whose
+// implemented fraction of use cases reaches threshold(version) as
+// "ready". Thresholds outside (0, 1] are ignored.
+func applyReadinessThresholds(report *CodeStatusReport, threshold func(version string) float64) {
+	for i := range report.Releases {
+		rel := &report.Releases[i]
+		t := threshold(rel.Version)
+		if t <= 0 || t > 1 {
+			continue
+		}
+		rel.ReadinessThreshold = t
+		if rel.CodeReadiness != "partial" {
+			continue
+		}
+		implemented := 0
+		for _, uc := range rel.UseCases {
+			if uc.CodeStatus == "implemented" {
+				implemented++
+			}
+		}
+		if float64(implemented) >= t*float64(len(rel.UseCases)) {
+			rel.CodeReadiness = "ready"
+		}
+	}
+}
+
 // detectSpecCodeGaps identifies discrepancies between specification status
 // in road-map.yaml and actual code status based on test file presence.
+// A "done" release whose code readiness is "ready" is accepted, including
+// its use cases without tests.
 func detectSpecCodeGaps(report *CodeStatusReport) []string {
 	var gaps []string
 	for i := range report.Releases {
 		rel := &report.Releases[i]
+		if rel.SpecStatus == "done" && rel.CodeReadiness == "ready" {
+			continue
+		}
 		if rel.SpecStatus == "done" && rel.CodeReadiness != "all implemented" {
 			gaps = append(gaps, fmt.Sprintf(
 				"release %s: spec status is %q but code readiness is %q",
@@ -177,27 +339,173 @@ func detectSpecCodeGaps(report *CodeStatusReport) []string {
 	return gaps
 }
 
+// mixedPackageWarnings formats a scanMixedTestPackagesFS result as report
+// warnings, ordered by UC prefix.
+func mixedPackageWarnings(mixed map[string][]string) []string {
+	var warnings []string
+	for _, prefix := range slices.Sorted(maps.Keys(mixed)) {
+		warnings = append(warnings, fmt.Sprintf(
+			"%s: test files declare mixed packages (%s); a file may be in the wrong use case directory",
+			prefix, strings.Join(mixed[prefix], ", ")))
+	}
+	return warnings
+}
+
 // CodeStatus reports the code implementation status per use case and
 // release by comparing road-map.yaml spec status with test file presence.
-func (o *Orchestrator) CodeStatus() error {
-	roadmap := loadYAML[RoadmapDoc]("docs/road-map.yaml")
-	if roadmap == nil {
-		return fmt.Errorf("cannot load docs/road-map.yaml")
+// The roadmap and tests/ are read from fsys when given (e.g. an
+// fstest.MapFS in tests), otherwise from the current directory.
+func (o *Orchestrator) CodeStatus(fsys ...fs.FS) error {
+	return o.CodeStatusForRelease("", fsys...)
+}
+
+// CodeStatusForRelease is CodeStatus restricted to the roadmap release
+// with the given version (e.g. "02.0"): only that release is reported and
+// only its gaps fail the check. An empty version reports every release.
+// Returns an error when no release has that version.
+func (o *Orchestrator) CodeStatusForRelease(version string, fsys ...fs.FS) error {
+	report, err := o.codeStatusReport(version, fsys)
+	if err != nil {
+		return err
+	}
+
+	if err := printCodeStatusReport(os.Stdout, report, o.cfg.Cobbler.CodeStatusFormat); err != nil {
+		return err
 	}
 
-	testScan := scanTestDirectories("tests")
+	if len(report.Gaps) > 0 {
+		return fmt.Errorf("found %d spec-vs-code gap(s)", len(report.Gaps))
+	}
+	return nil
+}
+
+// Gaps runs the CodeStatus scan and returns the spec-vs-code gaps across
+// all releases without printing anything, for callers that embed the
+// orchestrator as a library. A nil slice means no gaps.
+func (o *Orchestrator) Gaps(fsys ...fs.FS) ([]string, error) {
+	report, err := o.codeStatusReport("", fsys)
+	if err != nil {
+		return nil, err
+	}
+	return report.Gaps, nil
+}
+
+// codeStatusReport builds the CodeStatus report from the roadmap and
+// tests/ in fsys (or the current directory), restricted to the release
+// with the given version when version is non-empty.
+func (o *Orchestrator) codeStatusReport(version string, fsys []fs.FS) (*CodeStatusReport, error) {
+	root := resolveFS(fsys)
+	roadmap, err := o.loadRoadmap(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if version != "" {
+		if roadmap, err = filterRoadmapRelease(roadmap, version); err != nil {
+			return nil, fmt.Errorf("%s: %w", o.cfg.EffectiveRoadmapFile(), err)
+		}
+	}
+
+	testScan := o.scanTests(fsys)
 
 	report := computeCodeStatus(roadmap, testScan)
+	applyReadinessThresholds(&report, o.readinessThreshold)
 	report.Gaps = detectSpecCodeGaps(&report)
+	report.Warnings = mixedPackageWarnings(scanMixedTestPackagesFS(root, "tests"))
+	return &report, nil
+}
 
-	printCodeStatusReport(&report)
+// filterRoadmapRelease returns a copy of roadmap holding only the release
+// with the given version. Returns an error listing the known versions
+// when there is none.
+func filterRoadmapRelease(roadmap *RoadmapDoc, version string) (*RoadmapDoc, error) {
+	var known []string
+	for _, rel := range roadmap.Releases {
+		if rel.Version == version {
+			filtered := *roadmap
+			filtered.Releases = []RoadmapRelease{rel}
+			return &filtered, nil
+		}
+		known = append(known, rel.Version)
+	}
+	return nil, fmt.Errorf("release %q not found; known releases: %s", version, strings.Join(known, ", "))
+}
 
-	if len(report.Gaps) > 0 {
-		return fmt.Errorf("found %d spec-vs-code gap(s)", len(report.Gaps))
+// loadRoadmap reads the roadmap at Project.RoadmapFile (or the default)
+// with readRoadmap. Returns an error naming the path when it cannot be
+// loaded.
+func (o *Orchestrator) loadRoadmap(fsys []fs.FS) (*RoadmapDoc, error) {
+	roadmapPath := o.cfg.EffectiveRoadmapFile()
+	roadmap := readRoadmap(fsys, roadmapPath, o.cfg.EffectiveMaxSpecFileSize())
+	if roadmap == nil {
+		return nil, fmt.Errorf("cannot load %s", roadmapPath)
 	}
+	return roadmap, nil
+}
+
+// RoadmapSummary prints each roadmap release with its spec status and
+// use case count, followed by totals. Unlike CodeStatus it reads only the
+// roadmap and does not scan tests/. The roadmap is read from fsys when
+// given, otherwise from the current directory.
+func (o *Orchestrator) RoadmapSummary(fsys ...fs.FS) error {
+	roadmap, err := o.loadRoadmap(fsys)
+	if err != nil {
+		return err
+	}
+	return printRoadmapSummary(os.Stdout, roadmap)
+}
+
+// printRoadmapSummary writes the RoadmapSummary table to w.
+func printRoadmapSummary(w io.Writer, roadmap *RoadmapDoc) error {
+	fmt.Fprintln(w, "Roadmap Summary")
+	fmt.Fprintln(w, "===============")
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RELEASE\tNAME\tSTATUS\tUSE CASES")
+	totalUCs := 0
+	byStatus := map[string]int{}
+	for _, rel := range roadmap.Releases {
+		fmt.Fprintf(tw, "%s\t%s\t%s %s\t%d\n", rel.Version, rel.Name, statusIcon(rel.Status), rel.Status, len(rel.UseCases))
+		totalUCs += len(rel.UseCases)
+		byStatus[rel.Status]++
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	var parts []string
+	for _, status := range slices.Sorted(maps.Keys(byStatus)) {
+		parts = append(parts, fmt.Sprintf("%d %s", byStatus[status], status))
+	}
+	fmt.Fprintf(w, "\nTotal: %d release(s), %d use case(s)", len(roadmap.Releases), totalUCs)
+	if len(parts) > 0 {
+		fmt.Fprintf(w, " (releases: %s)", strings.Join(parts, ", "))
+	}
+	fmt.Fprintln(w)
 	return nil
 }
 
+// implementedUseCases returns the IDs of use cases that computeCodeStatus
+// reports as implemented. Files are read from fsys when provided,
+// otherwise from the working directory. Returns nil when the roadmap
+// cannot be loaded.
+func (o *Orchestrator) implementedUseCases(fsys ...fs.FS) []string {
+	roadmap := readRoadmap(fsys, o.cfg.EffectiveRoadmapFile(), o.cfg.EffectiveMaxSpecFileSize())
+	if roadmap == nil {
+		return nil
+	}
+	report := computeCodeStatus(roadmap, o.scanTests(fsys))
+	var ids []string
+	for _, rel := range report.Releases {
+		for _, uc := range rel.UseCases {
+			if uc.CodeStatus == "implemented" {
+				ids = append(ids, uc.ID)
+			}
+		}
+	}
+	return ids
+}
+
 // statusIcon returns a visual indicator for a status string.
 func statusIcon(status string) string {
 	switch status {
@@ -212,33 +520,74 @@ func statusIcon(status string) string {
 	}
 }
 
-// printCodeStatusReport formats the code status report to stdout.
-func printCodeStatusReport(report *CodeStatusReport) {
-	fmt.Println("Code Status Report")
-	fmt.Println("==================")
+// Code status report output formats accepted by printCodeStatusReport.
+const (
+	codeStatusFormatText = "text"
+	codeStatusFormatYAML = "yaml"
+	codeStatusFormatJSON = "json"
+)
+
+// printCodeStatusReport writes the code status report to w in the given
+// format ("text", "yaml", or "json"). An unknown format logs a warning and
+// falls back to text.
+func printCodeStatusReport(w io.Writer, report *CodeStatusReport, format string) error {
+	switch format {
+	case codeStatusFormatYAML:
+		data, err := yaml.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("marshaling code status report: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	case codeStatusFormatJSON:
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling code status report: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case codeStatusFormatText, "":
+	default:
+		logf("printCodeStatusReport: unknown format %q, using text", format)
+	}
+
+	fmt.Fprintln(w, "Code Status Report")
+	fmt.Fprintln(w, "==================")
 
 	for _, rel := range report.Releases {
-		fmt.Printf("\nRelease %s — %s\n", rel.Version, rel.Name)
-		fmt.Printf("  Spec status:    %s\n", rel.SpecStatus)
-		fmt.Printf("  Code readiness: %s\n", rel.CodeReadiness)
+		fmt.Fprintf(w, "\nRelease %s — %s\n", rel.Version, rel.Name)
+		fmt.Fprintf(w, "  Spec status:    %s\n", rel.SpecStatus)
+		if rel.ReadinessThreshold > 0 {
+			fmt.Fprintf(w, "  Code readiness: %s (ready threshold %.0f%%)\n", rel.CodeReadiness, rel.ReadinessThreshold*100)
+		} else {
+			fmt.Fprintf(w, "  Code readiness: %s\n", rel.CodeReadiness)
+		}
 
 		for _, uc := range rel.UseCases {
 			specTag := statusIcon(uc.SpecStatus)
 			codeTag := statusIcon(uc.CodeStatus)
-			fmt.Printf("    %s spec  %s code  %s", specTag, codeTag, uc.ID)
+			fmt.Fprintf(w, "    %s spec  %s code  %s", specTag, codeTag, uc.ID)
 			if uc.TestFiles > 0 {
-				fmt.Printf(" (%d test files)", uc.TestFiles)
+				fmt.Fprintf(w, " (%d test files)", uc.TestFiles)
 			}
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 	}
 
 	if len(report.Gaps) > 0 {
-		fmt.Printf("\nGaps between specification and code:\n")
+		fmt.Fprintf(w, "\nGaps between specification and code:\n")
 		for _, gap := range report.Gaps {
-			fmt.Printf("  - %s\n", gap)
+			fmt.Fprintf(w, "  - %s\n", gap)
 		}
 	} else {
-		fmt.Printf("\nNo gaps between specification and code.\n")
+		fmt.Fprintf(w, "\nNo gaps between specification and code.\n")
 	}
+
+	if len(report.Warnings) > 0 {
+		fmt.Fprintf(w, "\nWarnings:\n")
+		for _, warning := range report.Warnings {
+			fmt.Fprintf(w, "  - %s\n", warning)
+		}
+	}
+	return nil
 }
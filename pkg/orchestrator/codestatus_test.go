@@ -5,11 +5,18 @@ package orchestrator
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"testing/fstest"
+
+	"gopkg.in/yaml.v3"
 )
 
 // --- ucPrefixFromID ---
@@ -78,6 +85,39 @@ func TestCountTestFiles_NoDir(t *testing.T) {
 	}
 }
 
+func TestCountTestFilesFS_CustomSuffixes(t *testing.T) {
+	t.Parallel()
+	fsys := fstest.MapFS{
+		"uc001/init_test.go":      {Data: []byte("package x\n")},
+		"uc001/login.feature":     {Data: []byte("Feature: login\n")},
+		"uc001/session_spec.yaml": {Data: []byte("spec: session\n")},
+		"uc001/notes.yaml":        {Data: []byte("notes\n")},
+	}
+	if got := countTestFilesFS(fsys, "uc001", nil); got != 1 {
+		t.Errorf("default suffixes: count = %d, want 1", got)
+	}
+	if got := countTestFilesFS(fsys, "uc001", []string{".feature", "_spec.yaml"}); got != 2 {
+		t.Errorf("custom suffixes: count = %d, want 2", got)
+	}
+}
+
+func TestCodeStatus_TestFileSuffixesConfig(t *testing.T) {
+	t.Parallel()
+	// The only test is a BDD feature file, so the done use case counts as
+	// tested only when ".feature" is configured.
+	fsys := fstest.MapFS{
+		"docs/road-map.yaml":               {Data: []byte(roadmapYAML)},
+		"tests/rel01.0/uc001/init.feature": {Data: []byte("Feature: init\n")},
+	}
+	if err := New(Config{}).CodeStatus(fsys); err == nil {
+		t.Error("default suffixes: expected a gap for the untested use case")
+	}
+	o := New(Config{Cobbler: CobblerConfig{TestFileSuffixes: []string{"_test.go", ".feature"}}})
+	if err := o.CodeStatus(fsys); err != nil {
+		t.Errorf("with .feature configured: CodeStatus() returned error: %v", err)
+	}
+}
+
 // --- scanTestDirectories ---
 
 func TestScanTestDirectories(t *testing.T) {
@@ -125,6 +165,34 @@ func TestScanTestDirectories_NoDir(t *testing.T) {
 	}
 }
 
+// --- scanMixedTestPackagesFS ---
+
+func TestScanMixedTestPackagesFS(t *testing.T) {
+	t.Parallel()
+	fsys := fstest.MapFS{
+		// Consistent: internal and external test packages of uc001.
+		"tests/rel01.0/uc001/a_test.go": {Data: []byte("package uc001\n")},
+		"tests/rel01.0/uc001/b_test.go": {Data: []byte("// doc\npackage uc001_test\n")},
+		// Mixed: a uc001 file misplaced under uc002.
+		"tests/rel01.0/uc002/a_test.go": {Data: []byte("package uc002\n")},
+		"tests/rel01.0/uc002/b_test.go": {Data: []byte("package uc001\n")},
+		"tests/rel01.0/uc002/helper.go": {Data: []byte("package other\n")},
+		// Single file: nothing to compare.
+		"tests/rel02.0/uc001/a_test.go": {Data: []byte("package uc001\n")},
+	}
+
+	got := scanMixedTestPackagesFS(fsys, "tests")
+	want := map[string][]string{"rel01.0-uc002": {"uc001", "uc002"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("scanMixedTestPackagesFS = %v, want %v", got, want)
+	}
+
+	warnings := mixedPackageWarnings(got)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "rel01.0-uc002") || !strings.Contains(warnings[0], "uc001, uc002") {
+		t.Errorf("mixedPackageWarnings = %v, want one warning naming rel01.0-uc002 and both packages", warnings)
+	}
+}
+
 func TestScanTestDirectories_SkipsNonRelDirs(t *testing.T) {
 	root := t.TempDir()
 	// Create an "internal" directory that should be skipped.
@@ -334,6 +402,108 @@ func TestDetectSpecCodeGaps_SpecNotStarted_NoGap(t *testing.T) {
 
 // --- statusIcon ---
 
+// --- applyReadinessThresholds ---
+
+// readinessReport returns a "done" release 01.0 with implemented of total
+// use cases implemented.
+func readinessReport(implemented, total int) CodeStatusReport {
+	roadmap := &RoadmapDoc{Releases: []RoadmapRelease{{Version: "01.0", Name: "Core", Status: "done"}}}
+	scan := map[string]int{}
+	for i := 1; i <= total; i++ {
+		id := fmt.Sprintf("rel01.0-uc%03d-x", i)
+		roadmap.Releases[0].UseCases = append(roadmap.Releases[0].UseCases, RoadmapUseCase{ID: id, Status: "done"})
+		if i <= implemented {
+			scan[ucPrefixFromID(id)] = 1
+		}
+	}
+	return computeCodeStatus(roadmap, scan)
+}
+
+func TestApplyReadinessThresholds_DefaultKeepsLabels(t *testing.T) {
+	t.Parallel()
+	o := New(Config{})
+	report := readinessReport(4, 5)
+	applyReadinessThresholds(&report, o.readinessThreshold)
+	if got := report.Releases[0].CodeReadiness; got != "partial" {
+		t.Errorf("CodeReadiness = %q, want partial", got)
+	}
+	if gaps := detectSpecCodeGaps(&report); len(gaps) != 2 {
+		t.Errorf("got %d gaps, want 2 (release and uc005): %v", len(gaps), gaps)
+	}
+}
+
+func TestApplyReadinessThresholds_AboveThresholdIsReady(t *testing.T) {
+	t.Parallel()
+	o := New(Config{Cobbler: CobblerConfig{ReadinessThreshold: 0.8}})
+	report := readinessReport(4, 5)
+	applyReadinessThresholds(&report, o.readinessThreshold)
+	rel := report.Releases[0]
+	if rel.CodeReadiness != "ready" || rel.ReadinessThreshold != 0.8 {
+		t.Errorf("release = %q at %v, want ready at 0.8", rel.CodeReadiness, rel.ReadinessThreshold)
+	}
+	if gaps := detectSpecCodeGaps(&report); len(gaps) != 0 {
+		t.Errorf("got gaps %v, want none for a ready done release", gaps)
+	}
+}
+
+func TestApplyReadinessThresholds_BelowThresholdStaysPartial(t *testing.T) {
+	t.Parallel()
+	o := New(Config{Cobbler: CobblerConfig{ReadinessThreshold: 0.8}})
+	report := readinessReport(3, 5)
+	applyReadinessThresholds(&report, o.readinessThreshold)
+	if got := report.Releases[0].CodeReadiness; got != "partial" {
+		t.Errorf("CodeReadiness = %q, want partial below threshold", got)
+	}
+}
+
+func TestApplyReadinessThresholds_PerReleaseOverride(t *testing.T) {
+	t.Parallel()
+	o := New(Config{Cobbler: CobblerConfig{
+		ReadinessThreshold:         0.9,
+		ReleaseReadinessThresholds: map[string]float64{"01.0": 0.6},
+	}})
+	report := readinessReport(3, 5)
+	applyReadinessThresholds(&report, o.readinessThreshold)
+	if got := report.Releases[0].CodeReadiness; got != "ready" {
+		t.Errorf("CodeReadiness = %q, want ready with the 01.0 override", got)
+	}
+}
+
+func TestApplyReadinessThresholds_AllImplementedUnchanged(t *testing.T) {
+	t.Parallel()
+	o := New(Config{Cobbler: CobblerConfig{ReadinessThreshold: 0.5}})
+	report := readinessReport(2, 2)
+	applyReadinessThresholds(&report, o.readinessThreshold)
+	if got := report.Releases[0].CodeReadiness; got != "all implemented" {
+		t.Errorf("CodeReadiness = %q, want all implemented", got)
+	}
+}
+
+func TestPrintCodeStatusReport_ReadyInJSON(t *testing.T) {
+	t.Parallel()
+	o := New(Config{Cobbler: CobblerConfig{ReadinessThreshold: 0.8}})
+	report := readinessReport(4, 5)
+	applyReadinessThresholds(&report, o.readinessThreshold)
+
+	var buf bytes.Buffer
+	if err := printCodeStatusReport(&buf, &report, codeStatusFormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	var got CodeStatusReport
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Releases[0].CodeReadiness != "ready" || got.Releases[0].ReadinessThreshold != 0.8 {
+		t.Errorf("JSON release = %+v, want ready at 0.8", got.Releases[0])
+	}
+
+	buf.Reset()
+	printCodeStatusReport(&buf, &report, codeStatusFormatText)
+	if !strings.Contains(buf.String(), "Code readiness: ready (ready threshold 80%)") {
+		t.Errorf("text report missing ready label:\n%s", buf.String())
+	}
+}
+
 func TestStatusIcon(t *testing.T) {
 	cases := []struct {
 		input string
@@ -369,18 +539,10 @@ func TestPrintCodeStatusReport_ContainsReleaseInfo(t *testing.T) {
 		}},
 	}
 
-	old := os.Stdout
-	r, w, err := os.Pipe()
-	if err != nil {
+	var buf bytes.Buffer
+	if err := printCodeStatusReport(&buf, report, codeStatusFormatText); err != nil {
 		t.Fatal(err)
 	}
-	os.Stdout = w
-	printCodeStatusReport(report)
-	w.Close()
-	os.Stdout = old
-
-	var buf bytes.Buffer
-	io.Copy(&buf, r)
 	out := buf.String()
 
 	for _, want := range []string{"01.0", "Core", "done", "all implemented", "rel01.0-uc001-init", "No gaps"} {
@@ -404,18 +566,10 @@ func TestPrintCodeStatusReport_ShowsGaps(t *testing.T) {
 		Gaps: []string{"release 01.0: spec status is \"done\" but code readiness is \"none\""},
 	}
 
-	old := os.Stdout
-	r, w, err := os.Pipe()
-	if err != nil {
+	var buf bytes.Buffer
+	if err := printCodeStatusReport(&buf, report, codeStatusFormatText); err != nil {
 		t.Fatal(err)
 	}
-	os.Stdout = w
-	printCodeStatusReport(report)
-	w.Close()
-	os.Stdout = old
-
-	var buf bytes.Buffer
-	io.Copy(&buf, r)
 	out := buf.String()
 
 	if !strings.Contains(out, "Gaps") {
@@ -426,9 +580,97 @@ func TestPrintCodeStatusReport_ShowsGaps(t *testing.T) {
 	}
 }
 
+// sampleCodeStatusReport returns a small report used by the format tests.
+func sampleCodeStatusReport() *CodeStatusReport {
+	return &CodeStatusReport{
+		Releases: []ReleaseCodeStatus{{
+			Version:       "01.0",
+			Name:          "Core",
+			SpecStatus:    "done",
+			CodeReadiness: "partial",
+			UseCases: []UCCodeStatus{
+				{ID: "rel01.0-uc001-init", SpecStatus: "done", CodeStatus: "implemented", TestDir: "tests/rel01.0/uc001", TestFiles: 2},
+				{ID: "rel01.0-uc002-run", SpecStatus: "done", CodeStatus: "not started"},
+			},
+		}},
+		Gaps: []string{"release 01.0: spec status is \"done\" but code readiness is \"partial\""},
+	}
+}
+
+func TestPrintCodeStatusReport_TextHeaders(t *testing.T) {
+	var buf bytes.Buffer
+	if err := printCodeStatusReport(&buf, sampleCodeStatusReport(), codeStatusFormatText); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Code Status Report", "Release 01.0 — Core", "Spec status:", "Code readiness:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("text output missing %q\nfull output:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintCodeStatusReport_YAMLRoundTrip(t *testing.T) {
+	want := sampleCodeStatusReport()
+	var buf bytes.Buffer
+	if err := printCodeStatusReport(&buf, want, codeStatusFormatYAML); err != nil {
+		t.Fatal(err)
+	}
+	var got CodeStatusReport
+	if err := yaml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("yaml.Unmarshal: %v\noutput:\n%s", err, buf.String())
+	}
+	if !reflect.DeepEqual(&got, want) {
+		t.Errorf("YAML round-trip mismatch:\ngot  %+v\nwant %+v", got, *want)
+	}
+}
+
+func TestPrintCodeStatusReport_JSONRoundTrip(t *testing.T) {
+	want := sampleCodeStatusReport()
+	var buf bytes.Buffer
+	if err := printCodeStatusReport(&buf, want, codeStatusFormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	var got CodeStatusReport
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v\noutput:\n%s", err, buf.String())
+	}
+	if !reflect.DeepEqual(&got, want) {
+		t.Errorf("JSON round-trip mismatch:\ngot  %+v\nwant %+v", got, *want)
+	}
+}
+
+func TestPrintCodeStatusReport_UnknownFormatFallsBackToText(t *testing.T) {
+	// Not parallel: redirects os.Stderr to capture the warning.
+	old := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stderr = w
+
+	var buf bytes.Buffer
+	printErr := printCodeStatusReport(&buf, sampleCodeStatusReport(), "xml")
+	w.Close()
+	os.Stderr = old
+
+	var stderr bytes.Buffer
+	io.Copy(&stderr, r)
+
+	if printErr != nil {
+		t.Fatal(printErr)
+	}
+	if !strings.Contains(buf.String(), "Code Status Report") {
+		t.Errorf("expected text fallback, got:\n%s", buf.String())
+	}
+	if !strings.Contains(stderr.String(), `unknown format "xml"`) {
+		t.Errorf("expected warning on stderr, got: %q", stderr.String())
+	}
+}
+
 // --- CodeStatus (integration) ---
-// These tests use os.Chdir because CodeStatus reads docs/road-map.yaml and
-// tests/ relative to the working directory.
+// These tests inject an fstest.MapFS so CodeStatus reads docs/road-map.yaml
+// and tests/ without touching the working directory.
 
 const roadmapYAML = `id: test-roadmap
 title: Test Roadmap
@@ -442,86 +684,339 @@ releases:
 `
 
 func TestCodeStatus_NoGaps(t *testing.T) {
-	// Not parallel: uses os.Chdir.
-	dir := t.TempDir()
-	origDir, err := os.Getwd()
+	t.Parallel()
+	fsys := fstest.MapFS{
+		"docs/road-map.yaml":               {Data: []byte(roadmapYAML)},
+		"tests/rel01.0/uc001/init_test.go": {Data: []byte("package x\n")},
+	}
+
+	o := New(Config{})
+	if err := o.CodeStatus(fsys); err != nil {
+		t.Errorf("CodeStatus() returned error: %v", err)
+	}
+}
+
+func TestCodeStatus_WithGap(t *testing.T) {
+	t.Parallel()
+	// Roadmap has status=done but there is no tests/ directory.
+	fsys := fstest.MapFS{
+		"docs/road-map.yaml": {Data: []byte(roadmapYAML)},
+	}
+
+	o := New(Config{})
+	err := o.CodeStatus(fsys)
+	if err == nil {
+		t.Fatal("CodeStatus() expected error for spec-vs-code gap, got nil")
+	}
+	if !strings.Contains(err.Error(), "gap") {
+		t.Errorf("error should mention 'gap', got: %v", err)
+	}
+}
+
+const twoReleaseRoadmapYAML = `id: test-roadmap
+title: Test Roadmap
+releases:
+  - version: "01.0"
+    name: Core
+    status: done
+    use_cases:
+      - id: rel01.0-uc001-init
+        status: done
+  - version: "02.0"
+    name: Browser
+    status: done
+    use_cases:
+      - id: rel02.0-uc001-browse
+        status: done
+`
+
+func TestCodeStatusForRelease_FiltersToOneRelease(t *testing.T) {
+	// Not parallel: captures os.Stdout.
+	// rel01.0 has tests; rel02.0 has none and would be a gap unfiltered.
+	fsys := fstest.MapFS{
+		"docs/road-map.yaml":               {Data: []byte(twoReleaseRoadmapYAML)},
+		"tests/rel01.0/uc001/init_test.go": {Data: []byte("package x\n")},
+	}
+	o := New(Config{})
+	if err := o.CodeStatus(fsys); err == nil {
+		t.Fatal("unfiltered CodeStatus should report the rel02.0 gap")
+	}
+
+	var err error
+	out := captureStdout(t, func() { err = o.CodeStatusForRelease("01.0", fsys) })
 	if err != nil {
-		t.Fatal(err)
+		t.Errorf("CodeStatusForRelease(01.0) returned error: %v", err)
 	}
-	if err := os.Chdir(dir); err != nil {
-		t.Fatal(err)
+	if !strings.Contains(out, "rel01.0-uc001-init") || strings.Contains(out, "rel02.0") {
+		t.Errorf("output should cover only release 01.0:\n%s", out)
 	}
-	t.Cleanup(func() { os.Chdir(origDir) })
 
-	// Write docs/road-map.yaml.
-	if err := os.MkdirAll(filepath.Join(dir, "docs"), 0o755); err != nil {
-		t.Fatal(err)
+	out = captureStdout(t, func() { err = o.CodeStatusForRelease("02.0", fsys) })
+	if err == nil || !strings.Contains(err.Error(), "spec-vs-code gap") {
+		t.Errorf("CodeStatusForRelease(02.0) error = %v, want its gaps reported", err)
 	}
-	if err := os.WriteFile(filepath.Join(dir, "docs", "road-map.yaml"), []byte(roadmapYAML), 0o644); err != nil {
-		t.Fatal(err)
+	if strings.Contains(out, "rel01.0") {
+		t.Errorf("output should cover only release 02.0:\n%s", out)
 	}
+}
 
-	// Create matching test directory with a test file.
-	testDir := filepath.Join(dir, "tests", "rel01.0", "uc001")
-	if err := os.MkdirAll(testDir, 0o755); err != nil {
-		t.Fatal(err)
+func TestCodeStatusForRelease_UnknownRelease(t *testing.T) {
+	t.Parallel()
+	fsys := fstest.MapFS{"docs/road-map.yaml": {Data: []byte(twoReleaseRoadmapYAML)}}
+	err := New(Config{}).CodeStatusForRelease("09.0", fsys)
+	if err == nil {
+		t.Fatal("expected an error for an unknown release")
 	}
-	if err := os.WriteFile(filepath.Join(testDir, "init_test.go"), []byte("package x\n"), 0o644); err != nil {
-		t.Fatal(err)
+	want := `docs/road-map.yaml: release "09.0" not found; known releases: 01.0, 02.0`
+	if err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
 	}
+}
 
+func TestCodeStatus_MissingRoadmap(t *testing.T) {
+	t.Parallel()
 	o := New(Config{})
-	if err := o.CodeStatus(); err != nil {
-		t.Errorf("CodeStatus() returned error: %v", err)
+	if err := o.CodeStatus(fstest.MapFS{}); err == nil {
+		t.Fatal("CodeStatus() expected error when road-map.yaml missing, got nil")
 	}
 }
 
-func TestCodeStatus_WithGap(t *testing.T) {
-	// Not parallel: uses os.Chdir.
-	dir := t.TempDir()
-	origDir, err := os.Getwd()
-	if err != nil {
-		t.Fatal(err)
+func TestCodeStatus_CustomRoadmapFile(t *testing.T) {
+	t.Parallel()
+	fsys := fstest.MapFS{
+		"spec/roadmap.yaml":                {Data: []byte(roadmapYAML)},
+		"tests/rel01.0/uc001/init_test.go": {Data: []byte("package x\n")},
 	}
-	if err := os.Chdir(dir); err != nil {
-		t.Fatal(err)
+
+	o := New(Config{Project: ProjectConfig{RoadmapFile: "./spec/roadmap.yaml"}})
+	if err := o.CodeStatus(fsys); err != nil {
+		t.Errorf("CodeStatus() returned error: %v", err)
 	}
-	t.Cleanup(func() { os.Chdir(origDir) })
+}
 
-	// Write docs/road-map.yaml with status=done but no matching test files.
-	if err := os.MkdirAll(filepath.Join(dir, "docs"), 0o755); err != nil {
+// writeCodeStatusTree writes roadmap to docs/road-map.yaml and an empty
+// test file in tests/<rel>/<uc>/ for each entry of testDirs, relative to
+// the current directory.
+func writeCodeStatusTree(t *testing.T, roadmap string, testDirs ...string) {
+	t.Helper()
+	os.MkdirAll("docs", 0o755)
+	if err := os.WriteFile("docs/road-map.yaml", []byte(roadmap), 0o644); err != nil {
 		t.Fatal(err)
 	}
-	if err := os.WriteFile(filepath.Join(dir, "docs", "road-map.yaml"), []byte(roadmapYAML), 0o644); err != nil {
-		t.Fatal(err)
+	for _, d := range testDirs {
+		dir := filepath.Join("tests", d)
+		os.MkdirAll(dir, 0o755)
+		if err := os.WriteFile(filepath.Join(dir, "x_test.go"), []byte("package x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Not parallel: uses os.Chdir.
+func TestCodeStatus_WithMultipleReleases(t *testing.T) {
+	chdirTemp(t)
+	writeCodeStatusTree(t, `releases:
+  - version: "01.0"
+    name: Core
+    status: done
+    use_cases:
+      - id: rel01.0-uc001-init
+        status: done
+  - version: "02.0"
+    name: Next
+    status: not started
+    use_cases:
+      - id: rel02.0-uc001-extend
+        status: not started
+`, "rel01.0/uc001")
+
+	o := New(Config{})
+	if err := o.CodeStatus(); err != nil {
+		t.Errorf("CodeStatus() returned error: %v", err)
 	}
-	// Do not create tests/ directory — gaps should be detected.
+}
+
+// Not parallel: uses os.Chdir.
+func TestCodeStatus_PartialImplementation(t *testing.T) {
+	chdirTemp(t)
+	writeCodeStatusTree(t, `releases:
+  - version: "01.0"
+    name: Core
+    status: done
+    use_cases:
+      - id: rel01.0-uc001-init
+        status: done
+      - id: rel01.0-uc002-run
+        status: done
+`, "rel01.0/uc001")
 
 	o := New(Config{})
-	err = o.CodeStatus()
+	err := o.CodeStatus()
 	if err == nil {
-		t.Fatal("CodeStatus() expected error for spec-vs-code gap, got nil")
+		t.Fatal("CodeStatus() expected error for partially implemented release, got nil")
 	}
 	if !strings.Contains(err.Error(), "gap") {
 		t.Errorf("error should mention 'gap', got: %v", err)
 	}
 }
 
-func TestCodeStatus_MissingRoadmap(t *testing.T) {
-	// Not parallel: uses os.Chdir.
-	dir := t.TempDir()
-	origDir, err := os.Getwd()
+// --- scanTestDirectoriesFS / loadYAMLFS ---
+
+func TestScanTestDirectoriesFS_MapFS(t *testing.T) {
+	t.Parallel()
+	fsys := fstest.MapFS{
+		"tests/rel01.0/uc001/a_test.go": {Data: []byte("package x\n")},
+		"tests/rel01.0/uc001/b_test.go": {Data: []byte("package x\n")},
+		"tests/rel01.0/uc002/helper.go": {Data: []byte("package x\n")},
+		"tests/other/uc001/c_test.go":   {Data: []byte("package x\n")},
+	}
+	got := scanTestDirectoriesFS(fsys, "tests", nil)
+	want := map[string]int{"rel01.0-uc001": 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("scanTestDirectoriesFS = %v, want %v", got, want)
+	}
+}
+
+func TestLoadYAMLFS(t *testing.T) {
+	t.Parallel()
+	fsys := fstest.MapFS{
+		"docs/road-map.yaml": {Data: []byte(roadmapYAML)},
+		"docs/bad.yaml":      {Data: []byte("releases: [unclosed\n")},
+	}
+	rm := loadYAMLFS[RoadmapDoc](fsys, "docs/road-map.yaml")
+	if rm == nil || rm.ID != "test-roadmap" || len(rm.Releases) != 1 {
+		t.Fatalf("loadYAMLFS returned %+v", rm)
+	}
+	if got := loadYAMLFS[RoadmapDoc](fsys, "docs/missing.yaml"); got != nil {
+		t.Errorf("expected nil for missing file, got %+v", got)
+	}
+	if got := loadYAMLFS[RoadmapDoc](fsys, "docs/bad.yaml"); got != nil {
+		t.Errorf("expected nil for invalid YAML, got %+v", got)
+	}
+}
+
+// --- implementedUseCases ---
+
+func TestImplementedUseCases(t *testing.T) {
+	t.Parallel()
+	o := New(Config{})
+	with := fstest.MapFS{
+		"docs/road-map.yaml":               {Data: []byte(roadmapYAML)},
+		"tests/rel01.0/uc001/init_test.go": {Data: []byte("package x\n")},
+	}
+	if got := o.implementedUseCases(with); len(got) != 1 || got[0] != "rel01.0-uc001-init" {
+		t.Errorf("implementedUseCases() = %v, want [rel01.0-uc001-init]", got)
+	}
+	without := fstest.MapFS{"docs/road-map.yaml": {Data: []byte(roadmapYAML)}}
+	if got := o.implementedUseCases(without); len(got) != 0 {
+		t.Errorf("implementedUseCases() without tests = %v, want none", got)
+	}
+	if got := o.implementedUseCases(fstest.MapFS{}); got != nil {
+		t.Errorf("implementedUseCases() without roadmap = %v, want nil", got)
+	}
+}
+
+// --- RoadmapSummary ---
+
+func TestRoadmapSummary_MissingRoadmap(t *testing.T) {
+	t.Parallel()
+	o := New(Config{})
+	err := o.RoadmapSummary(fstest.MapFS{})
+	if err == nil {
+		t.Fatal("RoadmapSummary() expected error when road-map.yaml missing, got nil")
+	}
+	if !strings.Contains(err.Error(), "cannot load docs/road-map.yaml") {
+		t.Errorf("error = %v, want the CodeStatus load error", err)
+	}
+}
+
+func TestRoadmapSummary_IgnoresTests(t *testing.T) {
+	t.Parallel()
+	// status=done with no tests/ is a gap for CodeStatus but not here.
+	fsys := fstest.MapFS{"docs/road-map.yaml": {Data: []byte(roadmapYAML)}}
+	o := New(Config{})
+	if err := o.RoadmapSummary(fsys); err != nil {
+		t.Errorf("RoadmapSummary() returned error: %v", err)
+	}
+}
+
+func TestPrintRoadmapSummary(t *testing.T) {
+	t.Parallel()
+	roadmap := &RoadmapDoc{Releases: []RoadmapRelease{
+		{Version: "01.0", Name: "Core", Status: "done", UseCases: []RoadmapUseCase{{ID: "rel01.0-uc001"}, {ID: "rel01.0-uc002"}}},
+		{Version: "02.0", Name: "Extras", Status: "not started", UseCases: []RoadmapUseCase{{ID: "rel02.0-uc001"}}},
+	}}
+	var buf bytes.Buffer
+	if err := printRoadmapSummary(&buf, roadmap); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"Roadmap Summary",
+		"01.0", "Core", "[ok] done",
+		"02.0", "Extras", "[  ] not started",
+		"Total: 2 release(s), 3 use case(s) (releases: 1 done, 1 not started)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+// --- Gaps ---
+
+func TestGaps_MatchesDetectSpecCodeGaps(t *testing.T) {
+	t.Parallel()
+	fsys := fstest.MapFS{
+		"docs/road-map.yaml":               {Data: []byte(twoReleaseRoadmapYAML)},
+		"tests/rel01.0/uc001/init_test.go": {Data: []byte("package x\n")},
+	}
+	o := New(Config{})
+	got, err := o.Gaps(fsys)
+	if err != nil {
+		t.Fatalf("Gaps: %v", err)
+	}
+
+	roadmap, err := o.loadRoadmap([]fs.FS{fsys})
 	if err != nil {
 		t.Fatal(err)
 	}
-	if err := os.Chdir(dir); err != nil {
+	report := computeCodeStatus(roadmap, o.scanTests([]fs.FS{fsys}))
+	applyReadinessThresholds(&report, o.readinessThreshold)
+	want := detectSpecCodeGaps(&report)
+	if len(want) == 0 {
+		t.Fatal("fixture should produce gaps")
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Gaps() = %v, want %v", got, want)
+	}
+}
+
+func TestGaps_MissingRoadmap(t *testing.T) {
+	t.Parallel()
+	if _, err := New(Config{}).Gaps(fstest.MapFS{}); err == nil {
+		t.Error("Gaps() expected error when road-map.yaml missing")
+	}
+}
+
+func TestLoadRoadmap_AbsoluteRoadmapFile(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "road-map.yaml")
+	if err := os.WriteFile(path, []byte(twoReleaseRoadmapYAML), 0o644); err != nil {
 		t.Fatal(err)
 	}
-	t.Cleanup(func() { os.Chdir(origDir) })
+	cfg := Config{}
+	cfg.Project.RoadmapFile = path
+	o := New(cfg)
 
-	// No docs/road-map.yaml present.
-	o := New(Config{})
-	if err := o.CodeStatus(); err == nil {
-		t.Fatal("CodeStatus() expected error when road-map.yaml missing, got nil")
+	roadmap, err := o.loadRoadmap(nil)
+	if err != nil {
+		t.Fatalf("loadRoadmap with absolute roadmap_file: %v", err)
+	}
+	if len(roadmap.Releases) != 2 {
+		t.Errorf("got %d releases, want 2", len(roadmap.Releases))
+	}
+	if _, err := o.Gaps(); err != nil {
+		t.Errorf("Gaps with absolute roadmap_file: %v", err)
 	}
 }
@@ -13,14 +13,17 @@ import (
 
 // Binary names.
 const (
-	binGit      = "git"
-	binClaude   = "claude"
-	binGh       = "gh"
-	binGo       = "go"
-	binLint     = "golangci-lint"
-	binMage     = "mage"
-	binPodman   = "podman"
-	binSecurity = "security"
+	binGit       = "git"
+	binClaude    = "claude"
+	binGh        = "gh"
+	binGo        = "go"
+	binGofmt     = "gofmt"
+	binGoimports = "goimports"
+	binLint      = "golangci-lint"
+	binMage      = "mage"
+	binPodman    = "podman"
+	binSecurity  = "security"
+	binSh        = "sh"
 )
 
 // Directory and file path constants.
@@ -90,6 +93,11 @@ func gitCreateBranch(name, dir string) error {
 	return cmdGit(dir, "branch", name).Run()
 }
 
+// gitCreateBranchFrom creates branch name pointing at startPoint.
+func gitCreateBranchFrom(name, startPoint, dir string) error {
+	return cmdGit(dir, "branch", name, startPoint).Run()
+}
+
 func gitDeleteBranch(name, dir string) error {
 	return cmdGit(dir, "branch", "-d", name).Run()
 }
@@ -115,6 +123,22 @@ func gitDeleteTag(name, dir string) error {
 	return cmdGit(dir, "tag", "-d", name).Run()
 }
 
+// gitTagSigned creates a signed annotated tag at ref using key. An empty
+// ref tags HEAD.
+func gitTagSigned(name, ref, key, msg, dir string) error {
+	args := []string{"tag", "-s", "-u", key, "-m", msg, name}
+	if ref != "" {
+		args = append(args, ref)
+	}
+	return cmdGit(dir, args...).Run()
+}
+
+// gitSigningKey returns git's user.signingkey setting, or "" when unset.
+func gitSigningKey(dir string) string {
+	out, _ := cmdGit(dir, "config", "--get", "user.signingkey").Output() // unset key exits non-zero
+	return strings.TrimSpace(string(out))
+}
+
 // gitTagAt creates a tag pointing at the given ref (commit, tag, or branch).
 func gitTagAt(name, ref, dir string) error {
 	return cmdGit(dir, "tag", name, ref).Run()
@@ -188,6 +212,30 @@ func gitRevParseHEAD(dir string) (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
+// gitRevParseCommit resolves ref to a full commit SHA. Returns an error
+// when ref does not name a commit.
+func gitRevParseCommit(ref, dir string) (string, error) {
+	out, err := cmdGit(dir, "rev-parse", "--verify", "--quiet", ref+"^{commit}").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// gitRemoteURL returns the fetch URL of the named remote.
+func gitRemoteURL(remote, dir string) (string, error) {
+	out, err := cmdGit(dir, "remote", "get-url", remote).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// gitIsAncestor reports whether commit is reachable from ref.
+func gitIsAncestor(commit, ref, dir string) bool {
+	return cmdGit(dir, "merge-base", "--is-ancestor", commit, ref).Run() == nil
+}
+
 func gitResetSoft(ref, dir string) error {
 	return cmdGit(dir, "reset", "--soft", ref).Run()
 }
@@ -6,6 +6,9 @@ package orchestrator
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -46,6 +49,12 @@ type ProjectConfig struct {
 	// Source code is handled separately by GoSourceDirs.
 	ContextSources string `yaml:"context_sources"`
 
+	// SpecGlobs lists extra glob patterns for specification files outside
+	// the standard document structure. Matching files are counted in the
+	// stats spec word counts under the "spec_globs" category. Each pattern
+	// must be valid filepath.Match syntax; Validate rejects bad patterns.
+	SpecGlobs []string `yaml:"spec_globs"`
+
 	// ContextInclude is a newline-delimited list of glob patterns. When
 	// set, these patterns replace the standard document discovery
 	// (resolveStandardFiles). Only matching files are loaded into the
@@ -82,11 +91,90 @@ type ProjectConfig struct {
 	// If empty, resolveTargetRepo derives it from ModulePath.
 	TargetRepo string `yaml:"target_repo"`
 
+	// PRDDir is the directory holding product requirement documents
+	// (prd*.yaml) read by the analyze and pre-cycle checks
+	// (default "docs/specs/product-requirements").
+	PRDDir string `yaml:"prd_dir"`
+
+	// UseCaseDir is the directory holding use case files (rel*.yaml)
+	// (default "docs/specs/use-cases").
+	UseCaseDir string `yaml:"use_case_dir"`
+
+	// TestSuiteDir is the directory holding test suite files
+	// (test-rel*.yaml) (default "docs/specs/test-suites").
+	TestSuiteDir string `yaml:"test_suite_dir"`
+
+	// RoadmapFile is the path to the roadmap read by the analyze,
+	// pre-cycle, and code status checks (default "docs/road-map.yaml").
+	RoadmapFile string `yaml:"roadmap_file"`
+
+	// CheckRoadmapOrder enables an analyze check that reports roadmap
+	// releases not listed in ascending version order (numeric-aware, so
+	// "02.0" < "10.0"). Default false, since some teams order releases
+	// deliberately.
+	CheckRoadmapOrder bool `yaml:"check_roadmap_order"`
+
+	// CheckDuplicateTouchpoints enables an analyze check that reports use
+	// cases whose touchpoints cite the same PRD requirement more than
+	// once. Default false, since existing specs often repeat a citation
+	// across touchpoints.
+	CheckDuplicateTouchpoints bool `yaml:"check_duplicate_touchpoints"`
+
+	// CheckUncitedRequirements enables an analyze check that reports PRD
+	// requirement groups no use case touchpoint cites. Default false,
+	// since PRDs commonly carry requirements planned for later releases.
+	CheckUncitedRequirements bool `yaml:"check_uncited_requirements"`
+
 	// SeedFiles maps relative file paths to template source file paths.
 	// During LoadConfig, each source path is read and its content replaces
 	// the map value. During generator:start and generator:reset the content
 	// strings are executed as Go text/template templates with SeedData.
 	SeedFiles map[string]string `yaml:"seed_files"`
+
+	// OptionalSeedFiles maps relative file paths to seed templates that
+	// are rendered only when the ProjectConfig field named by When is
+	// non-empty (e.g. a cmd/main.go seeded only when main_package is set).
+	// Templates are loaded and executed the same way as SeedFiles; skipped
+	// entries are logged. A path may not appear in both maps.
+	OptionalSeedFiles map[string]OptionalSeedFile `yaml:"optional_seed_files"`
+}
+
+// OptionalSeedFile is a seed template gated on a project config field.
+type OptionalSeedFile struct {
+	// Template is the template source file path. LoadConfig replaces it
+	// with the file content.
+	Template string `yaml:"template"`
+
+	// When is the yaml key of a string field in the project section
+	// (e.g. "main_package", "binary_name"). The file is seeded only when
+	// that field is non-empty.
+	When string `yaml:"when"`
+}
+
+// seedConditionValue returns the value of the ProjectConfig string field
+// whose yaml key is field, and false if no such field exists.
+func (p ProjectConfig) seedConditionValue(field string) (string, bool) {
+	switch field {
+	case "module_path":
+		return p.ModulePath, true
+	case "binary_name":
+		return p.BinaryName, true
+	case "binary_dir":
+		return p.BinaryDir, true
+	case "main_package":
+		return p.MainPackage, true
+	case "version_file":
+		return p.VersionFile, true
+	case "magefiles_dir":
+		return p.MagefilesDir, true
+	case "context_sources":
+		return p.ContextSources, true
+	case "release":
+		return p.Release, true
+	case "target_repo":
+		return p.TargetRepo, true
+	}
+	return "", false
 }
 
 // GenerationConfig holds settings for the generation lifecycle.
@@ -134,10 +222,66 @@ type CobblerConfig struct {
 	// processes before calling measure again (default 10).
 	MaxStitchIssuesPerCycle int `yaml:"max_stitch_issues_per_cycle"`
 
+	// MaxConsecutiveStitchFailures aborts the stitch cycle after this many
+	// tasks in a row are reset because of a build gate or Claude failure.
+	// A success resets the count. Default 0 disables the breaker.
+	MaxConsecutiveStitchFailures int `yaml:"max_consecutive_stitch_failures"`
+
+	// FormatStitchChanges runs gofmt -w on the .go files Claude added or
+	// modified in the stitch worktree before they are committed, so the
+	// task commit carries no formatting noise. Non-Go files are left
+	// untouched. Default false.
+	FormatStitchChanges bool `yaml:"format_stitch_changes"`
+
+	// FormatStitchGoimports additionally runs goimports -w on those files
+	// when FormatStitchChanges is set. goimports must be on PATH; when it
+	// is missing the step logs a warning and only gofmt runs.
+	FormatStitchGoimports bool `yaml:"format_stitch_goimports"`
+
+	// StitchTests runs StitchTestCommand in the stitch worktree after
+	// Claude's changes are committed and records the package pass/fail
+	// counts as a comment on the task's issue. The output is saved to the
+	// history directory. Default false.
+	StitchTests bool `yaml:"stitch_tests"`
+
+	// StitchTestCommand is the command StitchTests runs, as argv
+	// (default ["go", "test", "./..."]).
+	StitchTestCommand []string `yaml:"stitch_test_command"`
+
+	// StitchTestFailureReview labels a task cobbler-needs-review instead
+	// of merging it when its StitchTests run fails. When false, a failing
+	// run is recorded and the task is merged as usual.
+	StitchTestFailureReview bool `yaml:"stitch_test_failure_review"`
+
+	// CommitAuthorName and CommitAuthorEmail set the author of the commits
+	// stitch makes in task worktrees, so agent-written changes are
+	// attributed to a distinct identity. Either one left empty falls back
+	// to the ambient git configuration.
+	CommitAuthorName  string `yaml:"commit_author_name"`
+	CommitAuthorEmail string `yaml:"commit_author_email"`
+
 	// MaxMeasureIssues is the maximum number of new issues to create per
 	// measure pass (default 1).
 	MaxMeasureIssues int `yaml:"max_measure_issues"`
 
+	// MaxMeasureTokens caps the estimated size of the assembled measure
+	// prompt, in tokens (bytes/4, the same estimate TokenStats reports).
+	// A prompt over the cap fails before Claude is invoked. Default 0
+	// disables the check.
+	MaxMeasureTokens int `yaml:"max_measure_tokens"`
+
+	// MaxMeasureTokensWarnOnly turns the MaxMeasureTokens check into a
+	// warning: an oversized prompt is logged with its estimate and the
+	// limit, and measure proceeds. Useful while calibrating the limit.
+	// Default false.
+	MaxMeasureTokensWarnOnly bool `yaml:"max_measure_tokens_warn_only"`
+
+	// MaxIssues is a deprecated alias for MaxMeasureIssues. When set and
+	// MaxMeasureIssues is zero, applyDefaults copies it over and logs a
+	// deprecation warning; the alias is then cleared.
+	// Deprecated: use MaxMeasureIssues instead.
+	MaxIssues int `yaml:"max_issues,omitempty"`
+
 	// UserPrompt provides additional context for the measure prompt.
 	UserPrompt string `yaml:"user_prompt"`
 
@@ -151,6 +295,23 @@ type CobblerConfig struct {
 	// If empty, the embedded default is used.
 	StitchPrompt string `yaml:"stitch_prompt"`
 
+	// StitchPromptExtensions lists file paths whose contents are appended
+	// to every stitch prompt. Unlike StitchPrompt, the files are read when
+	// the prompt is built; missing files are logged and skipped. A per-issue
+	// extension at {Dir}/extensions/{taskID}.txt is appended after these
+	// when present.
+	StitchPromptExtensions []string `yaml:"stitch_prompt_extensions"`
+
+	// MeasurePromptFooter is text appended as the last section (footer)
+	// of every measure prompt, after the constitutions and task context,
+	// e.g. repository-specific conventions. Empty (the default) adds
+	// nothing.
+	MeasurePromptFooter string `yaml:"measure_prompt_footer"`
+
+	// StitchPromptFooter is MeasurePromptFooter for stitch prompts; it
+	// follows the extensions.
+	StitchPromptFooter string `yaml:"stitch_prompt_footer"`
+
 	// PlanningConstitution is a file path to a custom planning constitution YAML.
 	// During LoadConfig the file is read and its content stored here.
 	// If empty, the embedded default is used.
@@ -185,6 +346,13 @@ type CobblerConfig struct {
 	// example's style, granularity, and naming conventions.
 	GoldenExample string `yaml:"golden_example"`
 
+	// GoldenExamples maps deliverable_type values (e.g. "code",
+	// "documentation") to golden example file paths, read by LoadConfig
+	// like GoldenExample. The measure prompt includes every example under
+	// a "## Golden Example: <Type>" heading. When non-empty it takes
+	// precedence over GoldenExample.
+	GoldenExamples map[string]string `yaml:"golden_examples"`
+
 	// MaxContextBytes is the maximum serialized size (in bytes) of the
 	// ProjectContext injected into the stitch prompt. When the context
 	// exceeds this budget, non-required source files are progressively
@@ -198,6 +366,17 @@ type CobblerConfig struct {
 	// logged as advisory warnings and import proceeds.
 	EnforceMeasureValidation bool `yaml:"enforce_measure_validation"`
 
+	// QuietImport suppresses the summary that import prints to stdout
+	// (issues validated, issues with warnings, issues created, and the
+	// validation warnings). Default false.
+	QuietImport bool `yaml:"quiet_import"`
+
+	// OpenCreatedIssues prints the URLs of the issues measure created and
+	// opens them in the browser. The URLs are not printed when QuietImport
+	// is set, and the browser is not opened when the CI environment
+	// variable is set. Default false.
+	OpenCreatedIssues bool `yaml:"open_created_issues"`
+
 	// MaxMeasureRetries is the maximum number of retry attempts per iteration
 	// when EnforceMeasureValidation rejects the output. When 0 (default),
 	// no retries are attempted. A value of 2-3 is recommended.
@@ -209,10 +388,96 @@ type CobblerConfig struct {
 	// is disabled and requirement count is governed only by P9 range rules.
 	MaxRequirementsPerTask int `yaml:"max_requirements_per_task"`
 
+	// MaxACPerTask is the maximum number of acceptance criteria a single
+	// proposed task may contain, for code and documentation tasks alike.
+	// When exceeded the task is rejected. When 0 (default), the limit is
+	// disabled.
+	MaxACPerTask int `yaml:"max_ac_per_task"`
+
+	// MaxFilesPerTask is the maximum number of files a single proposed
+	// task may list. The limit is appended to the measure prompt
+	// constraints, caps the {files_per_task} range, and a task over it is
+	// rejected. When 0 (default), the limit is disabled.
+	MaxFilesPerTask int `yaml:"max_files_per_task"`
+
+	// EffortValues lists the accepted effort labels for proposed issues
+	// (default ["S", "M", "L"]). An effort outside this set, and not a
+	// valid hour estimate under EffortMaxHours, is a validation error.
+	EffortValues []string `yaml:"effort_values"`
+
+	// EffortMaxHours enables numeric hour estimates as effort values. When
+	// greater than 0, an effort that parses as a number from 1 to
+	// EffortMaxHours is accepted. Default 0 disables hour estimates.
+	EffortMaxHours int `yaml:"effort_max_hours"`
+
+	// DefaultEffort is assigned to proposed issues that omit effort
+	// (default "M"). It must itself pass the EffortValues and
+	// EffortMaxHours rules; Validate rejects a default that does not.
+	DefaultEffort string `yaml:"default_effort"`
+
+	// WarnRequirementIDGaps enables an advisory warning when the numeric
+	// requirement IDs of a proposed task are not contiguous from R1 (e.g.,
+	// R1, R2, R4). Gaps usually mean a requirement was deleted while a
+	// reference still points at it. Default false, since some projects
+	// leave gaps intentionally.
+	WarnRequirementIDGaps bool `yaml:"warn_requirement_id_gaps"`
+
+	// EnforceRequirementIDFormat enables an advisory warning for every
+	// item ID in a proposed task that does not follow the numbered form:
+	// R<n> for requirements, AC<n> for acceptance criteria, and D<n> for
+	// design decisions. Default false, since some projects use descriptive
+	// IDs such as "REQ-SECURITY-1".
+	EnforceRequirementIDFormat bool `yaml:"enforce_requirement_id_format"`
+
+	// DeliverableExtensions maps a deliverable_type to the file extensions
+	// expected in its "files" list. Validation warns when none of a
+	// proposed task's files carries an expected extension, which usually
+	// means the task is mislabeled. Types not in the map are not checked.
+	// Default {"code": [".go"], "documentation": [".md", ".yaml", ".yml"]}.
+	DeliverableExtensions map[string][]string `yaml:"deliverable_extensions"`
+
+	// GuardImplementedUseCases enables the measure guard for use cases
+	// that CodeStatus reports as implemented. When true, the measure
+	// prompt lists those use cases as off limits and validation warns
+	// about any proposed issue that references one of them. Default false.
+	GuardImplementedUseCases bool `yaml:"guard_implemented_use_cases"`
+
+	// RequireMeasureContext makes measure refuse to call Claude when the
+	// project context has no roadmap, no specs, and no source code, which
+	// usually means measure was run outside the project root. Use
+	// MeasureForce (mage cobbler:measureForce) to run anyway. Default false.
+	RequireMeasureContext bool `yaml:"require_measure_context"`
+
 	// HistoryDir is the directory for saving measure artifacts (prompt,
 	// issues YAML, stream-json log) per iteration. Default "history".
+	// A relative HistoryDir is resolved under ArtifactsDir.
 	HistoryDir string `yaml:"history_dir"`
 
+	// ArtifactsDir holds the measure log (measure.yaml) and a relative
+	// HistoryDir, so CI can upload run artifacts separately from the
+	// scratch state in Dir. Default "" uses Dir.
+	ArtifactsDir string `yaml:"artifacts_dir"`
+
+	// HistoryFileNaming is a text/template that names the measure history
+	// files written by saveHistory. It receives .Timestamp, .Generation,
+	// and .Type ("issues" or "log"); the extension (.yaml or .log) is
+	// appended to the rendered name. Default
+	// "{{.Timestamp}}-measure-{{.Type}}", which gives the historical
+	// {ts}-measure-issues.yaml and {ts}-measure-log.log names. LoadConfig
+	// rejects a template that does not parse.
+	HistoryFileNaming string `yaml:"history_file_naming"`
+
+	// AnalysisFileName is the name of the pre-cycle analysis file written
+	// to the cobbler directory (default "analysis.yaml"). Separate names
+	// let several environments keep their own analysis side by side.
+	AnalysisFileName string `yaml:"analysis_file_name"`
+
+	// CaptureStderr appends Claude's stderr to
+	// {Dir}/claude-stderr-{timestamp}.log. In silent mode stderr is
+	// otherwise discarded; when not silent it is written to both the log
+	// file and the terminal. Default false.
+	CaptureStderr bool `yaml:"capture_stderr"`
+
 	// DocTagPrefix is the prefix used when creating documentation release
 	// tags (default "v0."). Tags are formed as <DocTagPrefix><YYYYMMDD>.<N>.
 	DocTagPrefix string `yaml:"doc_tag_prefix"`
@@ -221,6 +486,95 @@ type CobblerConfig struct {
 	// be created (default "main"). Tag() returns an error if the current
 	// branch does not match this value.
 	BaseBranch string `yaml:"base_branch"`
+
+	// SignTags makes Tag create GPG-signed annotated tags (git tag -s).
+	// Default false creates lightweight unsigned tags. When true and no
+	// signing key is available, Tag returns an error instead of falling
+	// back to an unsigned tag.
+	SignTags bool `yaml:"sign_tags"`
+
+	// SigningKey is the key ID passed to git tag -u when SignTags is set.
+	// When empty, git's user.signingkey setting is used.
+	SigningKey string `yaml:"signing_key"`
+
+	// IssueCreateDelayMs is the delay in milliseconds between successive
+	// issue creations during import. Large batches can trip GitHub API
+	// throttling; a delay spreads the requests out. The wait is skipped
+	// after the final issue and can be interrupted with Ctrl-C. When 0
+	// (the default), issues are created back to back.
+	IssueCreateDelayMs int `yaml:"issue_create_delay_ms"`
+
+	// PreCycleHook is a shell command run via "sh -c" in the repository
+	// root before RunPreCycleAnalysis starts its checks, e.g. to regenerate
+	// derived spec files. A non-zero exit is logged and the analysis
+	// continues. When empty (the default), no hook runs.
+	PreCycleHook string `yaml:"pre_cycle_hook"`
+
+	// PostAnalysisHook is a shell command run via "sh -c" in the repository
+	// root after RunPreCycleAnalysis writes the analysis file. The hook
+	// receives COBBLER_ANALYSIS_FILE (the file path) and
+	// COBBLER_TOTAL_ISSUES (consistency errors plus code gaps) in its
+	// environment. A non-zero exit is recorded as a warning in the analysis
+	// file. When empty (the default), no hook runs.
+	PostAnalysisHook string `yaml:"post_analysis_hook"`
+
+	// CodeStatusFormat selects the output format of CodeStatus: "text"
+	// (default, human-readable), "yaml", or "json". Unknown values fall
+	// back to text with a warning.
+	CodeStatusFormat string `yaml:"code_status_format"`
+
+	// ReadinessThreshold is the fraction of a release's use cases
+	// (0 < t <= 1) that must be implemented for CodeStatus to label a
+	// partially implemented release "ready". A "done" release that is
+	// ready is not reported as a spec-vs-code gap. Default 0 disables the
+	// label.
+	ReadinessThreshold float64 `yaml:"readiness_threshold"`
+
+	// ReleaseReadinessThresholds overrides ReadinessThreshold per release
+	// version (e.g. "01.0": 0.8).
+	ReleaseReadinessThresholds map[string]float64 `yaml:"release_readiness_thresholds"`
+
+	// CacheTestScan enables a test directory scan cache for CodeStatus,
+	// stored in the cobbler directory. A UC test directory is re-counted
+	// only when its modification time changed since the last scan in the
+	// same working directory; an injected fs.FS is never cached.
+	// Default false (every run walks the full tests tree).
+	CacheTestScan bool `yaml:"cache_test_scan"`
+
+	// TestFileSuffixes lists the file name suffixes CodeStatus counts as
+	// tests in a tests/relXX.Y/ucNNN directory, so that non-Go specs such
+	// as ".feature" or "_spec.yaml" mark a use case as tested. Default
+	// ["_test.go"].
+	TestFileSuffixes []string `yaml:"test_file_suffixes"`
+
+	// StatsWorkers bounds the number of Go files CollectStats reads
+	// concurrently. Default 0 uses GOMAXPROCS.
+	StatsWorkers int `yaml:"stats_workers"`
+
+	// StatsProgress makes CollectStats log the number of Go files
+	// processed while counting lines. Default false keeps stats output
+	// quiet for scripting.
+	StatsProgress bool `yaml:"stats_progress"`
+
+	// MinTestRatio is the lowest accepted ratio of Go test LOC to
+	// production LOC; CheckTestRatio fails below it. Default 0 disables
+	// the check.
+	MinTestRatio float64 `yaml:"min_test_ratio"`
+
+	// MaxSpecFileSizeBytes is the largest spec YAML file (PRDs, use cases,
+	// test suites, roadmap, and the other context documents) the
+	// orchestrator reads; larger files are skipped with a logged error
+	// instead of being read into memory. Unset means 10 MB; set it to 0
+	// (or a negative value) to remove the limit. Read it with
+	// EffectiveMaxSpecFileSize.
+	MaxSpecFileSizeBytes *int64 `yaml:"max_spec_file_size_bytes"`
+
+	// MeasureIssueFilter is an optional predicate applied to each proposed
+	// issue before validation. Issues for which it returns false are dropped
+	// from the import. The issue's Parsed field holds the unmarshaled
+	// description when it is valid YAML. When nil, all issues pass. Set it
+	// in Go code; it cannot be expressed in configuration.yaml.
+	MeasureIssueFilter func(issue ProposedIssue) bool `yaml:"-"`
 }
 
 // PodmanConfig holds settings for the podman container runtime.
@@ -252,6 +606,12 @@ type ClaudeConfig struct {
 	// If empty, DefaultTokenFile is used.
 	TokenFile string `yaml:"token_file"`
 
+	// TokenEnv names the environment variable that supplies the Claude
+	// OAuth token when the credential file in SecretsDir is absent, so CI
+	// does not need to materialize a file. The file takes precedence when
+	// present. Default: CLAUDE_CODE_OAUTH_TOKEN.
+	TokenEnv string `yaml:"token_env"`
+
 	// MaxTimeSec is the maximum duration in seconds for a single Claude
 	// invocation (default 300, i.e. 5 minutes). If the time expires, the
 	// process is killed and the task is returned to beads.
@@ -262,6 +622,14 @@ type ClaudeConfig struct {
 	// Default: /home/crumbs/.claude/.credentials.json
 	ContainerCredentialsPath string `yaml:"container_credentials_path"`
 
+	// OutputFormat is the format of Claude's stdout, used to parse token
+	// usage and to extract the response text (measure reads its issues
+	// YAML from it): "stream-json" (default, matching the default Args),
+	// "text" for plain output with the human-readable usage summary, or
+	// "auto" to detect it from the output. LoadConfig rejects any other
+	// value.
+	OutputFormat string `yaml:"output_format"`
+
 	// Temperature controls the randomness of Claude's output. Lower values
 	// produce more deterministic output. When 0 (the default), no temperature
 	// parameter is passed and Claude uses its built-in default.
@@ -271,6 +639,38 @@ type ClaudeConfig struct {
 	// value, the orchestrator logs a warning that the parameter cannot be
 	// passed through to the CLI.
 	Temperature float64 `yaml:"temperature"`
+
+	// CostModel holds per-token dollar rates used to estimate invocation
+	// cost in reports (GenerationLog, Outcomes). All rates default to 0,
+	// which yields an estimated cost of 0.
+	CostModel CostModel `yaml:"cost_model"`
+
+	// Fixture is the path to a canned Claude stream-json output file. When
+	// set, measure, MeasureDryRun and ReplayInvocation replay the file
+	// content instead of launching the container and skip the podman and
+	// credential checks, so they run deterministically without spending
+	// tokens. Stitch always invokes Claude. The COBBLER_CLAUDE_FIXTURE
+	// environment variable overrides this field, with a warning. Empty
+	// (the default) invokes Claude normally.
+	Fixture string `yaml:"fixture"`
+}
+
+// CostModel maps token counts to an estimated dollar cost. Each rate is
+// the price in USD of a single token of that kind.
+type CostModel struct {
+	// InputUSDPerToken is the price of one uncached input token.
+	InputUSDPerToken float64 `yaml:"input_usd_per_token"`
+
+	// OutputUSDPerToken is the price of one output token.
+	OutputUSDPerToken float64 `yaml:"output_usd_per_token"`
+
+	// CacheCreationUSDPerToken is the price of one token written to the
+	// prompt cache.
+	CacheCreationUSDPerToken float64 `yaml:"cache_creation_usd_per_token"`
+
+	// CacheReadUSDPerToken is the price of one token read from the prompt
+	// cache.
+	CacheReadUSDPerToken float64 `yaml:"cache_read_usd_per_token"`
 }
 
 // Config holds all orchestrator settings. Consuming repos either
@@ -340,11 +740,106 @@ func (c *Config) EffectiveTokenFile() string {
 	return c.Claude.DefaultTokenFile
 }
 
+// Default docs layout used when the corresponding ProjectConfig field is
+// empty. Only the Effective* accessors below read these.
+const (
+	defaultPRDDir       = "docs/specs/product-requirements"
+	defaultUseCaseDir   = "docs/specs/use-cases"
+	defaultTestSuiteDir = "docs/specs/test-suites"
+	defaultRoadmapFile  = "docs/road-map.yaml"
+)
+
+// EffectivePRDDir returns PRDDir, or the default when it is empty.
+func (c *Config) EffectivePRDDir() string {
+	if c.Project.PRDDir != "" {
+		return c.Project.PRDDir
+	}
+	return defaultPRDDir
+}
+
+// EffectiveUseCaseDir returns UseCaseDir, or the default when it is empty.
+func (c *Config) EffectiveUseCaseDir() string {
+	if c.Project.UseCaseDir != "" {
+		return c.Project.UseCaseDir
+	}
+	return defaultUseCaseDir
+}
+
+// EffectiveTestSuiteDir returns TestSuiteDir, or the default when it is
+// empty.
+func (c *Config) EffectiveTestSuiteDir() string {
+	if c.Project.TestSuiteDir != "" {
+		return c.Project.TestSuiteDir
+	}
+	return defaultTestSuiteDir
+}
+
+// EffectiveRoadmapFile returns RoadmapFile, or the default when it is
+// empty.
+func (c *Config) EffectiveRoadmapFile() string {
+	if c.Project.RoadmapFile != "" {
+		return c.Project.RoadmapFile
+	}
+	return defaultRoadmapFile
+}
+
+// EffectiveAnalysisFileName returns Cobbler.AnalysisFileName, or
+// defaultAnalysisFileName when it is empty.
+func (c *Config) EffectiveAnalysisFileName() string {
+	if c.Cobbler.AnalysisFileName != "" {
+		return c.Cobbler.AnalysisFileName
+	}
+	return defaultAnalysisFileName
+}
+
+// EffectiveArtifactsDir returns Cobbler.ArtifactsDir, or Cobbler.Dir when
+// it is empty.
+func (c *Config) EffectiveArtifactsDir() string {
+	if c.Cobbler.ArtifactsDir != "" {
+		return c.Cobbler.ArtifactsDir
+	}
+	return c.Cobbler.Dir
+}
+
+// EffectiveMaxSpecFileSize returns the spec file size limit in bytes:
+// Cobbler.MaxSpecFileSizeBytes, or 10 MB when it is unset. It returns 0,
+// meaning no limit, when the setting is 0 or negative.
+func (c *Config) EffectiveMaxSpecFileSize() int64 {
+	if c.Cobbler.MaxSpecFileSizeBytes == nil {
+		return defaultMaxSpecFileSize
+	}
+	return max(*c.Cobbler.MaxSpecFileSizeBytes, 0)
+}
+
 // ClaudeTimeout returns the max Claude invocation time as a Duration.
 func (c *Config) ClaudeTimeout() time.Duration {
 	return time.Duration(c.Claude.MaxTimeSec) * time.Second
 }
 
+// claudeFixtureEnv names the environment variable that overrides
+// Claude.Fixture.
+const claudeFixtureEnv = "COBBLER_CLAUDE_FIXTURE"
+
+// ClaudeFixture returns the canned Claude output path from
+// COBBLER_CLAUDE_FIXTURE, or Claude.Fixture when the variable is unset.
+// An environment value that differs from Claude.Fixture is logged as a
+// warning, since it silently replaces real Claude calls.
+func (c *Config) ClaudeFixture() string {
+	if v := os.Getenv(claudeFixtureEnv); v != "" {
+		if v != c.Claude.Fixture {
+			logf("config warning: %s=%s overrides claude.fixture %q; measure and replay will not invoke Claude",
+				claudeFixtureEnv, v, c.Claude.Fixture)
+		}
+		return v
+	}
+	return c.Claude.Fixture
+}
+
+// IssueCreateDelay returns the delay between issue creations as a Duration.
+func (c *Config) IssueCreateDelay() time.Duration {
+	return time.Duration(c.Cobbler.IssueCreateDelayMs) * time.Millisecond
+}
+
 // readFileInto reads the file at the path stored in *field and replaces
 // the value with the file content. If *field is empty, it is a no-op.
 func readFileInto(field *string) error {
@@ -363,6 +858,12 @@ func (c *Config) applyDefaults() {
 	if c.Project.BinaryDir == "" {
 		c.Project.BinaryDir = "bin"
 	}
+	// The docs layout defaults are resolved by the Effective* accessors
+	// only, which also serve Configs that never went through applyDefaults.
+	c.Project.PRDDir = c.EffectivePRDDir()
+	c.Project.UseCaseDir = c.EffectiveUseCaseDir()
+	c.Project.TestSuiteDir = c.EffectiveTestSuiteDir()
+	c.Project.RoadmapFile = c.EffectiveRoadmapFile()
 	if c.Generation.Prefix == "" {
 		c.Generation.Prefix = "generation-"
 	}
@@ -378,33 +879,61 @@ func (c *Config) applyDefaults() {
 	if c.Claude.DefaultTokenFile == "" {
 		c.Claude.DefaultTokenFile = "claude.json"
 	}
+	if c.Claude.TokenEnv == "" {
+		c.Claude.TokenEnv = "CLAUDE_CODE_OAUTH_TOKEN"
+	}
 	if len(c.Claude.Args) == 0 {
 		c.Claude.Args = defaultClaudeArgs
 	}
 	if c.Cobbler.MaxStitchIssuesPerCycle == 0 {
 		c.Cobbler.MaxStitchIssuesPerCycle = 10
 	}
+	c.migrateMaxIssues()
 	if c.Cobbler.MaxMeasureIssues == 0 {
 		c.Cobbler.MaxMeasureIssues = 1
 	}
 	if c.Cobbler.EstimatedLinesMin == 0 {
 		c.Cobbler.EstimatedLinesMin = 250
 	}
+	if len(c.Cobbler.EffortValues) == 0 {
+		c.Cobbler.EffortValues = []string{"S", "M", "L"}
+	}
+	if c.Cobbler.DefaultEffort == "" {
+		c.Cobbler.DefaultEffort = "M"
+	}
+	if len(c.Cobbler.TestFileSuffixes) == 0 {
+		c.Cobbler.TestFileSuffixes = []string{"_test.go"}
+	}
+	if c.Cobbler.DeliverableExtensions == nil {
+		c.Cobbler.DeliverableExtensions = map[string][]string{
+			"code":          {".go"},
+			"documentation": {".md", ".yaml", ".yml"},
+		}
+	}
 	if c.Cobbler.EstimatedLinesMax == 0 {
 		c.Cobbler.EstimatedLinesMax = 350
 	}
 	if c.Cobbler.HistoryDir == "" {
 		c.Cobbler.HistoryDir = "history"
 	}
+	if c.Cobbler.HistoryFileNaming == "" {
+		c.Cobbler.HistoryFileNaming = defaultHistoryFileNaming
+	}
 	if c.Cobbler.DocTagPrefix == "" {
 		c.Cobbler.DocTagPrefix = "v0."
 	}
 	if c.Cobbler.BaseBranch == "" {
 		c.Cobbler.BaseBranch = "main"
 	}
+	if c.Cobbler.CodeStatusFormat == "" {
+		c.Cobbler.CodeStatusFormat = codeStatusFormatText
+	}
 	if c.Claude.MaxTimeSec == 0 {
 		c.Claude.MaxTimeSec = 300
 	}
+	if c.Claude.OutputFormat == "" {
+		c.Claude.OutputFormat = claudeOutputStreamJSON
+	}
 	if c.Claude.ContainerCredentialsPath == "" {
 		c.Claude.ContainerCredentialsPath = "/home/crumbs/.claude/.credentials.json"
 	}
@@ -413,12 +942,164 @@ func (c *Config) applyDefaults() {
 	}
 }
 
+// validateDangerousPaths checks directories that the orchestrator removes
+// wholesale (Cobbler.Dir on CobblerReset, Project.BinaryDir on Clean and
+// generator cleanup) against misconfigurations that would delete
+// production code. Every path is made absolute against the current
+// working directory (the repo root) so that "./", "../repo" and absolute
+// aliases compare equal. It flags a directory that is the repo root or
+// one of its ancestors (including "/"), and a directory that overlaps any
+// entry in Project.GoSourceDirs, in either direction. Returns one warning
+// string per problem; an empty result means the paths are safe.
+func validateDangerousPaths(cfg Config) []string {
+	root := absOrClean(".")
+
+	var warnings []string
+	for _, d := range []struct {
+		field string
+		path  string
+	}{
+		{"cobbler.dir", cfg.Cobbler.Dir},
+		{"project.binary_dir", cfg.Project.BinaryDir},
+	} {
+		if d.path == "" {
+			continue
+		}
+		abs := absOrClean(d.path)
+		if pathWithin(root, abs) || abs == string(filepath.Separator) {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s %q resolves to the repository root or one of its ancestors; removing it would delete the project", d.field, d.path))
+			continue
+		}
+		for _, src := range cfg.Project.GoSourceDirs {
+			if src == "" {
+				continue
+			}
+			absSrc := absOrClean(src)
+			if pathWithin(abs, absSrc) || pathWithin(absSrc, abs) {
+				warnings = append(warnings, fmt.Sprintf(
+					"%s %q overlaps go_source_dirs entry %q; removing it would delete Go source", d.field, d.path, src))
+			}
+		}
+	}
+	return warnings
+}
+
+// absOrClean returns the absolute form of p, or p cleaned when the
+// working directory cannot be determined.
+func absOrClean(p string) string {
+	if abs, err := filepath.Abs(p); err == nil {
+		return abs
+	}
+	return filepath.Clean(p)
+}
+
+// pathWithin reports whether path is dir or lies inside it. Both must be
+// clean and either both absolute or both relative.
+func pathWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// migrateMaxIssues moves the deprecated Cobbler.MaxIssues value into
+// MaxMeasureIssues when the latter is unset. MaxIssues is cleared
+// afterwards so repeated applyDefaults calls (LoadConfig then New) warn
+// only once.
+func (c *Config) migrateMaxIssues() {
+	if c.Cobbler.MaxIssues == 0 {
+		return
+	}
+	if c.Cobbler.MaxMeasureIssues == 0 {
+		c.Cobbler.MaxMeasureIssues = c.Cobbler.MaxIssues
+		logf("config warning: cobbler.max_issues is deprecated, use max_measure_issues (using %d)", c.Cobbler.MaxIssues)
+	} else {
+		logf("config warning: cobbler.max_issues is deprecated and ignored because max_measure_issues is set (%d)", c.Cobbler.MaxMeasureIssues)
+	}
+	c.Cobbler.MaxIssues = 0
+}
+
 // LoadConfig reads a configuration YAML file and returns a Config.
 // For SeedFiles entries, the values are treated as file paths: LoadConfig
-// reads each file and replaces the map value with its content.
+// reads each file and replaces the map value with its content. The same
+// applies to the Template of each OptionalSeedFiles entry.
 // For MeasurePrompt and StitchPrompt, if non-empty LoadConfig reads
 // the referenced file.
 func LoadConfig(path string) (Config, error) {
+	cfg, err := parseConfigFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	if err := cfg.resolve(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// LoadConfigFiles replaces the Orchestrator's configuration with the
+// overlay of the given config files. Files are read in order and each
+// non-zero field of a later file overrides the value from earlier files;
+// map fields (SeedFiles, OptionalSeedFiles, GoldenExamples) are merged
+// key by key with later entries winning. File paths inside the merged
+// config are then read as in LoadConfig and defaults are applied.
+// MeasureIssueFilter cannot be set from YAML and is kept.
+func (o *Orchestrator) LoadConfigFiles(paths ...string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("at least one config file is required")
+	}
+	var cfg Config
+	for _, path := range paths {
+		layer, err := parseConfigFile(path)
+		if err != nil {
+			return fmt.Errorf("loading config from %s: %w", path, err)
+		}
+		mergeNonZero(reflect.ValueOf(&cfg).Elem(), reflect.ValueOf(layer))
+	}
+	if err := cfg.resolve(); err != nil {
+		return err
+	}
+	for _, w := range validateDangerousPaths(cfg) {
+		logf("config warning: %s", w)
+	}
+	cfg.Cobbler.MeasureIssueFilter = o.cfg.Cobbler.MeasureIssueFilter
+	o.cfg = cfg
+	return nil
+}
+
+// mergeNonZero overlays src onto dst. Structs are merged field by field,
+// maps entry by entry, and any other value replaces dst only when it is
+// non-zero.
+func mergeNonZero(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := range src.NumField() {
+			if dst.Field(i).CanSet() {
+				mergeNonZero(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Map:
+		if src.Len() == 0 {
+			return
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		}
+		iter := src.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(iter.Key(), iter.Value())
+		}
+	default:
+		if !src.IsZero() {
+			dst.Set(src)
+		}
+	}
+}
+
+// parseConfigFile reads and unmarshals one config file without resolving
+// file references or applying defaults.
+func parseConfigFile(path string) (Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return Config{}, fmt.Errorf("reading config file: %w", err)
@@ -428,35 +1109,111 @@ func LoadConfig(path string) (Config, error) {
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return Config{}, fmt.Errorf("parsing config file: %w", err)
 	}
+	return cfg, nil
+}
 
+// resolve replaces the file paths of a parsed config with the file
+// contents, applies defaults, and validates the history file naming
+// template. See LoadConfig for the fields read from disk.
+func (c *Config) resolve() error {
 	// Read seed file templates from disk.
-	for dest, src := range cfg.Project.SeedFiles {
+	for dest, src := range c.Project.SeedFiles {
 		if src == "" {
 			continue
 		}
 		content, err := os.ReadFile(src)
 		if err != nil {
-			return Config{}, fmt.Errorf("reading seed file %s for %s: %w", src, dest, err)
+			return fmt.Errorf("reading seed file %s for %s: %w", src, dest, err)
 		}
-		cfg.Project.SeedFiles[dest] = string(content)
+		c.Project.SeedFiles[dest] = string(content)
+	}
+	for dest, opt := range c.Project.OptionalSeedFiles {
+		if _, ok := c.Project.SeedFiles[dest]; ok {
+			return fmt.Errorf("seed file %s is listed in both seed_files and optional_seed_files", dest)
+		}
+		if _, ok := c.Project.seedConditionValue(opt.When); !ok {
+			return fmt.Errorf("optional seed file %s: unknown project field %q in when", dest, opt.When)
+		}
+		if opt.Template == "" {
+			continue
+		}
+		content, err := os.ReadFile(opt.Template)
+		if err != nil {
+			return fmt.Errorf("reading seed file %s for %s: %w", opt.Template, dest, err)
+		}
+		opt.Template = string(content)
+		c.Project.OptionalSeedFiles[dest] = opt
 	}
 
 	// Read prompt and constitution files from disk, replacing the path
 	// with the file content.
 	for _, field := range []*string{
-		&cfg.Cobbler.MeasurePrompt,
-		&cfg.Cobbler.StitchPrompt,
-		&cfg.Cobbler.PlanningConstitution,
-		&cfg.Cobbler.ExecutionConstitution,
-		&cfg.Cobbler.DesignConstitution,
-		&cfg.Cobbler.GoStyleConstitution,
-		&cfg.Cobbler.GoldenExample,
+		&c.Cobbler.MeasurePrompt,
+		&c.Cobbler.StitchPrompt,
+		&c.Cobbler.PlanningConstitution,
+		&c.Cobbler.ExecutionConstitution,
+		&c.Cobbler.DesignConstitution,
+		&c.Cobbler.GoStyleConstitution,
+		&c.Cobbler.GoldenExample,
 	} {
 		if err := readFileInto(field); err != nil {
-			return Config{}, err
+			return err
 		}
 	}
+	for kind, path := range c.Cobbler.GoldenExamples {
+		if err := readFileInto(&path); err != nil {
+			return fmt.Errorf("golden example for %s: %w", kind, err)
+		}
+		c.Cobbler.GoldenExamples[kind] = path
+	}
 
-	cfg.applyDefaults()
-	return cfg, nil
+	c.applyDefaults()
+	if _, err := parseHistoryFileNaming(c.Cobbler.HistoryFileNaming); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+// Validate checks configuration values that resolve and applyDefaults
+// cannot repair. An invalid SpecGlobs pattern, an unknown
+// Claude.OutputFormat, or a Cobbler.DefaultEffort outside the effort
+// rules is an error; a valid pattern that matches no files
+// is logged as a warning, since the spec word counts would silently
+// report zero for it.
+func (c *Config) Validate() error {
+	switch c.Claude.OutputFormat {
+	case "", claudeOutputStreamJSON, claudeOutputText, claudeOutputAuto:
+	default:
+		return fmt.Errorf("claude.output_format: unknown value %q (want %s, %s, or %s)",
+			c.Claude.OutputFormat, claudeOutputStreamJSON, claudeOutputText, claudeOutputAuto)
+	}
+	effortRules := measureRules{EffortValues: c.Cobbler.EffortValues, EffortMaxHours: c.Cobbler.EffortMaxHours}
+	if msg := validateEffort(c.Cobbler.DefaultEffort, effortRules); msg != "" {
+		return fmt.Errorf("cobbler.default_effort: %s", msg)
+	}
+	warnings, err := validateSpecGlobs(c.Project.SpecGlobs)
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		logf("config warning: %s", w)
+	}
+	return nil
+}
+
+// validateSpecGlobs returns an error for the first syntactically invalid
+// pattern in globs, and one warning per valid pattern that matches no
+// files relative to the working directory.
+func validateSpecGlobs(globs []string) ([]string, error) {
+	var warnings []string
+	for i, pattern := range globs {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("project.spec_globs[%d]: invalid pattern %q: %w", i, pattern, err)
+		}
+		if len(matches) == 0 {
+			warnings = append(warnings, fmt.Sprintf("project.spec_globs pattern %q matches no files", pattern))
+		}
+	}
+	return warnings, nil
 }
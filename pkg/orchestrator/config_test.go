@@ -4,8 +4,12 @@
 package orchestrator
 
 import (
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -124,6 +128,12 @@ func TestLoadConfig_AppliesDefaults(t *testing.T) {
 	if cfg.Cobbler.HistoryDir != "history" {
 		t.Errorf("Cobbler.HistoryDir default: got %q, want \"history\"", cfg.Cobbler.HistoryDir)
 	}
+	if cfg.Project.RoadmapFile != "docs/road-map.yaml" {
+		t.Errorf("Project.RoadmapFile default: got %q, want \"docs/road-map.yaml\"", cfg.Project.RoadmapFile)
+	}
+	if cfg.Project.PRDDir != "docs/specs/product-requirements" {
+		t.Errorf("Project.PRDDir default: got %q", cfg.Project.PRDDir)
+	}
 }
 
 func TestLoadConfig_ConstitutionFileOverride(t *testing.T) {
@@ -153,6 +163,39 @@ func TestLoadConfig_MissingConstitutionFile(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_HistoryFileNamingDefault(t *testing.T) {
+	f := writeTemp(t, "project:\n  module_path: example.com/test\n")
+	cfg, err := LoadConfig(f)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if cfg.Cobbler.HistoryFileNaming != defaultHistoryFileNaming {
+		t.Errorf("HistoryFileNaming = %q, want %q", cfg.Cobbler.HistoryFileNaming, defaultHistoryFileNaming)
+	}
+}
+
+func TestLoadConfig_MalformedHistoryFileNaming(t *testing.T) {
+	f := writeTemp(t, "cobbler:\n  history_file_naming: \"{{.Timestamp\"\n")
+	_, err := LoadConfig(f)
+	if err == nil {
+		t.Fatal("expected error for malformed history_file_naming, got nil")
+	}
+	if !strings.Contains(err.Error(), "history_file_naming") {
+		t.Errorf("error = %q, want it to mention history_file_naming", err)
+	}
+}
+
+func TestEffectiveAnalysisFileName(t *testing.T) {
+	cfg := Config{}
+	if got := cfg.EffectiveAnalysisFileName(); got != "analysis.yaml" {
+		t.Errorf("EffectiveAnalysisFileName() empty = %q, want %q", got, "analysis.yaml")
+	}
+	cfg.Cobbler.AnalysisFileName = "analysis-staging.yaml"
+	if got := cfg.EffectiveAnalysisFileName(); got != "analysis-staging.yaml" {
+		t.Errorf("EffectiveAnalysisFileName() custom = %q, want %q", got, "analysis-staging.yaml")
+	}
+}
+
 func TestLoadConfig_MissingFile(t *testing.T) {
 	_, err := LoadConfig("/nonexistent/configuration.yaml")
 	if err == nil {
@@ -160,6 +203,103 @@ func TestLoadConfig_MissingFile(t *testing.T) {
 	}
 }
 
+// --- LoadConfigFiles ---
+
+func TestLoadConfigFiles_SingleFileMatchesLoadConfig(t *testing.T) {
+	path := writeTemp(t, "project:\n  module_path: example.com/base\ncobbler:\n  max_stitch_issues: 7\n")
+	want, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := New(Config{})
+	if err := o.LoadConfigFiles(path); err != nil {
+		t.Fatalf("LoadConfigFiles: %v", err)
+	}
+	if !reflect.DeepEqual(o.Config(), want) {
+		t.Errorf("LoadConfigFiles(single) = %+v, want %+v", o.Config(), want)
+	}
+}
+
+func TestLoadConfigFiles_LaterFileOverridesString(t *testing.T) {
+	base := writeTemp(t, "project:\n  module_path: example.com/base\n  binary_name: base\n")
+	overlay := writeTemp(t, "project:\n  binary_name: override\n")
+	o := New(Config{})
+	if err := o.LoadConfigFiles(base, overlay); err != nil {
+		t.Fatalf("LoadConfigFiles: %v", err)
+	}
+	if got := o.Config().Project.BinaryName; got != "override" {
+		t.Errorf("BinaryName = %q, want override", got)
+	}
+}
+
+func TestLoadConfigFiles_UnsetFieldKeepsEarlierValue(t *testing.T) {
+	base := writeTemp(t, "project:\n  module_path: example.com/base\ncobbler:\n  max_stitch_issues: 7\n")
+	overlay := writeTemp(t, "project:\n  binary_name: override\n")
+	o := New(Config{})
+	if err := o.LoadConfigFiles(base, overlay); err != nil {
+		t.Fatalf("LoadConfigFiles: %v", err)
+	}
+	cfg := o.Config()
+	if cfg.Project.ModulePath != "example.com/base" {
+		t.Errorf("ModulePath = %q, want value from base file", cfg.Project.ModulePath)
+	}
+	if cfg.Cobbler.MaxStitchIssues != 7 {
+		t.Errorf("MaxStitchIssues = %d, want 7 from base file", cfg.Cobbler.MaxStitchIssues)
+	}
+}
+
+func TestLoadConfigFiles_MapEntriesMergeLaterWins(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"base-readme.tmpl":    "base readme",
+		"base-license.tmpl":   "base license",
+		"overlay-readme.tmpl": "overlay readme",
+		"base-code.yaml":      "base code example",
+		"overlay-doc.yaml":    "overlay doc example",
+	} {
+		os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+	}
+	base := writeTemp(t, fmt.Sprintf(`project:
+  seed_files:
+    README.md: %[1]s/base-readme.tmpl
+    LICENSE: %[1]s/base-license.tmpl
+cobbler:
+  golden_examples:
+    code: %[1]s/base-code.yaml
+`, dir))
+	overlay := writeTemp(t, fmt.Sprintf(`project:
+  seed_files:
+    README.md: %[1]s/overlay-readme.tmpl
+cobbler:
+  golden_examples:
+    documentation: %[1]s/overlay-doc.yaml
+`, dir))
+
+	o := New(Config{})
+	if err := o.LoadConfigFiles(base, overlay); err != nil {
+		t.Fatalf("LoadConfigFiles: %v", err)
+	}
+	cfg := o.Config()
+	wantSeeds := map[string]string{"README.md": "overlay readme", "LICENSE": "base license"}
+	if !reflect.DeepEqual(cfg.Project.SeedFiles, wantSeeds) {
+		t.Errorf("SeedFiles = %v, want %v", cfg.Project.SeedFiles, wantSeeds)
+	}
+	wantGolden := map[string]string{"code": "base code example", "documentation": "overlay doc example"}
+	if !reflect.DeepEqual(cfg.Cobbler.GoldenExamples, wantGolden) {
+		t.Errorf("GoldenExamples = %v, want %v", cfg.Cobbler.GoldenExamples, wantGolden)
+	}
+}
+
+func TestLoadConfigFiles_Errors(t *testing.T) {
+	o := New(Config{})
+	if err := o.LoadConfigFiles(); err == nil {
+		t.Error("expected error with no files")
+	}
+	if err := o.LoadConfigFiles(writeTemp(t, "project: {}\n"), "/nonexistent/configuration.yaml"); err == nil {
+		t.Error("expected error for missing overlay file")
+	}
+}
+
 func TestConfig_Silence_NilDefaultsTrue(t *testing.T) {
 	cfg := Config{}
 	if !cfg.Silence() {
@@ -297,6 +437,39 @@ func TestLoadConfig_SeedFilesMissing(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_OptionalSeedFilesResolved(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	seedSrc := filepath.Join(dir, "main.go.tmpl")
+	if err := os.WriteFile(seedSrc, []byte("package main"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	yaml := "project:\n  optional_seed_files:\n    cmd/main.go:\n      template: " + seedSrc + "\n      when: main_package\n"
+	f := writeTemp(t, yaml)
+	cfg, err := LoadConfig(f)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	opt := cfg.Project.OptionalSeedFiles["cmd/main.go"]
+	if opt.Template != "package main" || opt.When != "main_package" {
+		t.Errorf("OptionalSeedFiles[\"cmd/main.go\"] = %+v, want file content and when", opt)
+	}
+}
+
+func TestLoadConfig_OptionalSeedFilesRejectsBadEntries(t *testing.T) {
+	t.Parallel()
+	cases := map[string]string{
+		"unknown field": "project:\n  optional_seed_files:\n    a.go:\n      when: bogus\n",
+		"duplicate":     "project:\n  seed_files:\n    a.go: \"\"\n  optional_seed_files:\n    a.go:\n      when: main_package\n",
+	}
+	for name, yaml := range cases {
+		if _, err := LoadConfig(writeTemp(t, yaml)); err == nil {
+			t.Errorf("%s: expected LoadConfig error, got nil", name)
+		}
+	}
+}
+
 func TestLoadConfig_MeasurePromptFromFile(t *testing.T) {
 	t.Parallel()
 	dir := t.TempDir()
@@ -363,3 +536,217 @@ func TestWriteDefaultConfig_ExistingFileError(t *testing.T) {
 		t.Error("expected error when file already exists, got nil")
 	}
 }
+
+// --- validateDangerousPaths ---
+
+func TestValidateDangerousPaths_DefaultsSafe(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Project.GoSourceDirs = []string{"cmd/", "pkg/", "internal/", "tests/"}
+	if got := validateDangerousPaths(cfg); len(got) != 0 {
+		t.Errorf("expected no warnings for defaults, got %v", got)
+	}
+}
+
+func TestValidateDangerousPaths_Cases(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name      string
+		cobbler   string
+		binary    string
+		srcDirs   []string
+		wantCount int
+		wantSub   string
+	}{
+		{"cobbler dir is dot", ".", "bin", nil, 1, "cobbler.dir"},
+		{"binary dir is dot", ".cobbler/", ".", nil, 1, "project.binary_dir"},
+		{"binary dir is dot slash", ".cobbler/", "./", nil, 1, "project.binary_dir"},
+		{"cobbler dir is filesystem root", "/", "bin", nil, 1, "cobbler.dir"},
+		{"binary dir is repo root absolute", ".cobbler/", wd, nil, 1, "repository root"},
+		{"cobbler dir equals source dir", "pkg/", "bin", []string{"cmd/", "pkg/"}, 1, `"pkg/"`},
+		{"binary dir inside source dir", ".cobbler/", "pkg/bin", []string{"pkg/"}, 1, "go_source_dirs"},
+		{"source dir inside binary dir", ".cobbler/", "internal", []string{"internal/gen"}, 1, "go_source_dirs"},
+		{"both overlap", "cmd", "pkg", []string{"cmd/", "pkg/"}, 2, "go_source_dirs"},
+		{"sibling prefix is not overlap", ".cobbler/", "pkgbin", []string{"pkg/"}, 0, ""},
+		{"empty binary dir skipped", ".cobbler/", "", []string{"pkg/"}, 0, ""},
+		{"cobbler dir is parent of repo root", "..", "bin", nil, 1, "ancestors"},
+		{"cobbler dir outside repo", "../x", "bin", []string{"pkg/"}, 0, ""},
+		{"binary dir is repo root via parent", ".cobbler/", filepath.Join("..", filepath.Base(wd)), nil, 1, "repository root"},
+		{"absolute alias of source dir", filepath.Join(wd, "pkg"), "bin", []string{"pkg/"}, 1, "go_source_dirs"},
+		{"absolute source dir inside relative binary dir", ".cobbler/", "internal", []string{filepath.Join(wd, "internal", "gen")}, 1, "go_source_dirs"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := Config{
+				Project: ProjectConfig{BinaryDir: tc.binary, GoSourceDirs: tc.srcDirs},
+				Cobbler: CobblerConfig{Dir: tc.cobbler},
+			}
+			got := validateDangerousPaths(cfg)
+			if len(got) != tc.wantCount {
+				t.Fatalf("got %d warnings, want %d: %v", len(got), tc.wantCount, got)
+			}
+			if tc.wantSub != "" && !strings.Contains(got[0], tc.wantSub) {
+				t.Errorf("warning %q does not contain %q", got[0], tc.wantSub)
+			}
+		})
+	}
+}
+
+// --- MaxIssues deprecation ---
+
+// captureStderr runs fn with os.Stderr redirected and returns what was
+// written.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	old := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stderr = w
+	fn()
+	w.Close()
+	os.Stderr = old
+	data, _ := io.ReadAll(r)
+	return string(data)
+}
+
+func TestApplyDefaults_MaxIssuesMigrates(t *testing.T) {
+	cfg := Config{Cobbler: CobblerConfig{MaxIssues: 4}}
+	cfg.applyDefaults()
+	if cfg.Cobbler.MaxMeasureIssues != 4 {
+		t.Errorf("MaxMeasureIssues = %d, want 4", cfg.Cobbler.MaxMeasureIssues)
+	}
+	if cfg.Cobbler.MaxIssues != 0 {
+		t.Errorf("MaxIssues = %d, want 0 after migration", cfg.Cobbler.MaxIssues)
+	}
+}
+
+func TestApplyDefaults_MaxMeasureIssuesWins(t *testing.T) {
+	cfg := Config{Cobbler: CobblerConfig{MaxIssues: 4, MaxMeasureIssues: 2}}
+	cfg.applyDefaults()
+	if cfg.Cobbler.MaxMeasureIssues != 2 {
+		t.Errorf("MaxMeasureIssues = %d, want 2", cfg.Cobbler.MaxMeasureIssues)
+	}
+}
+
+func TestMaxIssues_DeprecationWarningOnce(t *testing.T) {
+	// Not parallel: redirects os.Stderr.
+	path := writeTemp(t, "cobbler:\n  max_issues: 3\n")
+	var o *Orchestrator
+	out := captureStderr(t, func() {
+		var err error
+		o, err = NewFromFile(path) // LoadConfig and New both apply defaults
+		if err != nil {
+			t.Error(err)
+		}
+	})
+	if n := strings.Count(out, "max_issues is deprecated"); n != 1 {
+		t.Errorf("deprecation warning count = %d, want 1\nstderr:\n%s", n, out)
+	}
+	if o != nil && o.Config().Cobbler.MaxMeasureIssues != 3 {
+		t.Errorf("MaxMeasureIssues = %d, want 3", o.Config().Cobbler.MaxMeasureIssues)
+	}
+}
+
+func TestMaxIssues_NoWarningWhenUnset(t *testing.T) {
+	// Not parallel: redirects os.Stderr.
+	out := captureStderr(t, func() { New(Config{}) })
+	if strings.Contains(out, "max_issues") {
+		t.Errorf("unexpected deprecation warning:\n%s", out)
+	}
+}
+
+// --- Validate / SpecGlobs ---
+
+func TestValidate_InvalidSpecGlob(t *testing.T) {
+	t.Parallel()
+	var cfg Config
+	cfg.Project.SpecGlobs = []string{"docs/*.yaml", "docs/[unclosed"}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "spec_globs[1]") {
+		t.Errorf("Validate() = %v, want an error naming spec_globs[1]", err)
+	}
+}
+
+func TestValidate_ClaudeOutputFormat(t *testing.T) {
+	t.Parallel()
+	for _, format := range []string{"", "stream-json", "text", "auto"} {
+		var cfg Config
+		cfg.Claude.OutputFormat = format
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate() with output_format %q = %v, want nil", format, err)
+		}
+	}
+	var cfg Config
+	cfg.Claude.OutputFormat = "json"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), `claude.output_format: unknown value "json"`) {
+		t.Errorf("Validate() = %v, want an unknown output_format error", err)
+	}
+}
+
+func TestValidate_DefaultEffort(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		values   []string
+		maxHours int
+		def      string
+		wantErr  string
+	}{
+		{name: "label", values: []string{"S", "M", "L"}, def: "m"},
+		{name: "hours within range", values: []string{"S", "M", "L"}, maxHours: 8, def: "4"},
+		{name: "no effort rules", def: "XL"},
+		{name: "unknown label", values: []string{"S", "M", "L"}, def: "XL", wantErr: `cobbler.default_effort: effort "XL" not one of [S, M, L]`},
+		{name: "hours out of range", values: []string{"S", "M", "L"}, maxHours: 8, def: "12", wantErr: "cobbler.default_effort: effort 12 hours outside range 1-8"},
+	}
+	for _, tc := range cases {
+		var cfg Config
+		cfg.Cobbler.EffortValues = tc.values
+		cfg.Cobbler.EffortMaxHours = tc.maxHours
+		cfg.Cobbler.DefaultEffort = tc.def
+		err := cfg.Validate()
+		if tc.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: Validate() = %v, want nil", tc.name, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tc.wantErr {
+			t.Errorf("%s: Validate() = %v, want %q", tc.name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestLoadConfig_UnknownClaudeOutputFormat(t *testing.T) {
+	t.Parallel()
+	f := writeTemp(t, "claude:\n  output_format: txt\n")
+	if _, err := LoadConfig(f); err == nil {
+		t.Error("LoadConfig accepted an unknown claude.output_format")
+	}
+}
+
+func TestLoadConfig_InvalidSpecGlob(t *testing.T) {
+	t.Parallel()
+	f := writeTemp(t, "project:\n  spec_globs:\n    - \"[\"\n")
+	if _, err := LoadConfig(f); err == nil {
+		t.Error("LoadConfig accepted an invalid spec_globs pattern")
+	}
+}
+
+func TestValidateSpecGlobs_ZeroVsSomeMatches(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "prd001.yaml"), []byte("title: x\n"), 0o644)
+	empty := filepath.Join(dir, "*.md")
+
+	warnings, err := validateSpecGlobs([]string{filepath.Join(dir, "*.yaml"), empty})
+	if err != nil {
+		t.Fatalf("validateSpecGlobs: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], empty) {
+		t.Errorf("warnings = %v, want one for %q", warnings, empty)
+	}
+}
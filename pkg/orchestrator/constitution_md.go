@@ -6,6 +6,7 @@ package orchestrator
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -17,6 +18,37 @@ type constitutionSectionsOnly struct {
 	Sections []ConstitutionSection `yaml:"sections"`
 }
 
+// Validate reports whether s has the fields needed to render it. It returns
+// an error when Tag or Title is empty or Content contains only whitespace.
+func (s ConstitutionSection) Validate() error {
+	var missing []string
+	if s.Tag == "" {
+		missing = append(missing, "tag")
+	}
+	if s.Title == "" {
+		missing = append(missing, "title")
+	}
+	if strings.TrimSpace(s.Content) == "" {
+		missing = append(missing, "content")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("constitution section %q: empty %s", s.Tag, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// ValidateAll calls Validate on each section and returns the errors in
+// section order. It returns nil when every section is valid.
+func ValidateAll(sections []ConstitutionSection) []error {
+	var errs []error
+	for _, s := range sections {
+		if err := s.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
 // ConstitutionToMarkdown converts a slice of ConstitutionSection values into a
 // markdown string. Each section becomes a level-2 heading (## Title), followed
 // by a blank line, the section content, and a trailing blank line.
@@ -31,8 +63,11 @@ func ConstitutionToMarkdown(sections []ConstitutionSection) string {
 }
 
 // ConstitutionPreviewFile reads the constitution YAML file at path, extracts
-// its sections field, and prints the rendered markdown to stdout. It returns
-// an error when the file is missing, malformed, or contains no sections.
+// its sections field, and prints the rendered markdown to stdout. Every
+// ValidateAll error is reported as a warning on stderr, and the invalid
+// sections are skipped. It
+// returns an error when the file is missing, malformed, or contains no
+// sections.
 func (o *Orchestrator) ConstitutionPreviewFile(path string) error {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -46,6 +81,12 @@ func (o *Orchestrator) ConstitutionPreviewFile(path string) error {
 		fmt.Fprintf(os.Stderr, "warning: %s has no sections field\n", path)
 		return fmt.Errorf("no sections in %s", path)
 	}
-	fmt.Print(ConstitutionToMarkdown(doc.Sections))
+	for _, err := range ValidateAll(doc.Sections) {
+		fmt.Fprintf(os.Stderr, "warning: %s: %v\n", path, err)
+	}
+	valid := slices.DeleteFunc(slices.Clone(doc.Sections), func(sec ConstitutionSection) bool {
+		return sec.Validate() != nil
+	})
+	fmt.Print(ConstitutionToMarkdown(valid))
 	return nil
 }
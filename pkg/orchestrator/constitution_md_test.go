@@ -107,3 +107,84 @@ func TestConstitutionPreviewFile_MissingFile(t *testing.T) {
 		t.Error("ConstitutionPreviewFile() expected error for missing file, got nil")
 	}
 }
+
+// Not parallel: captures os.Stdout and os.Stderr.
+func TestConstitutionPreviewFile_ReportsEveryInvalidSection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test-constitution.yaml")
+	content := "sections:\n" +
+		"  - tag: ''\n    title: No Tag\n    content: Body.\n" +
+		"  - tag: ok\n    title: Kept\n    content: Kept body.\n" +
+		"  - tag: blank\n    title: ''\n    content: ' '\n"
+	os.WriteFile(path, []byte(content), 0o644)
+
+	o := &Orchestrator{}
+	var stdout string
+	stderr := captureStderr(t, func() {
+		stdout = captureStdout(t, func() {
+			if err := o.ConstitutionPreviewFile(path); err != nil {
+				t.Errorf("ConstitutionPreviewFile() unexpected error: %v", err)
+			}
+		})
+	})
+	if got := strings.Count(stderr, "warning: "); got != 2 {
+		t.Errorf("stderr has %d warnings, want 2:\n%s", got, stderr)
+	}
+	if !strings.Contains(stderr, "tag") || !strings.Contains(stderr, `"blank"`) {
+		t.Errorf("stderr should name both invalid sections:\n%s", stderr)
+	}
+	if stdout != "## Kept\n\nKept body.\n\n" {
+		t.Errorf("stdout = %q, want only the valid section", stdout)
+	}
+}
+
+func TestValidateAll_AllValid(t *testing.T) {
+	sections := []ConstitutionSection{
+		{Tag: "a", Title: "A", Content: "Alpha."},
+		{Tag: "b", Title: "B", Content: "Beta."},
+	}
+	if errs := ValidateAll(sections); len(errs) != 0 {
+		t.Errorf("ValidateAll() = %v, want no errors", errs)
+	}
+}
+
+func TestValidateAll_EmptyTag(t *testing.T) {
+	sections := []ConstitutionSection{
+		{Tag: "", Title: "A", Content: "Alpha."},
+		{Tag: "b", Title: "B", Content: "Beta."},
+	}
+	errs := ValidateAll(sections)
+	if len(errs) != 1 {
+		t.Fatalf("ValidateAll() returned %d errors, want 1: %v", len(errs), errs)
+	}
+	if !strings.Contains(errs[0].Error(), "tag") {
+		t.Errorf("error = %q, want it to mention 'tag'", errs[0])
+	}
+}
+
+func TestValidateAll_WhitespaceContent(t *testing.T) {
+	sections := []ConstitutionSection{
+		{Tag: "a", Title: "A", Content: "  \n\t\n"},
+	}
+	errs := ValidateAll(sections)
+	if len(errs) != 1 {
+		t.Fatalf("ValidateAll() returned %d errors, want 1: %v", len(errs), errs)
+	}
+	if !strings.Contains(errs[0].Error(), "content") {
+		t.Errorf("error = %q, want it to mention 'content'", errs[0])
+	}
+}
+
+func TestValidateAll_MultipleFailures(t *testing.T) {
+	sections := []ConstitutionSection{
+		{Tag: "", Title: "A", Content: "Alpha."},
+		{Tag: "b", Title: "B", Content: "Beta."},
+		{Tag: "c", Title: "", Content: " "},
+	}
+	errs := ValidateAll(sections)
+	if len(errs) != 2 {
+		t.Fatalf("ValidateAll() returned %d errors, want 2: %v", len(errs), errs)
+	}
+	if msg := errs[1].Error(); !strings.Contains(msg, "title") || !strings.Contains(msg, "content") {
+		t.Errorf("error = %q, want it to mention 'title' and 'content'", msg)
+	}
+}
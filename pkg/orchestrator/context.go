@@ -805,6 +805,19 @@ func applyContextBudget(ctx *ProjectContext, budget int, requiredPaths []string)
 // Helper functions
 // ---------------------------------------------------------------------------
 
+// defaultMaxSpecFileSize is the default Cobbler.MaxSpecFileSizeBytes.
+const defaultMaxSpecFileSize = 10 << 20
+
+// specFileTooLarge reports whether a file of size bytes exceeds limit,
+// logging an error naming path when it does. A limit of 0 means no limit.
+func specFileTooLarge(path string, size, limit int64) bool {
+	if limit <= 0 || size <= limit {
+		return false
+	}
+	logf("loadYAML: error: %s is %d bytes, over the %d byte limit (max_spec_file_size_bytes); skipping", path, size, limit)
+	return true
+}
+
 // loadYAML reads a YAML file and unmarshals it into T.
 // Returns nil if the file does not exist or cannot be parsed.
 func loadYAML[T any](path string) *T {
@@ -812,6 +825,40 @@ func loadYAML[T any](path string) *T {
 	if err != nil {
 		return nil
 	}
+	return unmarshalYAML[T](path, data)
+}
+
+// loadSpecYAML is loadYAML for spec documents: it also returns nil,
+// without reading the file, when the file is larger than limit bytes.
+// Pass Config.EffectiveMaxSpecFileSize as limit; 0 means no limit.
+func loadSpecYAML[T any](path string, limit int64) *T {
+	if info, err := os.Stat(path); err == nil && specFileTooLarge(path, info.Size(), limit) {
+		return nil
+	}
+	return loadYAML[T](path)
+}
+
+// loadSpecYAMLFS is loadSpecYAML reading from fsys instead of the real
+// filesystem.
+func loadSpecYAMLFS[T any](fsys fs.FS, path string, limit int64) *T {
+	if info, err := fs.Stat(fsys, path); err == nil && specFileTooLarge(path, info.Size(), limit) {
+		return nil
+	}
+	return loadYAMLFS[T](fsys, path)
+}
+
+// loadYAMLFS is loadYAML reading from fsys instead of the real
+// filesystem. path uses fs.FS conventions (slash-separated, unrooted).
+func loadYAMLFS[T any](fsys fs.FS, path string) *T {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil
+	}
+	return unmarshalYAML[T](path, data)
+}
+
+// unmarshalYAML parses data into T, logging and returning nil on error.
+func unmarshalYAML[T any](path string, data []byte) *T {
 	var v T
 	if err := yaml.Unmarshal(data, &v); err != nil {
 		logf("loadYAML: parse error for %s: %v", path, err)
@@ -1207,26 +1254,27 @@ func prdIDsFromUseCases(useCases []*UseCaseDoc) map[string]bool {
 // loadContextFileInto loads a single file into the appropriate field
 // of ctx based on its classified category. Applies release filtering
 // for use_case and test_suite categories. Does not handle constitution
-// or extra categories.
-func loadContextFileInto(ctx *ProjectContext, path string, rf releaseFilter) {
+// or extra categories. Typed documents larger than maxFileSize bytes are
+// skipped; 0 means no limit.
+func loadContextFileInto(ctx *ProjectContext, path string, rf releaseFilter, maxFileSize int64) {
 	switch classifyContextFile(path) {
 	case "vision":
-		if v := loadYAML[VisionDoc](path); v != nil {
+		if v := loadSpecYAML[VisionDoc](path, maxFileSize); v != nil {
 			v.File = path
 			ctx.Vision = v
 		}
 	case "architecture":
-		if v := loadYAML[ArchitectureDoc](path); v != nil {
+		if v := loadSpecYAML[ArchitectureDoc](path, maxFileSize); v != nil {
 			v.File = path
 			ctx.Architecture = v
 		}
 	case "specifications":
-		if v := loadYAML[SpecificationsDoc](path); v != nil {
+		if v := loadSpecYAML[SpecificationsDoc](path, maxFileSize); v != nil {
 			v.File = path
 			ctx.Specifications = v
 		}
 	case "roadmap":
-		if v := loadYAML[RoadmapDoc](path); v != nil {
+		if v := loadSpecYAML[RoadmapDoc](path, maxFileSize); v != nil {
 			v.File = path
 			ctx.Roadmap = v
 		}
@@ -1234,7 +1282,7 @@ func loadContextFileInto(ctx *ProjectContext, path string, rf releaseFilter) {
 		if !fileMatchesRelease(path, rf) {
 			return
 		}
-		if v := loadYAML[UseCaseDoc](path); v != nil {
+		if v := loadSpecYAML[UseCaseDoc](path, maxFileSize); v != nil {
 			v.File = path
 			ctx.Specs.UseCases = append(ctx.Specs.UseCases, v)
 		}
@@ -1242,7 +1290,7 @@ func loadContextFileInto(ctx *ProjectContext, path string, rf releaseFilter) {
 		if !fileMatchesRelease(path, rf) {
 			return
 		}
-		if v := loadYAML[TestSuiteDoc](path); v != nil {
+		if v := loadSpecYAML[TestSuiteDoc](path, maxFileSize); v != nil {
 			v.File = path
 			ctx.Specs.TestSuites = append(ctx.Specs.TestSuites, v)
 		}
@@ -1259,7 +1307,7 @@ func loadContextFileInto(ctx *ProjectContext, path string, rf releaseFilter) {
 			}
 		}
 	case "engineering":
-		if v := loadYAML[EngineeringDoc](path); v != nil {
+		if v := loadSpecYAML[EngineeringDoc](path, maxFileSize); v != nil {
 			v.File = path
 			ctx.Engineering = append(ctx.Engineering, v)
 		}
@@ -1280,8 +1328,11 @@ func loadContextFileInto(ctx *ProjectContext, path string, rf releaseFilter) {
 // existing issues, and assembles them into a ProjectContext struct.
 // The project config controls include/exclude filtering and release scoping.
 // When phaseCtx is non-nil, its non-empty fields override the corresponding
-// ProjectConfig fields (prd003 R9.5-R9.7).
-func buildProjectContext(existingIssuesJSON string, project ProjectConfig, phaseCtx *PhaseContext) (*ProjectContext, error) {
+// ProjectConfig fields (prd003 R9.5-R9.7). analysisFile names the
+// pre-cycle analysis file in the cobbler directory; "" means the default.
+// Spec documents larger than maxFileSize bytes are skipped; 0 means no
+// limit.
+func buildProjectContext(existingIssuesJSON string, project ProjectConfig, phaseCtx *PhaseContext, analysisFile string, maxFileSize int64) (*ProjectContext, error) {
 	ctx := &ProjectContext{}
 	ctx.Specs = &SpecsCollection{}
 
@@ -1350,14 +1401,14 @@ func buildProjectContext(existingIssuesJSON string, project ProjectConfig, phase
 			prdPaths = append(prdPaths, path)
 			continue
 		}
-		loadContextFileInto(ctx, path, rf)
+		loadContextFileInto(ctx, path, rf, maxFileSize)
 	}
 
 	// Load PRDs filtered by release: when a release filter is active, only
 	// include PRDs referenced by the loaded (release-scoped) use cases.
 	if !rf.active() {
 		for _, path := range prdPaths {
-			if v := loadYAML[PRDDoc](path); v != nil {
+			if v := loadSpecYAML[PRDDoc](path, maxFileSize); v != nil {
 				v.File = path
 				ctx.Specs.ProductRequirements = append(ctx.Specs.ProductRequirements, v)
 			}
@@ -1367,7 +1418,7 @@ func buildProjectContext(existingIssuesJSON string, project ProjectConfig, phase
 		for _, path := range prdPaths {
 			stem := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
 			if referencedPRDs[stem] {
-				if v := loadYAML[PRDDoc](path); v != nil {
+				if v := loadSpecYAML[PRDDoc](path, maxFileSize); v != nil {
 					v.File = path
 					ctx.Specs.ProductRequirements = append(ctx.Specs.ProductRequirements, v)
 				}
@@ -1415,7 +1466,7 @@ func buildProjectContext(existingIssuesJSON string, project ProjectConfig, phase
 	ctx.Issues = parseIssuesJSON(existingIssuesJSON)
 
 	// Load pre-cycle analysis results if present in the scratch directory.
-	ctx.Analysis = loadAnalysisDoc(dirCobbler)
+	ctx.Analysis = loadAnalysisDoc(dirCobbler, analysisFile)
 
 	logf("buildProjectContext: vision=%v arch=%v roadmap=%v specs=%v eng=%d analysis=%v issues=%d extra=%d src=%d files=%d",
 		ctx.Vision != nil,
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"gopkg.in/yaml.v3"
 )
@@ -91,7 +92,7 @@ func TestBuildProjectContext_PhaseContextOverride(t *testing.T) {
 		Include: "docs/custom.yaml",
 	}
 
-	ctx, err := buildProjectContext("", project, phaseCtx)
+	ctx, err := buildProjectContext("", project, phaseCtx, "", 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -111,7 +112,7 @@ func TestBuildProjectContext_NilPhaseContextUsesConfig(t *testing.T) {
 		GoSourceDirs: []string{"pkg/"},
 	}
 
-	ctx, err := buildProjectContext("", project, nil)
+	ctx, err := buildProjectContext("", project, nil, "", 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -136,7 +137,7 @@ func TestBuildProjectContext_PhaseContextPartialOverride(t *testing.T) {
 		Include: "docs/VISION.yaml",
 	}
 
-	ctx, err := buildProjectContext("", project, phaseCtx)
+	ctx, err := buildProjectContext("", project, phaseCtx, "", 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -407,9 +408,9 @@ func TestLoadContextFileIntoSetsFilePath(t *testing.T) {
 
 	ctx := &ProjectContext{Specs: &SpecsCollection{}}
 	noFilter := releaseFilter{}
-	loadContextFileInto(ctx, "docs/VISION.yaml", noFilter)
-	loadContextFileInto(ctx, "docs/ARCHITECTURE.yaml", noFilter)
-	loadContextFileInto(ctx, "docs/road-map.yaml", noFilter)
+	loadContextFileInto(ctx, "docs/VISION.yaml", noFilter, 0)
+	loadContextFileInto(ctx, "docs/ARCHITECTURE.yaml", noFilter, 0)
+	loadContextFileInto(ctx, "docs/road-map.yaml", noFilter, 0)
 
 	if ctx.Vision == nil || ctx.Vision.File != "docs/VISION.yaml" {
 		t.Errorf("Vision.File = %q, want %q", ctx.Vision.File, "docs/VISION.yaml")
@@ -500,9 +501,9 @@ func TestLoadContextFileInto_SpecAux(t *testing.T) {
 
 	ctx := &ProjectContext{Specs: &SpecsCollection{}}
 	noFilter := releaseFilter{}
-	loadContextFileInto(ctx, filepath.Join("docs", "specs", "dependency-map.yaml"), noFilter)
-	loadContextFileInto(ctx, filepath.Join("docs", "specs", "sources.yaml"), noFilter)
-	loadContextFileInto(ctx, filepath.Join("docs", "specs", "utilities.yaml"), noFilter)
+	loadContextFileInto(ctx, filepath.Join("docs", "specs", "dependency-map.yaml"), noFilter, 0)
+	loadContextFileInto(ctx, filepath.Join("docs", "specs", "sources.yaml"), noFilter, 0)
+	loadContextFileInto(ctx, filepath.Join("docs", "specs", "utilities.yaml"), noFilter, 0)
 
 	if ctx.Specs.DependencyMap == nil {
 		t.Error("Specs.DependencyMap should be set for dependency-map.yaml")
@@ -532,7 +533,7 @@ func TestLoadContextFileInto_Engineering(t *testing.T) {
 
 	ctx := &ProjectContext{Specs: &SpecsCollection{}}
 	noFilter := releaseFilter{}
-	loadContextFileInto(ctx, filepath.Join("docs", "engineering", "eng01-testing.yaml"), noFilter)
+	loadContextFileInto(ctx, filepath.Join("docs", "engineering", "eng01-testing.yaml"), noFilter, 0)
 
 	if len(ctx.Engineering) != 1 {
 		t.Fatalf("Engineering len = %d, want 1", len(ctx.Engineering))
@@ -552,7 +553,7 @@ func TestLoadContextFileInto_Extra(t *testing.T) {
 
 	ctx := &ProjectContext{Specs: &SpecsCollection{}}
 	noFilter := releaseFilter{}
-	loadContextFileInto(ctx, "notes.yaml", noFilter)
+	loadContextFileInto(ctx, "notes.yaml", noFilter, 0)
 
 	if len(ctx.Extra) != 1 {
 		t.Fatalf("Extra len = %d, want 1", len(ctx.Extra))
@@ -663,7 +664,7 @@ func TestContextExclude(t *testing.T) {
 		ContextExclude: "docs/extra.yaml\npkg/app/util.go",
 	}
 
-	ctx, err := buildProjectContext("", project, nil)
+	ctx, err := buildProjectContext("", project, nil, "", 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -705,7 +706,7 @@ func TestContextIncludeReplacesStandard(t *testing.T) {
 		ContextInclude: "docs/custom.yaml",
 	}
 
-	ctx, err := buildProjectContext("", project, nil)
+	ctx, err := buildProjectContext("", project, nil, "", 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -745,7 +746,7 @@ func TestContextExcludeDirectory(t *testing.T) {
 		ContextExclude: "pkg/sub",
 	}
 
-	ctx, err := buildProjectContext("", project, nil)
+	ctx, err := buildProjectContext("", project, nil, "", 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -782,7 +783,7 @@ func TestContextIncludeWithExclude(t *testing.T) {
 		ContextExclude: "docs/inc2.yaml",
 	}
 
-	ctx, err := buildProjectContext("", project, nil)
+	ctx, err := buildProjectContext("", project, nil, "", 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -835,7 +836,7 @@ func TestBuildProjectContext_ReleasesFilter(t *testing.T) {
 		Releases: []string{"01.0", "03.0"},
 	}
 
-	ctx, err := buildProjectContext("", project, nil)
+	ctx, err := buildProjectContext("", project, nil, "", 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -871,7 +872,7 @@ func TestBuildProjectContext_ReleaseLegacyBackwardCompat(t *testing.T) {
 		Release: "01.0",
 	}
 
-	ctx, err := buildProjectContext("", project, nil)
+	ctx, err := buildProjectContext("", project, nil, "", 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -903,7 +904,7 @@ func TestBuildProjectContext_ReleasesOverridesRelease(t *testing.T) {
 		Releases: []string{"01.0"},
 	}
 
-	ctx, err := buildProjectContext("", project, nil)
+	ctx, err := buildProjectContext("", project, nil, "", 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -932,7 +933,7 @@ func TestBuildProjectContext_EmptyReleasesIncludesAll(t *testing.T) {
 	// No release filtering: both should be included.
 	project := ProjectConfig{}
 
-	ctx, err := buildProjectContext("", project, nil)
+	ctx, err := buildProjectContext("", project, nil, "", 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -957,7 +958,7 @@ func TestBuildProjectContext_PhaseContextOverridesReleases(t *testing.T) {
 	}
 	phase := &PhaseContext{Release: "01.0"}
 
-	ctx, err := buildProjectContext("", project, phase)
+	ctx, err := buildProjectContext("", project, phase, "", 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1378,7 +1379,7 @@ func TestContextExcludeEverything(t *testing.T) {
 		ContextExclude: ".",
 	}
 
-	ctx, err := buildProjectContext("", project, nil)
+	ctx, err := buildProjectContext("", project, nil, "", 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1400,3 +1401,129 @@ func TestContextExcludeEverything(t *testing.T) {
 		t.Errorf("Extra should be empty with context_exclude='.', got %d", len(ctx.Extra))
 	}
 }
+
+// ---------------------------------------------------------------------------
+// loadSpecYAML size limit
+// ---------------------------------------------------------------------------
+
+// writeSizedYAML writes a `title:` YAML document padded with a trailing
+// comment to exactly size bytes.
+func writeSizedYAML(t *testing.T, size int) string {
+	t.Helper()
+	head := "title: ok\n# "
+	data := head + strings.Repeat("x", size-len(head)-1) + "\n"
+	path := filepath.Join(t.TempDir(), "doc.yaml")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+type sizedDoc struct {
+	Title string `yaml:"title"`
+}
+
+func TestLoadSpecYAML_UnderSizeLimit(t *testing.T) {
+	t.Parallel()
+	path := writeSizedYAML(t, 50)
+	if got := loadSpecYAML[sizedDoc](path, 100); got == nil || got.Title != "ok" {
+		t.Errorf("loadSpecYAML() = %v, want title ok", got)
+	}
+}
+
+func TestLoadSpecYAML_AtSizeLimit(t *testing.T) {
+	t.Parallel()
+	path := writeSizedYAML(t, 100)
+	if got := loadSpecYAML[sizedDoc](path, 100); got == nil || got.Title != "ok" {
+		t.Errorf("loadSpecYAML() = %v, want title ok", got)
+	}
+}
+
+func TestLoadSpecYAML_OverSizeLimit(t *testing.T) {
+	// Not parallel: captureStderr redirects os.Stderr.
+	path := writeSizedYAML(t, 101)
+	var got *sizedDoc
+	stderr := captureStderr(t, func() { got = loadSpecYAML[sizedDoc](path, 100) })
+	if got != nil {
+		t.Errorf("loadSpecYAML() = %v, want nil over the limit", got)
+	}
+	if !strings.Contains(stderr, "101 bytes, over the 100 byte limit") {
+		t.Errorf("stderr = %q, want size limit error", stderr)
+	}
+}
+
+func TestLoadSpecYAML_ZeroSizeLimit(t *testing.T) {
+	t.Parallel()
+	path := writeSizedYAML(t, 4096)
+	if got := loadSpecYAML[sizedDoc](path, 0); got == nil || got.Title != "ok" {
+		t.Errorf("loadSpecYAML() = %v, want title ok with no limit", got)
+	}
+}
+
+func TestLoadSpecYAMLFS_OverSizeLimit(t *testing.T) {
+	t.Parallel()
+	fsys := fstest.MapFS{"doc.yaml": {Data: []byte("title: ok\n")}}
+	if got := loadSpecYAMLFS[sizedDoc](fsys, "doc.yaml", 5); got != nil {
+		t.Errorf("loadSpecYAMLFS() = %v, want nil over the limit", got)
+	}
+	if got := loadSpecYAMLFS[sizedDoc](fsys, "doc.yaml", 0); got == nil || got.Title != "ok" {
+		t.Errorf("loadSpecYAMLFS() = %v, want title ok with no limit", got)
+	}
+}
+
+func TestEffectiveMaxSpecFileSize(t *testing.T) {
+	t.Parallel()
+	n := func(v int64) *int64 { return &v }
+	for _, tc := range []struct {
+		setting *int64
+		want    int64
+	}{
+		{nil, defaultMaxSpecFileSize},
+		{n(0), 0},
+		{n(-1), 0},
+		{n(2048), 2048},
+	} {
+		cfg := Config{Cobbler: CobblerConfig{MaxSpecFileSizeBytes: tc.setting}}
+		if got := cfg.EffectiveMaxSpecFileSize(); got != tc.want {
+			t.Errorf("EffectiveMaxSpecFileSize() with %v = %d, want %d", tc.setting, got, tc.want)
+		}
+	}
+}
+
+func TestLoadConfig_ZeroSpecFileSizeDisablesLimit(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "configuration.yaml")
+	if err := os.WriteFile(path, []byte("cobbler:\n  max_spec_file_size_bytes: 0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if got := cfg.EffectiveMaxSpecFileSize(); got != 0 {
+		t.Errorf("EffectiveMaxSpecFileSize() = %d, want 0 (no limit)", got)
+	}
+}
+
+func TestBuildProjectContext_SkipsOversizedSpec(t *testing.T) {
+	_, cleanup := setupContextTestDir(t)
+	defer cleanup()
+
+	if err := os.WriteFile("docs/VISION.yaml", []byte("id: v1\ntitle: "+strings.Repeat("x", 200)+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx, err := buildProjectContext("", ProjectConfig{}, nil, "", 100)
+	if err != nil {
+		t.Fatalf("buildProjectContext: %v", err)
+	}
+	if ctx.Vision != nil {
+		t.Errorf("Vision = %+v, want nil for a file over the limit", ctx.Vision)
+	}
+	ctx, err = buildProjectContext("", ProjectConfig{}, nil, "", 0)
+	if err != nil {
+		t.Fatalf("buildProjectContext: %v", err)
+	}
+	if ctx.Vision == nil {
+		t.Error("Vision = nil, want it loaded with no limit")
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import "fmt"
+
+// Estimate returns the dollar cost of the given token counts under m.
+// input is the total input count as recorded by parseClaudeTokens, which
+// already includes cacheCreation and cacheRead; only the uncached
+// remainder is priced at the base input rate. Cache token counts are
+// priced at their own rates; pass 0 when the invocation did not report
+// them.
+func (m CostModel) Estimate(input, output, cacheCreation, cacheRead int) float64 {
+	uncached := max(input-cacheCreation-cacheRead, 0)
+	return float64(uncached)*m.InputUSDPerToken +
+		float64(output)*m.OutputUSDPerToken +
+		float64(cacheCreation)*m.CacheCreationUSDPerToken +
+		float64(cacheRead)*m.CacheReadUSDPerToken
+}
+
+// EstimateCost returns the estimated dollar cost of a single invocation
+// using the configured Claude.CostModel.
+func (o *Orchestrator) EstimateCost(rec InvocationRecord) float64 {
+	t := rec.Tokens
+	return o.cfg.Claude.CostModel.Estimate(t.Input, t.Output, t.CacheCreation, t.CacheRead)
+}
+
+// EstimateTotalCost returns the summed estimated dollar cost of recs.
+func (o *Orchestrator) EstimateTotalCost(recs []InvocationRecord) float64 {
+	var total float64
+	for _, rec := range recs {
+		total += o.EstimateCost(rec)
+	}
+	return total
+}
+
+// TotalCostUSD returns the summed dollar cost of every invocation recorded
+// for generation genID in the history directory. Each record contributes
+// the cost Claude reported; records without a reported cost fall back to
+// the CostModel estimate from their token counts.
+func (o *Orchestrator) TotalCostUSD(genID string) (float64, error) {
+	if genID == "" {
+		return 0, fmt.Errorf("generation ID is required")
+	}
+	dir := o.historyDir()
+	if dir == "" {
+		return 0, fmt.Errorf("history directory is not configured")
+	}
+	stats, err := loadHistoryStats(dir)
+	if err != nil {
+		return 0, err
+	}
+	var total float64
+	for _, s := range filterStatsByGeneration(stats, genID) {
+		total += statsCostUSD(s, o.cfg.Claude.CostModel)
+	}
+	return total, nil
+}
+
+// statsCostUSD returns the reported cost of s, or the model estimate when
+// no cost was reported.
+func statsCostUSD(s HistoryStats, model CostModel) float64 {
+	if s.CostUSD > 0 {
+		return s.CostUSD
+	}
+	t := s.Tokens
+	return model.Estimate(t.Input, t.Output, t.CacheCreation, t.CacheRead)
+}
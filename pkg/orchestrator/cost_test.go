@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestCostModel_ZeroRates(t *testing.T) {
+	t.Parallel()
+	if got := (CostModel{}).Estimate(1000, 500, 200, 300); got != 0 {
+		t.Errorf("Estimate with zero rates = %v, want 0", got)
+	}
+}
+
+func TestCostModel_Estimate(t *testing.T) {
+	t.Parallel()
+	m := CostModel{
+		InputUSDPerToken:         0.000003,
+		OutputUSDPerToken:        0.000015,
+		CacheCreationUSDPerToken: 0.00000375,
+		CacheReadUSDPerToken:     0.0000003,
+	}
+	// Input is the total of 1M uncached, 200k cache-creation and 2M
+	// cache-read tokens, as parseClaudeTokens records it.
+	got := m.Estimate(3_200_000, 100_000, 200_000, 2_000_000)
+	want := 3.0 + 1.5 + 0.75 + 0.6
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("Estimate = %v, want %v", got, want)
+	}
+}
+
+func TestCostModel_Estimate_CacheTokensNotDoubleCounted(t *testing.T) {
+	t.Parallel()
+	m := CostModel{
+		InputUSDPerToken:         0.01,
+		OutputUSDPerToken:        0.1,
+		CacheCreationUSDPerToken: 0.02,
+		CacheReadUSDPerToken:     0.001,
+	}
+	// 100 input tokens of which 30 are cache creation and 50 cache read:
+	// 20 uncached * 0.01 + 10 output * 0.1 + 30 * 0.02 + 50 * 0.001.
+	got := m.Estimate(100, 10, 30, 50)
+	if want := 1.85; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Estimate = %v, want %v", got, want)
+	}
+}
+
+func TestEstimateCost_UsesCacheFields(t *testing.T) {
+	t.Parallel()
+	o := New(Config{Claude: ClaudeConfig{CostModel: CostModel{CacheReadUSDPerToken: 0.001}}})
+	rec := InvocationRecord{Tokens: claudeTokens{Input: 60, Output: 10, CacheRead: 50}}
+	if got := o.EstimateCost(rec); math.Abs(got-0.05) > 1e-9 {
+		t.Errorf("EstimateCost = %v, want 0.05", got)
+	}
+	total := o.EstimateTotalCost([]InvocationRecord{rec, rec})
+	if math.Abs(total-0.1) > 1e-9 {
+		t.Errorf("EstimateTotalCost = %v, want 0.1", total)
+	}
+}
+
+func TestTotalCostUSD_SumsGenerationWithEstimateFallback(t *testing.T) {
+	t.Parallel()
+	histDir := t.TempDir()
+	for name, s := range map[string]HistoryStats{
+		"2026-03-01-09-00-00-measure-stats.yaml": {Caller: "measure", Generation: "gen-a", CostUSD: 0.25},
+		"2026-03-01-10-00-00-stitch-stats.yaml":  {Caller: "stitch", Generation: "gen-a", Tokens: historyTokens{Input: 100, Output: 10}},
+		"2026-03-01-11-00-00-stitch-stats.yaml":  {Caller: "stitch", Generation: "gen-b", CostUSD: 9},
+	} {
+		data, _ := yaml.Marshal(s)
+		os.WriteFile(filepath.Join(histDir, name), data, 0o644)
+	}
+
+	o := New(Config{
+		Cobbler: CobblerConfig{HistoryDir: histDir},
+		Claude:  ClaudeConfig{CostModel: CostModel{InputUSDPerToken: 0.001, OutputUSDPerToken: 0.01}},
+	})
+	got, err := o.TotalCostUSD("gen-a")
+	if err != nil {
+		t.Fatalf("TotalCostUSD: %v", err)
+	}
+	if want := 0.25 + 0.1 + 0.1; math.Abs(got-want) > 1e-9 {
+		t.Errorf("TotalCostUSD = %v, want %v", got, want)
+	}
+}
+
+func TestTotalCostUSD_RequiresGenID(t *testing.T) {
+	t.Parallel()
+	if _, err := New(Config{}).TotalCostUSD(""); err == nil {
+		t.Error("expected error for empty generation ID")
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// defaultIssueHost is the web host used for issue URLs when the origin
+// remote does not name one.
+const defaultIssueHost = "github.com"
+
+// issueURL returns the web URL for issue id in repo ("owner/name") on
+// host, such as github.com or a GitHub Enterprise host. It returns ""
+// when repo is empty or id is not an issue number, as with IDs from a
+// non-GitHub backend.
+func issueURL(host, repo, id string) string {
+	n, err := strconv.Atoi(id)
+	if repo == "" || err != nil || n <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("https://%s/%s/issues/%d", host, repo, n)
+}
+
+// remoteHost returns the host of the origin remote in repoRoot, or
+// defaultIssueHost when there is no origin or its URL has no host. It
+// assumes the issues repo lives on the same host as origin.
+func remoteHost(repoRoot string) string {
+	remote, err := gitRemoteURL("origin", repoRoot)
+	if err != nil {
+		return defaultIssueHost
+	}
+	if host := parseRemoteHost(remote); host != "" {
+		return host
+	}
+	return defaultIssueHost
+}
+
+// parseRemoteHost returns the host of a git remote URL in URL form
+// (https://host/owner/name, ssh://git@host:22/owner/name) or scp-like
+// form (git@host:owner/name). An http or https remote keeps its port,
+// since the web UI is served there; other schemes drop it. It returns ""
+// for a local path.
+func parseRemoteHost(remote string) string {
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return ""
+		}
+		if u.Scheme == "http" || u.Scheme == "https" {
+			return u.Host
+		}
+		return u.Hostname()
+	}
+	userHost, _, ok := strings.Cut(remote, ":")
+	if !ok || strings.ContainsAny(userHost, `/\`) {
+		return ""
+	}
+	_, host, found := strings.Cut(userHost, "@")
+	if !found {
+		host = userHost
+	}
+	return host
+}
+
+// openBrowserFn opens a URL in the user's browser. Tests replace it to
+// record URLs without launching anything.
+var openBrowserFn = openBrowser
+
+// openBrowser opens url with the platform's default handler.
+func openBrowser(url string) error {
+	bin := "xdg-open"
+	switch runtime.GOOS {
+	case "darwin":
+		bin = "open"
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url).Start()
+	}
+	return exec.Command(bin, url).Start()
+}
+
+// browserSuppressed reports whether created issues must not be opened in
+// a browser: OpenCreatedIssues is off or the CI environment variable is
+// set.
+func (o *Orchestrator) browserSuppressed() bool {
+	return !o.cfg.Cobbler.OpenCreatedIssues || os.Getenv("CI") != ""
+}
+
+// showCreatedIssues writes one line per created issue to w: its URL on
+// host for a GitHub issue number, or the bare ID otherwise. URLs are also
+// opened in the browser unless browserSuppressed.
+func (o *Orchestrator) showCreatedIssues(w io.Writer, host, repo string, ids []string) {
+	open := !o.browserSuppressed()
+	for _, id := range ids {
+		link := issueURL(host, repo, id)
+		if link == "" {
+			fmt.Fprintln(w, id)
+			continue
+		}
+		fmt.Fprintln(w, link)
+		if open {
+			if err := openBrowserFn(link); err != nil {
+				logf("showCreatedIssues: open %s: %v", link, err)
+			}
+		}
+	}
+}
+
+// latestRunIssues returns the issue IDs created by the most recent measure
+// run in the history issue index of dir, across all of that run's
+// iterations, in iteration order. Iterations are grouped by the run ID in
+// their measure stats; an iteration without one (recorded before run IDs
+// existed, or whose stats are missing) counts as a run of its own.
+func latestRunIssues(dir string) []string {
+	index := loadHistoryIssueIndex(filepath.Join(dir, historyIssueIndexFile))
+	stamps := slices.Sorted(maps.Keys(index))
+	runOf := make(map[string]string, len(stamps))
+	latest := ""
+	for _, ts := range stamps {
+		run := ts
+		if s := loadYAML[HistoryStats](filepath.Join(dir, ts+"-measure-stats.yaml")); s != nil && s.RunID != "" {
+			run = s.RunID
+		}
+		runOf[ts] = run
+		latest = max(latest, run)
+	}
+	var ids []string
+	for _, ts := range stamps {
+		if runOf[ts] == latest {
+			ids = append(ids, index[ts]...)
+		}
+	}
+	return ids
+}
+
+// CreatedIssues prints the issues created by the most recent measure run
+// recorded in the history issue index, across all of its iterations, as
+// URLs for GitHub issues, and opens them in the browser when
+// OpenCreatedIssues is set outside CI. The URL host is taken from the
+// origin remote (github.com when it has none), so issues in a repo on a
+// different host than origin get wrong URLs.
+func (o *Orchestrator) CreatedIssues() error {
+	dir := o.historyDir()
+	if dir == "" {
+		return fmt.Errorf("history directory is not configured")
+	}
+	ids := latestRunIssues(dir)
+	if len(ids) == 0 {
+		fmt.Println("no created issues recorded")
+		return nil
+	}
+
+	repoRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting working directory: %w", err)
+	}
+	repo, err := detectGitHubRepo(repoRoot, o.cfg)
+	if err != nil {
+		logf("CreatedIssues: detectGitHubRepo: %v; printing IDs only", err)
+	}
+	o.showCreatedIssues(os.Stdout, remoteHost(repoRoot), repo, ids)
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// --- issueURL ---
+
+func TestIssueURL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		host, repo, id, want string
+	}{
+		{"github.com", "acme/widgets", "42", "https://github.com/acme/widgets/issues/42"},
+		{"ghe.example.com:8443", "acme/widgets", "42", "https://ghe.example.com:8443/acme/widgets/issues/42"},
+		{"ghe.example.com", "acme/widgets", "42", "https://ghe.example.com/acme/widgets/issues/42"},
+		{"github.com", "acme/widgets", "bd-a1b2", ""},
+		{"github.com", "acme/widgets", "0", ""},
+		{"github.com", "", "42", ""},
+	}
+	for _, tc := range tests {
+		if got := issueURL(tc.host, tc.repo, tc.id); got != tc.want {
+			t.Errorf("issueURL(%q, %q, %q) = %q, want %q", tc.host, tc.repo, tc.id, got, tc.want)
+		}
+	}
+}
+
+// --- parseRemoteHost ---
+
+func TestParseRemoteHost(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		remote, want string
+	}{
+		{"https://github.com/acme/widgets.git", "github.com"},
+		{"https://ghe.example.com/acme/widgets", "ghe.example.com"},
+		{"https://user@ghe.example.com:8443/acme/widgets.git", "ghe.example.com:8443"},
+		{"ssh://git@ghe.example.com:2222/acme/widgets.git", "ghe.example.com"},
+		{"git@ghe.example.com:acme/widgets.git", "ghe.example.com"},
+		{"/srv/git/widgets.git", ""},
+		{"../widgets", ""},
+	}
+	for _, tc := range tests {
+		if got := parseRemoteHost(tc.remote); got != tc.want {
+			t.Errorf("parseRemoteHost(%q) = %q, want %q", tc.remote, got, tc.want)
+		}
+	}
+}
+
+// --- latestRunIssues ---
+
+func TestLatestRunIssues_AllIterationsOfLastRun(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	index := map[string][]string{
+		"2026-03-01-09-00-00": {"1"},      // earlier run
+		"2026-03-01-10-00-00": {"2", "3"}, // last run, iteration 1
+		"2026-03-01-10-05-00": {"4"},      // last run, iteration 2
+	}
+	data, err := yaml.Marshal(index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, historyIssueIndexFile), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for ts, run := range map[string]string{
+		"2026-03-01-09-00-00": "2026-03-01-08-59-00",
+		"2026-03-01-10-00-00": "2026-03-01-09-59-00",
+		"2026-03-01-10-05-00": "2026-03-01-09-59-00",
+	} {
+		stats, err := yaml.Marshal(HistoryStats{Caller: "measure", RunID: run})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, ts+"-measure-stats.yaml"), stats, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, want := latestRunIssues(dir), []string{"2", "3", "4"}; !slices.Equal(got, want) {
+		t.Errorf("latestRunIssues() = %v, want %v", got, want)
+	}
+}
+
+func TestLatestRunIssues_NoRunIDIsOwnRun(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	data, err := yaml.Marshal(map[string][]string{
+		"2026-03-01-09-00-00": {"1"},
+		"2026-03-01-10-00-00": {"2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, historyIssueIndexFile), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := latestRunIssues(dir), []string{"2"}; !slices.Equal(got, want) {
+		t.Errorf("latestRunIssues() = %v, want %v", got, want)
+	}
+}
+
+// --- showCreatedIssues ---
+
+func TestShowCreatedIssues_OpensOnlyWhenEnabledOutsideCI(t *testing.T) {
+	// Not parallel: replaces openBrowserFn and sets CI.
+	var opened []string
+	orig := openBrowserFn
+	openBrowserFn = func(url string) error { opened = append(opened, url); return nil }
+	t.Cleanup(func() { openBrowserFn = orig })
+
+	cfg := Config{}
+	cfg.Cobbler.OpenCreatedIssues = true
+	o := New(cfg)
+
+	t.Setenv("CI", "")
+	var buf bytes.Buffer
+	o.showCreatedIssues(&buf, "github.com", "acme/widgets", []string{"7", "bd-x"})
+	if want := "https://github.com/acme/widgets/issues/7\nbd-x\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+	if !slices.Equal(opened, []string{"https://github.com/acme/widgets/issues/7"}) {
+		t.Errorf("opened = %v, want the issue URL only", opened)
+	}
+
+	opened = nil
+	t.Setenv("CI", "true")
+	o.showCreatedIssues(&bytes.Buffer{}, "github.com", "acme/widgets", []string{"7"})
+	if len(opened) != 0 {
+		t.Errorf("opened %v with CI set, want none", opened)
+	}
+}
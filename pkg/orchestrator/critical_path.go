@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// criticalPath returns the titles of the longest dependency chain among
+// issues, in stitch order, and its length: the minimum number of
+// sequential stitch cycles the issues need. An issue depends on its
+// Dependency, the field import records as cobbler_depends_on; a negative
+// Dependency, or one on an index not among issues (an issue that already
+// exists), starts a chain. Ties go to the chain ending at the earliest
+// issue in the list. Returns an error naming the loop when the
+// dependencies form a cycle.
+func criticalPath(issues []ProposedIssue) ([]string, int, error) {
+	byIndex := make(map[int]ProposedIssue, len(issues))
+	for _, issue := range issues {
+		byIndex[issue.Index] = issue
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[int]int{}
+	depth := map[int]int{} // length of the longest chain ending at an issue
+	prev := map[int]int{}  // predecessor of an issue on that chain
+	var stack []int
+
+	var visit func(idx int) error
+	visit = func(idx int) error {
+		switch state[idx] {
+		case visited:
+			return nil
+		case visiting:
+			loop := append(slices.Clone(stack[slices.Index(stack, idx):]), idx)
+			return fmt.Errorf("dependency cycle: %s", joinIndices(loop, " -> "))
+		}
+		state[idx] = visiting
+		stack = append(stack, idx)
+		depth[idx] = 1
+		if dep := byIndex[idx].Dependency; dep >= 0 {
+			if _, ok := byIndex[dep]; ok {
+				if err := visit(dep); err != nil {
+					return err
+				}
+				depth[idx] = depth[dep] + 1
+				prev[idx] = dep
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[idx] = visited
+		return nil
+	}
+
+	end, longest := 0, 0
+	for _, issue := range issues {
+		if err := visit(issue.Index); err != nil {
+			return nil, 0, err
+		}
+		if depth[issue.Index] > longest {
+			end, longest = issue.Index, depth[issue.Index]
+		}
+	}
+	if longest == 0 {
+		return nil, 0, nil
+	}
+
+	titles := make([]string, longest)
+	for i, idx := longest-1, end; i >= 0; i-- {
+		titles[i] = byIndex[idx].Title
+		idx = prev[idx]
+	}
+	return titles, longest, nil
+}
+
+// writeCriticalPath writes the critical path of issues to w as one
+// "Critical path:" line, or an error line when the dependencies form a
+// cycle. It writes nothing when issues is empty.
+func writeCriticalPath(w io.Writer, issues []ProposedIssue) {
+	titles, length, err := criticalPath(issues)
+	if err != nil {
+		fmt.Fprintf(w, "error: %v\n", err)
+		return
+	}
+	if length == 0 {
+		return
+	}
+	fmt.Fprintf(w, "Critical path: %d sequential stitch cycle(s): %s\n", length, strings.Join(titles, " -> "))
+}
+
+// joinIndices formats indices joined by sep.
+func joinIndices(indices []int, sep string) string {
+	parts := make([]string, len(indices))
+	for i, idx := range indices {
+		parts[i] = strconv.Itoa(idx)
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// --- criticalPath ---
+
+func TestCriticalPath_LinearChain(t *testing.T) {
+	t.Parallel()
+	issues := []ProposedIssue{
+		{Index: 3, Title: "wire CLI", Dependency: 2},
+		{Index: 1, Title: "add parser", Dependency: -1},
+		{Index: 2, Title: "add evaluator", Dependency: 1},
+	}
+	titles, length, err := criticalPath(issues)
+	if err != nil {
+		t.Fatalf("criticalPath: %v", err)
+	}
+	want := []string{"add parser", "add evaluator", "wire CLI"}
+	if length != 3 || !reflect.DeepEqual(titles, want) {
+		t.Errorf("got %v (length %d), want %v (length 3)", titles, length, want)
+	}
+}
+
+func TestCriticalPath_Branching(t *testing.T) {
+	t.Parallel()
+	// 0 -> {1, 2}, with 2 -> 4 -> 3 making the right branch longest.
+	issues := []ProposedIssue{
+		{Index: 0, Title: "schema", Dependency: -1},
+		{Index: 1, Title: "reader", Dependency: 0},
+		{Index: 2, Title: "writer", Dependency: 0},
+		{Index: 4, Title: "writer cache", Dependency: 2},
+		{Index: 3, Title: "sync", Dependency: 4},
+	}
+	titles, length, err := criticalPath(issues)
+	if err != nil {
+		t.Fatalf("criticalPath: %v", err)
+	}
+	want := []string{"schema", "writer", "writer cache", "sync"}
+	if length != 4 || !reflect.DeepEqual(titles, want) {
+		t.Errorf("got %v (length %d), want %v (length 4)", titles, length, want)
+	}
+}
+
+func TestCriticalPath_IndependentIssues(t *testing.T) {
+	t.Parallel()
+	issues := []ProposedIssue{
+		{Index: 1, Title: "a", Dependency: -1},
+		{Index: 2, Title: "b", Dependency: 99}, // existing issue, not in the batch
+	}
+	titles, length, err := criticalPath(issues)
+	if err != nil {
+		t.Fatalf("criticalPath: %v", err)
+	}
+	if length != 1 || !reflect.DeepEqual(titles, []string{"a"}) {
+		t.Errorf("got %v (length %d), want [a] (length 1)", titles, length)
+	}
+	if titles, length, _ := criticalPath(nil); titles != nil || length != 0 {
+		t.Errorf("empty input: got %v (length %d), want nil (length 0)", titles, length)
+	}
+}
+
+func TestCriticalPath_Cycle(t *testing.T) {
+	t.Parallel()
+	issues := []ProposedIssue{
+		{Index: 1, Title: "a", Dependency: 3},
+		{Index: 2, Title: "b", Dependency: 1},
+		{Index: 3, Title: "c", Dependency: 2},
+	}
+	_, _, err := criticalPath(issues)
+	if err == nil {
+		t.Fatal("expected an error for a dependency cycle")
+	}
+	if !strings.Contains(err.Error(), "dependency cycle: 1 -> 3 -> 2 -> 1") {
+		t.Errorf("error = %v, want the loop 1 -> 3 -> 2 -> 1", err)
+	}
+}
+
+func TestCriticalPath_SelfDependency(t *testing.T) {
+	t.Parallel()
+	_, _, err := criticalPath([]ProposedIssue{{Index: 5, Title: "self", Dependency: 5}})
+	if err == nil || !strings.Contains(err.Error(), "5 -> 5") {
+		t.Errorf("error = %v, want a 5 -> 5 cycle", err)
+	}
+}
+
+// --- writeCriticalPath ---
+
+func TestWriteCriticalPath(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	writeCriticalPath(&buf, []ProposedIssue{
+		{Index: 1, Title: "add parser", Dependency: -1},
+		{Index: 2, Title: "add evaluator", Dependency: 1},
+	})
+	if want := "Critical path: 2 sequential stitch cycle(s): add parser -> add evaluator\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	writeCriticalPath(&buf, []ProposedIssue{{Index: 5, Title: "self", Dependency: 5}})
+	if !strings.HasPrefix(buf.String(), "error: dependency cycle: 5 -> 5") {
+		t.Errorf("cycle output = %q, want an error line", buf.String())
+	}
+
+	buf.Reset()
+	writeCriticalPath(&buf, nil)
+	if buf.Len() != 0 {
+		t.Errorf("empty input wrote %q, want nothing", buf.String())
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GenerationStats summarizes one generation across all of its recorded
+// measure and stitch invocations.
+type GenerationStats struct {
+	Generation        string
+	Invocations       int           // measure and stitch invocations recorded
+	IssuesProposed    int           // issues in the saved measure output files
+	IssuesImplemented int           // distinct tasks with a successful stitch
+	InputTokens       int           // summed input tokens
+	OutputTokens      int           // summed output tokens
+	WallClock         time.Duration // first invocation start to last invocation end
+	ProdLOCAdded      int           // net production LOC change from successful stitches
+	TestLOCAdded      int           // net test LOC change from successful stitches
+	CostUSD           float64       // reported cost, or the CostModel estimate when unreported
+}
+
+// GenerationStats aggregates the history records of generation genID:
+// the HistoryStats files for tokens, timing, LOC, and cost, and the saved
+// measure issues files for the number of proposed issues.
+func (o *Orchestrator) GenerationStats(genID string) (*GenerationStats, error) {
+	if genID == "" {
+		return nil, fmt.Errorf("generation ID is required")
+	}
+	dir := o.historyDir()
+	if dir == "" {
+		return nil, fmt.Errorf("history directory is not configured")
+	}
+	stats, err := loadHistoryStats(dir)
+	if err != nil {
+		return nil, err
+	}
+	gs := summarizeGeneration(genID, filterStatsByGeneration(stats, genID), o.cfg.Claude.CostModel)
+	gs.IssuesProposed = o.countProposedIssues(dir, genID)
+	return gs, nil
+}
+
+// summarizeGeneration folds the records of one generation into a
+// GenerationStats. IssuesProposed is left for the caller.
+func summarizeGeneration(genID string, stats []HistoryStats, model CostModel) *GenerationStats {
+	gs := &GenerationStats{Generation: genID, Invocations: len(stats)}
+	implemented := map[string]bool{}
+	var first, last time.Time
+	for _, s := range stats {
+		gs.InputTokens += s.Tokens.Input
+		gs.OutputTokens += s.Tokens.Output
+		gs.CostUSD += statsCostUSD(s, model)
+
+		if start, err := time.Parse(time.RFC3339, s.StartedAt); err == nil {
+			end := start.Add(time.Duration(s.DurationS) * time.Second)
+			if first.IsZero() || start.Before(first) {
+				first = start
+			}
+			if end.After(last) {
+				last = end
+			}
+		}
+
+		if s.Caller != "stitch" || s.Status != "success" {
+			continue
+		}
+		implemented[s.TaskID] = true
+		if s.LOCAfter != (LocSnapshot{}) {
+			gs.ProdLOCAdded += s.LOCAfter.Production - s.LOCBefore.Production
+			gs.TestLOCAdded += s.LOCAfter.Test - s.LOCBefore.Test
+		}
+	}
+	gs.IssuesImplemented = len(implemented)
+	if !first.IsZero() {
+		gs.WallClock = last.Sub(first)
+	}
+	return gs
+}
+
+// countProposedIssues sums the issues in the measure issues files saved
+// for successful measure invocations of genID. Each file shares its
+// timestamp with the {ts}-measure-stats.yaml record of the invocation.
+func (o *Orchestrator) countProposedIssues(dir, genID string) int {
+	paths, err := filepath.Glob(filepath.Join(dir, "*-measure-stats.yaml"))
+	if err != nil {
+		logf("countProposedIssues: %v", err)
+		return 0
+	}
+	total := 0
+	for _, p := range paths {
+		s := loadYAML[HistoryStats](p)
+		if s == nil || s.Generation != genID || s.Status != "success" {
+			continue
+		}
+		ts := strings.TrimSuffix(filepath.Base(p), "-measure-stats.yaml")
+		data, err := os.ReadFile(filepath.Join(dir, o.historyFileNameFor(ts, genID, "issues")+".yaml"))
+		if err != nil {
+			continue
+		}
+		var issues []ProposedIssue
+		if err := yaml.Unmarshal(data, &issues); err != nil {
+			logf("countProposedIssues: parse issues for %s: %v", ts, err)
+			continue
+		}
+		total += len(issues)
+	}
+	return total
+}
+
+// PrintGenerationStats prints stats as a summary table to stdout.
+func PrintGenerationStats(stats *GenerationStats) error {
+	return formatGenerationStats(os.Stdout, stats)
+}
+
+// formatGenerationStats writes the PrintGenerationStats table to w.
+func formatGenerationStats(w io.Writer, gs *GenerationStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Generation\t%s\n", gs.Generation)
+	fmt.Fprintf(tw, "Invocations\t%d\n", gs.Invocations)
+	fmt.Fprintf(tw, "Issues proposed\t%d\n", gs.IssuesProposed)
+	fmt.Fprintf(tw, "Issues implemented\t%d\n", gs.IssuesImplemented)
+	fmt.Fprintf(tw, "Tokens\t%d (in %d, out %d)\n", gs.InputTokens+gs.OutputTokens, gs.InputTokens, gs.OutputTokens)
+	fmt.Fprintf(tw, "Wall clock\t%s\n", formatDuration(int(gs.WallClock.Seconds())))
+	fmt.Fprintf(tw, "LOC added\t%+d prod, %+d test\n", gs.ProdLOCAdded, gs.TestLOCAdded)
+	fmt.Fprintf(tw, "Cost\t$%.2f\n", gs.CostUSD)
+	return tw.Flush()
+}
+
+// GenerationThroughput counts the issues created by the measure runs of
+// one generation.
+type GenerationThroughput struct {
+	Generation    string
+	Runs          int    // measure runs that created at least one issue
+	IssuesCreated int    // issues created across those runs
+	FirstRun      string // timestamp of the earliest run
+	LastRun       string // timestamp of the latest run
+}
+
+// IssueThroughput prints a timeline of issues created per generation
+// across all measure runs, from oldest to newest generation. Created IDs
+// come from the history issue index; each run's generation comes from its
+// {ts}-measure-stats.yaml record.
+func (o *Orchestrator) IssueThroughput() error {
+	dir := o.historyDir()
+	if dir == "" {
+		return fmt.Errorf("history directory is not configured")
+	}
+	rows := issueThroughput(dir)
+	if len(rows) == 0 {
+		fmt.Printf("no created issues recorded in %s\n", dir)
+		return nil
+	}
+	return formatIssueThroughput(os.Stdout, rows)
+}
+
+// issueThroughput groups the history issue index in dir by generation and
+// sums the created issue counts. A missing index yields no rows; runs
+// whose stats record is missing or unreadable are logged and skipped.
+// Rows are ordered by their first run.
+func issueThroughput(dir string) []GenerationThroughput {
+	index := loadHistoryIssueIndex(filepath.Join(dir, historyIssueIndexFile))
+	stamps := make([]string, 0, len(index))
+	for ts := range index {
+		stamps = append(stamps, ts)
+	}
+	sort.Strings(stamps)
+
+	var rows []GenerationThroughput
+	byGen := map[string]int{}
+	for _, ts := range stamps {
+		s := loadYAML[HistoryStats](filepath.Join(dir, ts+"-measure-stats.yaml"))
+		if s == nil {
+			logf("issueThroughput: no measure stats for %s, skipping", ts)
+			continue
+		}
+		i, ok := byGen[s.Generation]
+		if !ok {
+			i = len(rows)
+			byGen[s.Generation] = i
+			rows = append(rows, GenerationThroughput{Generation: s.Generation, FirstRun: ts})
+		}
+		rows[i].Runs++
+		rows[i].IssuesCreated += len(index[ts])
+		rows[i].LastRun = ts
+	}
+	return rows
+}
+
+// formatIssueThroughput writes the IssueThroughput timeline to w, with a
+// running total of created issues.
+func formatIssueThroughput(w io.Writer, rows []GenerationThroughput) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "GENERATION\tFIRST RUN\tLAST RUN\tRUNS\tCREATED\tTOTAL")
+	total := 0
+	for _, r := range rows {
+		total += r.IssuesCreated
+		gen := r.Generation
+		if gen == "" {
+			gen = "(none)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%d\n", gen, r.FirstRun, r.LastRun, r.Runs, r.IssuesCreated, total)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// --- GenerationStats ---
+
+func TestGenerationStats_AggregatesGeneration(t *testing.T) {
+	t.Parallel()
+	histDir := t.TempDir()
+	for name, s := range map[string]HistoryStats{
+		"2026-03-01-09-00-00-measure-stats.yaml": {
+			Caller: "measure", Generation: "gen-a", Status: "success",
+			StartedAt: "2026-03-01T09:00:00Z", DurationS: 60,
+			Tokens: historyTokens{Input: 1000, Output: 200}, CostUSD: 0.50,
+		},
+		"2026-03-01-09-10-00-stitch-stats.yaml": {
+			Caller: "stitch", Generation: "gen-a", Status: "success", TaskID: "1",
+			StartedAt: "2026-03-01T09:10:00Z", DurationS: 300,
+			Tokens:    historyTokens{Input: 400, Output: 100},
+			LOCBefore: LocSnapshot{Production: 100, Test: 50},
+			LOCAfter:  LocSnapshot{Production: 130, Test: 70},
+		},
+		"2026-03-01-09-20-00-stitch-stats.yaml": {
+			Caller: "stitch", Generation: "gen-a", Status: "failed", TaskID: "2",
+			StartedAt: "2026-03-01T09:20:00Z", DurationS: 120,
+			Tokens: historyTokens{Input: 10, Output: 5}, CostUSD: 0.05,
+		},
+		"2026-03-01-09-30-00-stitch-stats.yaml": {
+			Caller: "stitch", Generation: "gen-a", Status: "success", TaskID: "2",
+			StartedAt: "2026-03-01T09:30:00Z", DurationS: 600,
+			Tokens: historyTokens{Input: 90, Output: 45}, CostUSD: 0.20,
+			LOCBefore: LocSnapshot{Production: 130, Test: 70},
+			LOCAfter:  LocSnapshot{Production: 125, Test: 90},
+		},
+		"2026-03-02-09-00-00-measure-stats.yaml": {
+			Caller: "measure", Generation: "gen-b", Status: "success",
+			StartedAt: "2026-03-02T09:00:00Z", DurationS: 60,
+			Tokens: historyTokens{Input: 9999, Output: 9999}, CostUSD: 9,
+		},
+	} {
+		data, _ := yaml.Marshal(s)
+		os.WriteFile(filepath.Join(histDir, name), data, 0o644)
+	}
+	os.WriteFile(filepath.Join(histDir, "2026-03-01-09-00-00-measure-issues.yaml"),
+		[]byte("- index: 1\n  title: a\n- index: 2\n  title: b\n- index: 3\n  title: c\n"), 0o644)
+	os.WriteFile(filepath.Join(histDir, "2026-03-02-09-00-00-measure-issues.yaml"),
+		[]byte("- index: 1\n  title: other generation\n"), 0o644)
+
+	o := New(Config{
+		Cobbler: CobblerConfig{HistoryDir: histDir},
+		Claude:  ClaudeConfig{CostModel: CostModel{InputUSDPerToken: 0.001, OutputUSDPerToken: 0.01}},
+	})
+	gs, err := o.GenerationStats("gen-a")
+	if err != nil {
+		t.Fatalf("GenerationStats: %v", err)
+	}
+
+	if gs.Generation != "gen-a" {
+		t.Errorf("Generation = %q, want gen-a", gs.Generation)
+	}
+	if gs.Invocations != 4 {
+		t.Errorf("Invocations = %d, want 4", gs.Invocations)
+	}
+	if gs.IssuesProposed != 3 {
+		t.Errorf("IssuesProposed = %d, want 3", gs.IssuesProposed)
+	}
+	if gs.IssuesImplemented != 2 {
+		t.Errorf("IssuesImplemented = %d, want 2", gs.IssuesImplemented)
+	}
+	if gs.InputTokens != 1500 || gs.OutputTokens != 350 {
+		t.Errorf("tokens = in %d out %d, want in 1500 out 350", gs.InputTokens, gs.OutputTokens)
+	}
+	if want := 40 * time.Minute; gs.WallClock != want {
+		t.Errorf("WallClock = %s, want %s", gs.WallClock, want)
+	}
+	if gs.ProdLOCAdded != 25 || gs.TestLOCAdded != 40 {
+		t.Errorf("LOC added = prod %d test %d, want prod 25 test 40", gs.ProdLOCAdded, gs.TestLOCAdded)
+	}
+	// Unreported stitch cost falls back to the estimate: 400*0.001 + 100*0.01.
+	if want := 0.50 + 1.40 + 0.05 + 0.20; math.Abs(gs.CostUSD-want) > 1e-9 {
+		t.Errorf("CostUSD = %v, want %v", gs.CostUSD, want)
+	}
+}
+
+func TestGenerationStats_RequiresGenID(t *testing.T) {
+	t.Parallel()
+	if _, err := New(Config{}).GenerationStats(""); err == nil {
+		t.Error("expected error for empty generation ID")
+	}
+}
+
+func TestSummarizeGeneration_Empty(t *testing.T) {
+	t.Parallel()
+	gs := summarizeGeneration("gen", nil, CostModel{})
+	if *gs != (GenerationStats{Generation: "gen"}) {
+		t.Errorf("summarizeGeneration(nil) = %+v, want zero stats", *gs)
+	}
+}
+
+// --- formatGenerationStats ---
+
+func TestFormatGenerationStats_Fields(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	err := formatGenerationStats(&buf, &GenerationStats{
+		Generation:        "gen-a",
+		Invocations:       4,
+		IssuesProposed:    3,
+		IssuesImplemented: 2,
+		InputTokens:       1500,
+		OutputTokens:      350,
+		WallClock:         40*time.Minute + 5*time.Second,
+		ProdLOCAdded:      25,
+		TestLOCAdded:      -4,
+		CostUSD:           2.154,
+	})
+	if err != nil {
+		t.Fatalf("formatGenerationStats: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"gen-a",
+		"Invocations         4",
+		"Issues proposed     3",
+		"Issues implemented  2",
+		"1850 (in 1500, out 350)",
+		"40m5s",
+		"+25 prod, -4 test",
+		"$2.15",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+// --- issueThroughput ---
+
+func TestIssueThroughput_GroupsByGeneration(t *testing.T) {
+	t.Parallel()
+	histDir := t.TempDir()
+	for ts, gen := range map[string]string{
+		"2026-03-01-09-00-00": "gen-a",
+		"2026-03-01-12-00-00": "gen-a",
+		"2026-03-02-09-00-00": "gen-b",
+	} {
+		data, _ := yaml.Marshal(HistoryStats{Caller: "measure", Generation: gen, Status: "success"})
+		os.WriteFile(filepath.Join(histDir, ts+"-measure-stats.yaml"), data, 0o644)
+	}
+	index := map[string][]string{
+		"2026-03-01-09-00-00": {"1", "2"},
+		"2026-03-01-12-00-00": {"3"},
+		"2026-03-02-09-00-00": {"4", "5", "6"},
+		"2026-03-03-09-00-00": {"7"}, // no stats record: skipped
+	}
+	data, _ := yaml.Marshal(index)
+	os.WriteFile(filepath.Join(histDir, historyIssueIndexFile), data, 0o644)
+
+	got := issueThroughput(histDir)
+	want := []GenerationThroughput{
+		{Generation: "gen-a", Runs: 2, IssuesCreated: 3, FirstRun: "2026-03-01-09-00-00", LastRun: "2026-03-01-12-00-00"},
+		{Generation: "gen-b", Runs: 1, IssuesCreated: 3, FirstRun: "2026-03-02-09-00-00", LastRun: "2026-03-02-09-00-00"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows %+v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := formatIssueThroughput(&buf, got); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasSuffix(strings.TrimSpace(lines[2]), "6") {
+		t.Errorf("timeline missing running total:\n%s", buf.String())
+	}
+}
+
+func TestIssueThroughput_MissingIndex(t *testing.T) {
+	t.Parallel()
+	if got := issueThroughput(t.TempDir()); len(got) != 0 {
+		t.Errorf("missing index: got %+v, want no rows", got)
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"strings"
 	"text/template"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // GeneratorRun executes N cycles of Measure + Stitch within the current generation.
@@ -592,7 +594,10 @@ func (o *Orchestrator) cleanupUnmergedTags() {
 	}
 }
 
-// resolveBranch determines which branch to work on.
+// resolveBranch determines which branch to work on: the explicit branch
+// when given, else the current branch when it is a generation branch (as
+// inside a generation worktree), else the only generation branch, else
+// the current branch.
 func (o *Orchestrator) resolveBranch(explicit string) (string, error) {
 	if explicit != "" {
 		if !gitBranchExists(explicit, ".") {
@@ -601,6 +606,12 @@ func (o *Orchestrator) resolveBranch(explicit string) (string, error) {
 		return explicit, nil
 	}
 
+	if prefix := o.cfg.Generation.Prefix; prefix != "" {
+		if current, err := gitCurrentBranch("."); err == nil && strings.HasPrefix(current, prefix) {
+			return current, nil
+		}
+	}
+
 	branches := o.listGenerationBranches()
 	switch len(branches) {
 	case 0:
@@ -762,6 +773,13 @@ func (o *Orchestrator) GeneratorReset() error {
 		return fmt.Errorf("switching to %s: %w", baseBranch, err)
 	}
 
+	// Render seed templates before anything is deleted so a broken
+	// template cannot leave the repository without sources.
+	seeds, err := o.renderSeedFiles(baseBranch)
+	if err != nil {
+		return fmt.Errorf("seeding files: %w", err)
+	}
+
 	wtBase := worktreeBasePath()
 	ghRepo, _ := detectGitHubRepo(".", o.cfg)
 	genBranches := o.listGenerationBranches()
@@ -816,9 +834,10 @@ func (o *Orchestrator) GeneratorReset() error {
 	o.cleanupDirs()
 
 	logf("generator:reset: seeding Go sources and reinitializing go.mod")
-	if err := o.seedFiles(baseBranch); err != nil {
+	if err := writeSeedFiles(seeds); err != nil {
 		return fmt.Errorf("seeding files: %w", err)
 	}
+	o.recordSeedFiles(seeds)
 	if err := o.reinitGoModule(); err != nil {
 		return fmt.Errorf("reinitializing go module: %w", err)
 	}
@@ -833,15 +852,21 @@ func (o *Orchestrator) GeneratorReset() error {
 
 // resetGoSources deletes Go files, removes empty source dirs,
 // clears build artifacts, seeds files, and reinitializes the Go module.
+// Seed templates are rendered first; if any fails, nothing is deleted.
 func (o *Orchestrator) resetGoSources(version string) error {
+	seeds, err := o.renderSeedFiles(version)
+	if err != nil {
+		return fmt.Errorf("seeding files: %w", err)
+	}
 	o.deleteGoFiles(".")
 	for _, dir := range o.cfg.Project.GoSourceDirs {
 		removeEmptyDirs(dir)
 	}
 	os.RemoveAll(o.cfg.Project.BinaryDir + "/")
-	if err := o.seedFiles(version); err != nil {
+	if err := writeSeedFiles(seeds); err != nil {
 		return fmt.Errorf("seeding files: %w", err)
 	}
+	o.recordSeedFiles(seeds)
 	return o.reinitGoModule()
 }
 
@@ -858,35 +883,192 @@ func (o *Orchestrator) cleanGoSources() {
 
 // seedFiles creates the configured seed files using Go templates.
 func (o *Orchestrator) seedFiles(version string) error {
+	seeds, err := o.renderSeedFiles(version)
+	if err != nil {
+		return err
+	}
+	if err := writeSeedFiles(seeds); err != nil {
+		return err
+	}
+	o.recordSeedFiles(seeds)
+	return nil
+}
+
+// renderSeedFiles executes every SeedFiles template, plus each
+// OptionalSeedFiles template whose When field is non-empty, with SeedData
+// and returns the rendered contents keyed by destination path. It returns an
+// error naming the first template (in path order) that fails to parse or
+// execute, without touching the filesystem.
+func (o *Orchestrator) renderSeedFiles(version string) (map[string][]byte, error) {
 	data := SeedData{
 		Version:    version,
 		ModulePath: o.cfg.Project.ModulePath,
 	}
 
-	for _, path := range slices.Sorted(maps.Keys(o.cfg.Project.SeedFiles)) {
-		tmplStr := o.cfg.Project.SeedFiles[path]
-		dir := filepath.Dir(path)
-		if err := os.MkdirAll(dir, 0o755); err != nil {
-			return err
+	sources := make(map[string]string, len(o.cfg.Project.SeedFiles)+len(o.cfg.Project.OptionalSeedFiles))
+	maps.Copy(sources, o.cfg.Project.SeedFiles)
+	for _, path := range slices.Sorted(maps.Keys(o.cfg.Project.OptionalSeedFiles)) {
+		opt := o.cfg.Project.OptionalSeedFiles[path]
+		if _, dup := sources[path]; dup {
+			return nil, fmt.Errorf("seed file %s is listed in both seed_files and optional_seed_files", path)
+		}
+		val, ok := o.cfg.Project.seedConditionValue(opt.When)
+		if !ok {
+			return nil, fmt.Errorf("optional seed file %s: unknown project field %q in when", path, opt.When)
 		}
+		if val == "" {
+			logf("renderSeedFiles: skipping %s (%s is empty)", path, opt.When)
+			continue
+		}
+		sources[path] = opt.Template
+	}
 
-		tmpl, err := template.New(path).Parse(tmplStr)
+	rendered := make(map[string][]byte, len(sources))
+	for _, path := range slices.Sorted(maps.Keys(sources)) {
+		tmpl, err := template.New(path).Parse(sources[path])
 		if err != nil {
-			return fmt.Errorf("parsing seed template for %s: %w", path, err)
+			return nil, fmt.Errorf("parsing seed template for %s: %w", path, err)
 		}
 
 		var buf bytes.Buffer
 		if err := tmpl.Execute(&buf, data); err != nil {
-			return fmt.Errorf("executing seed template for %s: %w", path, err)
+			return nil, fmt.Errorf("executing seed template for %s: %w", path, err)
 		}
+		rendered[path] = buf.Bytes()
+	}
+	return rendered, nil
+}
 
-		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+// writeSeedFiles writes rendered seed files, creating parent directories
+// as needed.
+func writeSeedFiles(rendered map[string][]byte) error {
+	for _, path := range slices.Sorted(maps.Keys(rendered)) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, rendered[path], 0o644); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// seedManifestFileName is the file that lists every path written by
+// seeding, so that seeds later dropped from the config can still be found
+// on disk. See seedManifestPath for where it is kept.
+const seedManifestFileName = "cobbler-seed-manifest.yaml"
+
+// seedManifestPath returns the seed manifest path. The manifest is kept in
+// the shared .git directory of o.repoRoot, which neither CobblerReset
+// (removes Cobbler.Dir) nor GeneratorReset (removes Go sources and
+// worktrees) touches, and which is never committed. Outside a git
+// repository it falls back to Cobbler.Dir under o.repoRoot, where a
+// cobbler reset loses it. Returns "" when o.repoRoot is unset or neither
+// is available.
+func (o *Orchestrator) seedManifestPath() string {
+	if o.repoRoot == "" {
+		return ""
+	}
+	if gitDir, err := gitCommonDir(o.repoRoot); err == nil {
+		return filepath.Join(gitDir, seedManifestFileName)
+	}
+	if o.cfg.Cobbler.Dir == "" {
+		return ""
+	}
+	if filepath.IsAbs(o.cfg.Cobbler.Dir) {
+		return filepath.Join(o.cfg.Cobbler.Dir, seedManifestFileName)
+	}
+	return filepath.Join(o.repoRoot, o.cfg.Cobbler.Dir, seedManifestFileName)
+}
+
+// recordSeedFiles adds the paths of rendered to the seed manifest.
+// Recorded paths whose files no longer exist are dropped. Errors are
+// logged, not returned, because the manifest only feeds
+// UnreferencedSeedFiles.
+func (o *Orchestrator) recordSeedFiles(rendered map[string][]byte) {
+	path := o.seedManifestPath()
+	if path == "" {
+		return
+	}
+	paths := map[string]bool{}
+	for _, p := range o.loadSeedManifest() {
+		if _, err := os.Stat(p); err == nil {
+			paths[p] = true
+		}
+	}
+	for p := range rendered {
+		paths[filepath.Clean(p)] = true
+	}
+	data, err := yaml.Marshal(slices.Sorted(maps.Keys(paths)))
+	if err != nil {
+		logf("recordSeedFiles: marshal: %v", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		logf("recordSeedFiles: mkdir %s: %v", filepath.Dir(path), err)
+		return
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		logf("recordSeedFiles: write %s: %v", path, err)
+	}
+}
+
+// loadSeedManifest returns the paths in the seed manifest, or nil when
+// there is none.
+func (o *Orchestrator) loadSeedManifest() []string {
+	path := o.seedManifestPath()
+	if path == "" {
+		return nil
+	}
+	paths := loadYAML[[]string](path)
+	if paths == nil {
+		return nil
+	}
+	return *paths
+}
+
+// unreferencedSeedFiles returns the sorted seed manifest paths that still
+// exist on disk but are keys of neither SeedFiles nor OptionalSeedFiles.
+func (o *Orchestrator) unreferencedSeedFiles() []string {
+	configured := map[string]bool{}
+	for p := range o.cfg.Project.SeedFiles {
+		configured[filepath.Clean(p)] = true
+	}
+	for p := range o.cfg.Project.OptionalSeedFiles {
+		configured[filepath.Clean(p)] = true
+	}
+	var stale []string
+	for _, p := range o.loadSeedManifest() {
+		if configured[p] {
+			continue
+		}
+		if _, err := os.Stat(p); err == nil {
+			stale = append(stale, p)
+		}
+	}
+	slices.Sort(stale)
+	return stale
+}
+
+// UnreferencedSeedFiles prints the files that were seeded by an earlier
+// config but are no longer listed in SeedFiles or OptionalSeedFiles, so
+// they can be reviewed and removed. Nothing is deleted. Only seeds
+// written since the seed manifest was introduced are known.
+//
+// Exposed as a mage target (e.g., mage generator:staleSeeds).
+func (o *Orchestrator) UnreferencedSeedFiles() error {
+	stale := o.unreferencedSeedFiles()
+	if len(stale) == 0 {
+		fmt.Println("No unreferenced seed files.")
+		return nil
+	}
+	fmt.Printf("%d seed file(s) no longer in seed_files or optional_seed_files:\n", len(stale))
+	for _, p := range stale {
+		fmt.Printf("  %s\n", p)
+	}
+	return nil
+}
+
 // reinitGoModule removes go.sum and go.mod, then creates a fresh module
 // with a local replace directive and resolves dependencies.
 func (o *Orchestrator) reinitGoModule() error {
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -345,6 +346,100 @@ func TestSeedFiles_InvalidTemplate(t *testing.T) {
 	}
 }
 
+func TestResetGoSources_BadTemplateLeavesSourcesUntouched(t *testing.T) {
+	dir := chdirTemp(t)
+	os.MkdirAll(filepath.Join(dir, "pkg", "app"), 0o755)
+	existing := filepath.Join(dir, "pkg", "app", "app.go")
+	os.WriteFile(existing, []byte("package app\n"), 0o644)
+
+	o := &Orchestrator{cfg: Config{
+		Project: ProjectConfig{
+			GoSourceDirs: []string{"pkg/"},
+			SeedFiles: map[string]string{
+				"good.go": "package main\n",
+				"bad.go":  "{{.Invalid",
+			},
+		},
+	}}
+
+	err := o.resetGoSources("main")
+	if err == nil {
+		t.Fatal("resetGoSources() expected error for invalid template, got nil")
+	}
+	if !strings.Contains(err.Error(), "bad.go") {
+		t.Errorf("error = %q, want it to name bad.go", err)
+	}
+	data, readErr := os.ReadFile(existing)
+	if readErr != nil || string(data) != "package app\n" {
+		t.Errorf("existing source should be untouched, got %q (err=%v)", data, readErr)
+	}
+	if _, statErr := os.Stat(filepath.Join(dir, "good.go")); !os.IsNotExist(statErr) {
+		t.Error("no seed file should be written when any template fails")
+	}
+}
+
+func TestSeedFiles_OptionalIncludedWhenFieldSet(t *testing.T) {
+	dir := chdirTemp(t)
+
+	o := &Orchestrator{cfg: Config{
+		Project: ProjectConfig{
+			MainPackage: "cmd/app",
+			OptionalSeedFiles: map[string]OptionalSeedFile{
+				"cmd/app/main.go": {Template: "package main // {{.Version}}\n", When: "main_package"},
+			},
+		},
+	}}
+
+	if err := o.seedFiles("v1"); err != nil {
+		t.Fatalf("seedFiles() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "cmd", "app", "main.go"))
+	if err != nil {
+		t.Fatalf("reading seeded file: %v", err)
+	}
+	if string(data) != "package main // v1\n" {
+		t.Errorf("seeded file content = %q, want rendered template", data)
+	}
+}
+
+func TestSeedFiles_OptionalSkippedWhenFieldEmpty(t *testing.T) {
+	dir := chdirTemp(t)
+
+	o := &Orchestrator{cfg: Config{
+		Project: ProjectConfig{
+			SeedFiles: map[string]string{"lib.go": "package lib\n"},
+			OptionalSeedFiles: map[string]OptionalSeedFile{
+				"cmd/app/main.go": {Template: "package main\n", When: "main_package"},
+			},
+		},
+	}}
+
+	if err := o.seedFiles("v1"); err != nil {
+		t.Fatalf("seedFiles() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "lib.go")); err != nil {
+		t.Errorf("unconditional seed file should be written: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "cmd", "app", "main.go")); !os.IsNotExist(err) {
+		t.Error("optional seed file should be skipped when main_package is empty")
+	}
+}
+
+func TestRenderSeedFiles_OptionalUnknownField(t *testing.T) {
+	t.Parallel()
+	o := &Orchestrator{cfg: Config{
+		Project: ProjectConfig{
+			OptionalSeedFiles: map[string]OptionalSeedFile{
+				"x.go": {Template: "package x\n", When: "no_such_field"},
+			},
+		},
+	}}
+
+	if _, err := o.renderSeedFiles("v1"); err == nil || !strings.Contains(err.Error(), "no_such_field") {
+		t.Errorf("renderSeedFiles() error = %v, want unknown field error", err)
+	}
+}
+
 func TestSeedFiles_EmptyMap(t *testing.T) {
 	t.Parallel()
 	o := &Orchestrator{cfg: Config{
@@ -358,6 +453,73 @@ func TestSeedFiles_EmptyMap(t *testing.T) {
 	}
 }
 
+// --- unreferencedSeedFiles (uses cwd, NOT parallel) ---
+
+func TestUnreferencedSeedFiles_ReportsDroppedSeed(t *testing.T) {
+	dir := initTestGitRepo(t)
+
+	o := &Orchestrator{cfg: Config{
+		Cobbler: CobblerConfig{Dir: ".cobbler"},
+		Project: ProjectConfig{
+			SeedFiles: map[string]string{
+				"cmd/app/version.go": "package main\n",
+				"pkg/old/seed.go":    "package old\n",
+			},
+		},
+	}, repoRoot: dir}
+	if err := o.seedFiles("v1"); err != nil {
+		t.Fatalf("seedFiles() error = %v", err)
+	}
+	if got := o.unreferencedSeedFiles(); len(got) != 0 {
+		t.Fatalf("all seeds configured: got %v, want none", got)
+	}
+
+	// The manifest survives a cobbler reset, which removes Cobbler.Dir.
+	if err := o.CobblerReset(); err != nil {
+		t.Fatalf("CobblerReset() error = %v", err)
+	}
+
+	// Drop pkg/old/seed.go from the config and re-seed.
+	delete(o.cfg.Project.SeedFiles, "pkg/old/seed.go")
+	if err := o.seedFiles("v2"); err != nil {
+		t.Fatalf("seedFiles() error = %v", err)
+	}
+	want := []string{filepath.Join("pkg", "old", "seed.go")}
+	if got := o.unreferencedSeedFiles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unreferencedSeedFiles() = %v, want %v", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pkg", "old", "seed.go")); err != nil {
+		t.Errorf("unreferenced seed should not be deleted: %v", err)
+	}
+
+	// Once the stale file is removed it is no longer reported.
+	os.Remove(filepath.Join(dir, "pkg", "old", "seed.go"))
+	if got := o.unreferencedSeedFiles(); len(got) != 0 {
+		t.Errorf("after removal: got %v, want none", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git", seedManifestFileName)); err != nil {
+		t.Errorf("seed manifest should be kept in the test repo's .git: %v", err)
+	}
+}
+
+func TestSeedManifestPath_EmptyWithoutRepoRoot(t *testing.T) {
+	t.Parallel()
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: ".cobbler"}}}
+	if got := o.seedManifestPath(); got != "" {
+		t.Errorf("seedManifestPath() = %q, want empty when repoRoot is unset", got)
+	}
+}
+
+func TestSeedManifestPath_FallsBackToCobblerDirUnderRepoRoot(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: ".cobbler"}}, repoRoot: root}
+	want := filepath.Join(root, ".cobbler", seedManifestFileName)
+	if got := o.seedManifestPath(); got != want {
+		t.Errorf("seedManifestPath() = %q, want %q", got, want)
+	}
+}
+
 // --- deleteGoFiles (uses cwd, NOT parallel) ---
 
 func TestDeleteGoFiles_RemovesGoFiles(t *testing.T) {
@@ -676,6 +838,75 @@ func TestResolveBranch_MultipleGenerationBranches(t *testing.T) {
 	}
 }
 
+func TestResolveBranch_CurrentGenerationBranch(t *testing.T) {
+	initTestGitRepo(t)
+
+	gitCreateBranch("generation-2026-02-28-12-00-00", "")
+	gitCreateBranch("generation-2026-02-28-13-00-00", "")
+	if err := gitCheckout("generation-2026-02-28-13-00-00", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// HEAD wins over the multiple-branches error.
+	o := &Orchestrator{cfg: Config{Generation: GenerationConfig{Prefix: "generation-"}}}
+	got, err := o.resolveBranch("")
+	if err != nil {
+		t.Fatalf("resolveBranch() error = %v", err)
+	}
+	if got != "generation-2026-02-28-13-00-00" {
+		t.Errorf("resolveBranch() = %q, want %q", got, "generation-2026-02-28-13-00-00")
+	}
+}
+
+func TestResolveBranch_InsideGenerationWorktree(t *testing.T) {
+	repo := initTestGitRepo(t)
+
+	gitCreateBranch("generation-2026-02-28-12-00-00", "")
+	gitCreateBranch("generation-2026-02-28-13-00-00", "")
+	wt := filepath.Join(t.TempDir(), "wt")
+	if out, err := gitWorktreeAdd(wt, "generation-2026-02-28-12-00-00", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git worktree add: %v\n%s", err, out)
+	}
+	if err := os.Chdir(wt); err != nil {
+		t.Fatal(err)
+	}
+
+	o := &Orchestrator{cfg: Config{Generation: GenerationConfig{Prefix: "generation-"}}}
+	got, err := o.resolveBranch("")
+	if err != nil {
+		t.Fatalf("resolveBranch() error = %v", err)
+	}
+	if got != "generation-2026-02-28-12-00-00" {
+		t.Errorf("resolveBranch() = %q, want %q", got, "generation-2026-02-28-12-00-00")
+	}
+}
+
+func TestResolveBranch_CurrentBranchWithoutPrefixIgnored(t *testing.T) {
+	initTestGitRepo(t)
+
+	gitCreateBranch("generation-2026-02-28-12-00-00", "")
+
+	// On main, the single generation branch is still chosen.
+	o := &Orchestrator{cfg: Config{Generation: GenerationConfig{Prefix: "generation-"}}}
+	got, err := o.resolveBranch("")
+	if err != nil {
+		t.Fatalf("resolveBranch() error = %v", err)
+	}
+	if got != "generation-2026-02-28-12-00-00" {
+		t.Errorf("resolveBranch() = %q, want %q", got, "generation-2026-02-28-12-00-00")
+	}
+}
+
+func TestResolveBranch_GitUnavailable(t *testing.T) {
+	initTestGitRepo(t)
+	t.Setenv("PATH", t.TempDir())
+
+	o := &Orchestrator{cfg: Config{Generation: GenerationConfig{Prefix: "generation-"}}}
+	if _, err := o.resolveBranch(""); err == nil {
+		t.Error("resolveBranch() expected error when git is not on PATH")
+	}
+}
+
 // --- listGenerationBranches (git, NOT parallel) ---
 
 func TestListGenerationBranches_NoBranches(t *testing.T) {
@@ -9,7 +9,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -20,6 +22,7 @@ type cobblerIssue struct {
 	Title       string // Issue title
 	Index       int    // cobbler_index from front-matter
 	DependsOn   int    // cobbler_depends_on (-1 = no dependency)
+	BaseBranch  string // cobbler_base_branch; empty means the generation branch
 	Generation  string // cobbler_generation label value
 	Description string // Body text below the front-matter block
 	Labels      []string
@@ -31,13 +34,16 @@ type cobblerFrontMatter struct {
 	Generation string `yaml:"cobbler_generation"`
 	Index      int    `yaml:"cobbler_index"`
 	DependsOn  int    `yaml:"cobbler_depends_on"`
+	BaseBranch string `yaml:"cobbler_base_branch"`
 }
 
 // cobblerLabelReady and cobblerLabelInProgress are the two status labels
 // applied to orchestrator issues during their lifecycle.
+// cobblerLabelNeedsReview parks a task whose worktree tests failed.
 const (
-	cobblerLabelReady      = "cobbler-ready"
-	cobblerLabelInProgress = "cobbler-in-progress"
+	cobblerLabelReady       = "cobbler-ready"
+	cobblerLabelInProgress  = "cobbler-in-progress"
+	cobblerLabelNeedsReview = "cobbler-needs-review"
 )
 
 // cobblerGenLabelPrefix is the prefix for generation-scoped labels.
@@ -49,13 +55,19 @@ func cobblerGenLabel(generation string) string {
 }
 
 // formatIssueFrontMatter formats the YAML front-matter block for an issue body.
-func formatIssueFrontMatter(generation string, index, dependsOn int) string {
-	if dependsOn < 0 {
-		return fmt.Sprintf("---\ncobbler_generation: %s\ncobbler_index: %d\n---\n\n",
-			generation, index)
-	}
-	return fmt.Sprintf("---\ncobbler_generation: %s\ncobbler_index: %d\ncobbler_depends_on: %d\n---\n\n",
-		generation, index, dependsOn)
+// cobbler_depends_on is omitted when dependsOn is negative and
+// cobbler_base_branch is omitted when baseBranch is empty.
+func formatIssueFrontMatter(generation string, index, dependsOn int, baseBranch string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "---\ncobbler_generation: %s\ncobbler_index: %d\n", generation, index)
+	if dependsOn >= 0 {
+		fmt.Fprintf(&b, "cobbler_depends_on: %d\n", dependsOn)
+	}
+	if baseBranch != "" {
+		fmt.Fprintf(&b, "cobbler_base_branch: %s\n", baseBranch)
+	}
+	b.WriteString("---\n\n")
+	return b.String()
 }
 
 // parseIssueFrontMatter splits a GitHub issue body into its YAML front-matter
@@ -84,6 +96,8 @@ func parseIssueFrontMatter(body string) (cobblerFrontMatter, string) {
 			fmt.Sscanf(strings.TrimSpace(strings.TrimPrefix(line, "cobbler_index:")), "%d", &fm.Index)
 		} else if strings.HasPrefix(line, "cobbler_depends_on:") {
 			fmt.Sscanf(strings.TrimSpace(strings.TrimPrefix(line, "cobbler_depends_on:")), "%d", &fm.DependsOn)
+		} else if strings.HasPrefix(line, "cobbler_base_branch:") {
+			fm.BaseBranch = strings.TrimSpace(strings.TrimPrefix(line, "cobbler_base_branch:"))
 		}
 	}
 	return fm, description
@@ -133,6 +147,7 @@ func ensureCobblerLabels(repo string) error {
 	}{
 		{cobblerLabelReady, "0075ca", "Cobbler task ready to be picked by stitch"},
 		{cobblerLabelInProgress, "e4e669", "Cobbler task currently being worked on"},
+		{cobblerLabelNeedsReview, "d93f0b", "Cobbler task whose worktree tests failed"},
 	}
 
 	for _, l := range labels {
@@ -189,12 +204,12 @@ func ensureCobblerGenLabel(repo, generation string) error {
 }
 
 // createCobblerIssue creates a GitHub issue on repo for the given generation
-// and proposedIssue. Returns the GitHub issue number.
+// and ProposedIssue. Returns the GitHub issue number.
 //
 // Note: gh issue create (v2.87.3) does not support --json; it outputs the
 // issue URL (https://github.com/owner/repo/issues/123) on success.
-func createCobblerIssue(repo, generation string, issue proposedIssue) (int, error) {
-	body := formatIssueFrontMatter(generation, issue.Index, issue.Dependency) + issue.Description
+func createCobblerIssue(repo, generation string, issue ProposedIssue) (int, error) {
+	body := formatIssueFrontMatter(generation, issue.Index, issue.Dependency, issue.BaseBranch) + issue.Description
 
 	genLabel := cobblerGenLabel(generation)
 	out, err := exec.Command(binGh, "issue", "create",
@@ -264,6 +279,7 @@ func listOpenCobblerIssues(repo, generation string) ([]cobblerIssue, error) {
 			Title:       r.Title,
 			Index:       fm.Index,
 			DependsOn:   fm.DependsOn,
+			BaseBranch:  fm.BaseBranch,
 			Generation:  fm.Generation,
 			Description: desc,
 			Labels:      labelNames,
@@ -283,17 +299,31 @@ func hasLabel(issue cobblerIssue, label string) bool {
 }
 
 // promoteReadyIssues builds the DAG from open issues and applies
-// cobbler-ready to unblocked issues. Issues whose dependency is still open
-// have cobbler-ready removed.
+// cobbler-ready to unblocked issues. Issues whose dependency is still open,
+// and issues parked with cobbler-needs-review, have cobbler-ready removed.
 func promoteReadyIssues(repo, generation string) error {
 	issues, err := listOpenCobblerIssues(repo, generation)
 	if err != nil {
 		return fmt.Errorf("promoteReadyIssues: %w", err)
 	}
-	if len(issues) == 0 {
-		return nil
+	add, remove := readyLabelChanges(issues)
+	for _, n := range add {
+		if err := addIssueLabel(repo, n, cobblerLabelReady); err != nil {
+			logf("promoteReadyIssues: add ready label to #%d: %v", n, err)
+		}
 	}
+	for _, n := range remove {
+		if err := removeIssueLabel(repo, n, cobblerLabelReady); err != nil {
+			logf("promoteReadyIssues: remove ready label from #%d: %v", n, err)
+		}
+	}
+	return nil
+}
 
+// readyLabelChanges returns the issue numbers that promoteReadyIssues must
+// add cobbler-ready to and remove it from. An issue is eligible when its
+// dependency is not open and it is not labelled cobbler-needs-review.
+func readyLabelChanges(issues []cobblerIssue) (add, remove []int) {
 	// Build set of open cobbler indices.
 	openIndices := make(map[int]bool, len(issues))
 	for _, iss := range issues {
@@ -302,23 +332,21 @@ func promoteReadyIssues(repo, generation string) error {
 
 	for _, iss := range issues {
 		blocked := iss.DependsOn >= 0 && openIndices[iss.DependsOn]
+		eligible := !blocked && !hasLabel(iss, cobblerLabelNeedsReview)
 		currentlyReady := hasLabel(iss, cobblerLabelReady)
 
-		if !blocked && !currentlyReady {
-			if err := addIssueLabel(repo, iss.Number, cobblerLabelReady); err != nil {
-				logf("promoteReadyIssues: add ready label to #%d: %v", iss.Number, err)
-			}
-		} else if blocked && currentlyReady {
-			if err := removeIssueLabel(repo, iss.Number, cobblerLabelReady); err != nil {
-				logf("promoteReadyIssues: remove ready label from #%d: %v", iss.Number, err)
-			}
+		if eligible && !currentlyReady {
+			add = append(add, iss.Number)
+		} else if !eligible && currentlyReady {
+			remove = append(remove, iss.Number)
 		}
 	}
-	return nil
+	return add, remove
 }
 
-// pickReadyIssue promotes ready issues then picks the lowest-numbered
-// cobbler-ready issue, adds cobbler-in-progress, and returns it.
+// pickReadyIssue promotes ready issues then picks the first cobbler-ready
+// issue in stitch order (lowest priority value, then task ID), adds
+// cobbler-in-progress, and returns it.
 func pickReadyIssue(repo, generation string) (cobblerIssue, error) {
 	if err := promoteReadyIssues(repo, generation); err != nil {
 		return cobblerIssue{}, fmt.Errorf("pickReadyIssue promote: %w", err)
@@ -329,17 +357,10 @@ func pickReadyIssue(repo, generation string) (cobblerIssue, error) {
 		return cobblerIssue{}, fmt.Errorf("pickReadyIssue list: %w", err)
 	}
 
-	// Filter to ready issues and sort by number ascending.
-	var ready []cobblerIssue
-	for _, iss := range issues {
-		if hasLabel(iss, cobblerLabelReady) && !hasLabel(iss, cobblerLabelInProgress) {
-			ready = append(ready, iss)
-		}
-	}
+	ready := readyIssues(issues)
 	if len(ready) == 0 {
 		return cobblerIssue{}, fmt.Errorf("no ready issues for generation %s", generation)
 	}
-	sort.Slice(ready, func(i, j int) bool { return ready[i].Number < ready[j].Number })
 
 	picked := ready[0]
 	if err := addIssueLabel(repo, picked.Number, cobblerLabelInProgress); err != nil {
@@ -349,6 +370,26 @@ func pickReadyIssue(repo, generation string) (cobblerIssue, error) {
 	return picked, nil
 }
 
+// readyIssues returns the issues stitch may pick: labelled cobbler-ready
+// and neither in progress nor parked with cobbler-needs-review, sorted
+// into stitch processing order: description priority, then task ID
+// (compareStitchTasks).
+func readyIssues(issues []cobblerIssue) []cobblerIssue {
+	var ready []cobblerIssue
+	for _, iss := range issues {
+		if hasLabel(iss, cobblerLabelReady) && !hasLabel(iss, cobblerLabelInProgress) && !hasLabel(iss, cobblerLabelNeedsReview) {
+			ready = append(ready, iss)
+		}
+	}
+	slices.SortFunc(ready, func(a, b cobblerIssue) int {
+		return compareStitchTasks(
+			stitchTask{id: strconv.Itoa(a.Number), priority: parseTaskPriority(a.Description)},
+			stitchTask{id: strconv.Itoa(b.Number), priority: parseTaskPriority(b.Description)},
+		)
+	})
+	return ready
+}
+
 // closeCobblerIssue closes a GitHub issue and re-runs promoteReadyIssues so
 // any unblocked issues become ready.
 func closeCobblerIssue(repo string, number int, generation string) error {
@@ -433,6 +474,15 @@ func addIssueLabel(repo string, number int, label string) error {
 	).Run()
 }
 
+// commentOnIssue adds a comment with body to a GitHub issue.
+func commentOnIssue(repo string, number int, body string) error {
+	return exec.Command(binGh, "issue", "comment",
+		"--repo", repo,
+		fmt.Sprintf("%d", number),
+		"--body", body,
+	).Run()
+}
+
 // removeIssueLabel removes a label from a GitHub issue via the API.
 func removeIssueLabel(repo string, number int, label string) error {
 	return exec.Command(binGh, "issue", "edit",
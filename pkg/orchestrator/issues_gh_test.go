@@ -6,6 +6,7 @@ package orchestrator
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 )
 
@@ -86,17 +87,20 @@ func TestFormatIssueFrontMatter(t *testing.T) {
 		generation string
 		index      int
 		dependsOn  int
+		baseBranch string
 	}{
-		{"no dep", "gen-2026-02-28-001", 1, -1},
-		{"with dep", "gen-2026-02-28-001", 3, 2},
-		{"dep zero", "gen-abc", 2, 0},
+		{"no dep", "gen-2026-02-28-001", 1, -1, ""},
+		{"with dep", "gen-2026-02-28-001", 3, 2, ""},
+		{"dep zero", "gen-abc", 2, 0, ""},
+		{"base branch", "gen-abc", 4, -1, "release-01.0"},
+		{"dep and base branch", "gen-abc", 5, 4, "release-02.0"},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			desc := "Test description content"
-			body := formatIssueFrontMatter(tc.generation, tc.index, tc.dependsOn) + desc
+			body := formatIssueFrontMatter(tc.generation, tc.index, tc.dependsOn, tc.baseBranch) + desc
 			fm, parsedDesc := parseIssueFrontMatter(body)
 
 			if fm.Generation != tc.generation {
@@ -108,6 +112,9 @@ func TestFormatIssueFrontMatter(t *testing.T) {
 			if fm.DependsOn != tc.dependsOn {
 				t.Errorf("DependsOn round-trip: got %d want %d", fm.DependsOn, tc.dependsOn)
 			}
+			if fm.BaseBranch != tc.baseBranch {
+				t.Errorf("BaseBranch round-trip: got %q want %q", fm.BaseBranch, tc.baseBranch)
+			}
 			if parsedDesc != desc {
 				t.Errorf("Description round-trip: got %q want %q", parsedDesc, desc)
 			}
@@ -222,6 +229,50 @@ func TestDAGPromotionDepClosed(t *testing.T) {
 	}
 }
 
+func TestReadyLabelChanges_SkipsNeedsReview(t *testing.T) {
+	t.Parallel()
+	issues := []cobblerIssue{
+		{Number: 10, Index: 1, DependsOn: -1},
+		{Number: 11, Index: 2, DependsOn: -1, Labels: []string{cobblerLabelNeedsReview}},
+		{Number: 12, Index: 3, DependsOn: -1, Labels: []string{cobblerLabelNeedsReview, cobblerLabelReady}},
+	}
+	add, remove := readyLabelChanges(issues)
+	if !slices.Equal(add, []int{10}) {
+		t.Errorf("add = %v, want [10]: a parked issue must not be re-promoted", add)
+	}
+	if !slices.Equal(remove, []int{12}) {
+		t.Errorf("remove = %v, want [12]", remove)
+	}
+}
+
+func TestReadyIssues_SkipsNeedsReview(t *testing.T) {
+	t.Parallel()
+	issues := []cobblerIssue{
+		{Number: 10, Labels: []string{cobblerLabelReady, cobblerLabelNeedsReview}},
+		{Number: 11, Labels: []string{cobblerLabelReady}},
+	}
+	got := readyIssues(issues)
+	if len(got) != 1 || got[0].Number != 11 {
+		t.Errorf("readyIssues = %+v, want only #11", got)
+	}
+}
+
+func TestReadyIssues_NumericTieBreak(t *testing.T) {
+	t.Parallel()
+	issues := []cobblerIssue{
+		{Number: 10, Labels: []string{cobblerLabelReady}},
+		{Number: 9, Labels: []string{cobblerLabelReady}},
+		{Number: 11, Labels: []string{cobblerLabelReady}, Description: "priority: 1\n"},
+	}
+	var got []int
+	for _, iss := range readyIssues(issues) {
+		got = append(got, iss.Number)
+	}
+	if want := []int{11, 9, 10}; !slices.Equal(got, want) {
+		t.Errorf("readyIssues order = %v, want %v", got, want)
+	}
+}
+
 // writeFileForTest is a test helper that writes content to path.
 func writeFileForTest(path, content string) error {
 	return os.WriteFile(path, []byte(content), 0o644)
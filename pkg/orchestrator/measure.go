@@ -4,11 +4,22 @@
 package orchestrator
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"os/signal"
+	"path"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -42,6 +53,63 @@ func (o *Orchestrator) MeasurePrompt() error {
 	return nil
 }
 
+// MeasureDryRun runs one measure iteration end to end without touching
+// GitHub: it builds the prompt, invokes Claude, extracts the proposed
+// issues, and applies the same filtering and validation as import, then
+// prints what would be created. Combined with Claude.Fixture (or
+// COBBLER_CLAUDE_FIXTURE) it spends no tokens and is fully deterministic.
+// Returns an error when EnforceMeasureValidation is set and validation
+// fails, matching import.
+func (o *Orchestrator) MeasureDryRun() error {
+	return o.measureDryRun(os.Stdout)
+}
+
+// measureDryRun writes the MeasureDryRun report to w.
+func (o *Orchestrator) measureDryRun(w io.Writer) error {
+	if err := o.checkClaudeOrFixture(); err != nil {
+		return err
+	}
+	prompt, err := o.buildMeasurePrompt(o.cfg.Cobbler.UserPrompt, "", 1)
+	if err != nil {
+		return err
+	}
+	if err := o.checkMeasureTokenBudget(prompt); err != nil {
+		return err
+	}
+	tokens, err := o.runClaudeOrFixture(prompt, "", o.cfg.Silence(), "--max-turns", "1")
+	if err != nil {
+		return fmt.Errorf("running Claude: %w", err)
+	}
+	yamlContent, err := extractYAMLBlock(extractClaudeText(tokens.RawOutput, o.cfg.Claude.OutputFormat))
+	if err != nil {
+		return err
+	}
+	issues, skipped, vr, err := o.prepareProposedIssues(yamlContent)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Dry run: would create %d issue(s)\n", len(issues))
+	for _, issue := range issues {
+		fmt.Fprintf(w, "  [%d] %s (dep=%d, effort=%s)\n", issue.Index, issue.Title, issue.Dependency, issue.Effort)
+	}
+	writeCriticalPath(w, issues)
+	for _, sk := range skipped {
+		fmt.Fprintf(w, "  skip [%d] %s: %s\n", sk.Index, sk.Title, sk.Reason)
+	}
+	for _, msg := range vr.Warnings {
+		fmt.Fprintf(w, "warning: %s\n", msg)
+	}
+	for _, msg := range vr.Errors {
+		fmt.Fprintf(w, "error: %s\n", msg)
+	}
+	if vr.HasErrors() && o.cfg.Cobbler.EnforceMeasureValidation {
+		return fmt.Errorf("measure validation failed (%d error(s)): %s",
+			len(vr.Errors), strings.Join(vr.Errors, "; "))
+	}
+	return nil
+}
+
 // RunMeasure runs the measure workflow using Config settings.
 // repo is the GitHub owner/repo where issues are created.
 // It uses an iterative strategy: Claude is called once per issue with limit=1,
@@ -50,14 +118,25 @@ func (o *Orchestrator) MeasurePrompt() error {
 // avoid duplicates. This avoids the super-linear thinking-time scaling observed
 // when requesting multiple issues in a single call (see eng04-measure-scaling).
 func (o *Orchestrator) RunMeasure() error {
+	return o.runMeasure(false)
+}
+
+// MeasureForce runs RunMeasure without the RequireMeasureContext check.
+func (o *Orchestrator) MeasureForce() error {
+	return o.runMeasure(true)
+}
+
+// runMeasure implements RunMeasure. force skips checkMeasureContext.
+func (o *Orchestrator) runMeasure(force bool) error {
 	setPhase("measure")
 	defer clearPhase()
 	measureStart := time.Now()
+	// runID groups the history records of every iteration of this run.
+	runID := measureStart.Format("2006-01-02-15-04-05")
 
 	// Start orchestrator log capture.
 	if hdir := o.historyDir(); hdir != "" {
-		logPath := filepath.Join(hdir,
-			measureStart.Format("2006-01-02-15-04-05")+"-measure-orchestrator.log")
+		logPath := filepath.Join(hdir, runID+"-measure-orchestrator.log")
 		if err := openLogSink(logPath); err != nil {
 			logf("warning: could not open orchestrator log: %v", err)
 		} else {
@@ -68,7 +147,12 @@ func (o *Orchestrator) RunMeasure() error {
 	logf("starting (iterative, %d issue(s) requested)", o.cfg.Cobbler.MaxMeasureIssues)
 	o.logConfig("measure")
 
-	if err := o.checkClaude(); err != nil {
+	if o.cfg.Cobbler.RequireMeasureContext && !force {
+		if err := o.checkMeasureContext(); err != nil {
+			return err
+		}
+	}
+	if err := o.checkClaudeOrFixture(); err != nil {
 		return err
 	}
 
@@ -116,7 +200,7 @@ func (o *Orchestrator) RunMeasure() error {
 	// Schema errors and constitution drift are bugs in the target project's
 	// files; filing them as GitHub issues prevents Claude from proposing them
 	// as measure tasks, which would fail validation and block the cycle.
-	if analysis := loadAnalysisDoc(o.cfg.Cobbler.Dir); analysis != nil && len(analysis.Defects) > 0 {
+	if analysis := loadAnalysisDoc(o.cfg.Cobbler.Dir, o.cfg.EffectiveAnalysisFileName()); analysis != nil && len(analysis.Defects) > 0 {
 		if targetRepo := resolveTargetRepo(o.cfg); targetRepo != "" {
 			logf("measure: filing %d defect(s) as bug issues in %s", len(analysis.Defects), targetRepo)
 			fileTargetRepoDefects(targetRepo, analysis.Defects)
@@ -169,6 +253,7 @@ func (o *Orchestrator) RunMeasure() error {
 
 		var createdIDs []string
 		var lastOutputFile string
+		var lastHistoryTS string
 
 		// Attempt loop: try Claude + import, retrying on validation failure.
 		for attempt := 0; attempt <= maxRetries; attempt++ {
@@ -186,13 +271,17 @@ func (o *Orchestrator) RunMeasure() error {
 				return promptErr
 			}
 			logf("iteration %d prompt built, length=%d bytes", i+1, len(prompt))
+			if err := o.checkMeasureTokenBudget(prompt); err != nil {
+				return err
+			}
 
 			// Save prompt BEFORE calling Claude so it's on disk even if Claude times out.
 			historyTS := time.Now().Format("2006-01-02-15-04-05")
+			lastHistoryTS = historyTS
 			o.saveHistoryPrompt(historyTS, "measure", prompt)
 
 			iterStart := time.Now()
-			tokens, err := o.runClaude(prompt, "", o.cfg.Silence(), "--max-turns", "1")
+			tokens, err := o.runClaudeOrFixture(prompt, "", o.cfg.Silence(), "--max-turns", "1")
 			iterDuration := time.Since(iterStart)
 
 			totalTokens.InputTokens += tokens.InputTokens
@@ -208,6 +297,7 @@ func (o *Orchestrator) RunMeasure() error {
 				o.saveHistoryLog(historyTS, "measure", tokens.RawOutput)
 				o.saveHistoryStats(historyTS, "measure", HistoryStats{
 					Caller:    "measure",
+					RunID:     runID,
 					Status:    "failed",
 					Error:     fmt.Sprintf("claude failure (iteration %d/%d): %v", i+1, totalIssues, err),
 					StartedAt: iterStart.UTC().Format(time.RFC3339),
@@ -224,8 +314,12 @@ func (o *Orchestrator) RunMeasure() error {
 
 			// Save remaining history artifacts (log, issues, stats) after Claude.
 			o.saveHistory(historyTS, tokens.RawOutput, outputFile)
+			if o.historyDir() != "" {
+				o.saveLOCSnapshot()
+			}
 			o.saveHistoryStats(historyTS, "measure", HistoryStats{
 				Caller:    "measure",
+				RunID:     runID,
 				Status:    "success",
 				StartedAt: iterStart.UTC().Format(time.RFC3339),
 				Duration:  iterDuration.Round(time.Second).String(),
@@ -237,7 +331,7 @@ func (o *Orchestrator) RunMeasure() error {
 			})
 
 			// Extract YAML from Claude's text output and write to file.
-			textOutput := extractTextFromStreamJSON(tokens.RawOutput)
+			textOutput := extractClaudeText(tokens.RawOutput, o.cfg.Claude.OutputFormat)
 			yamlContent, extractErr := extractYAMLBlock(textOutput)
 			if extractErr != nil {
 				logf("iteration %d YAML extraction failed: %v", i+1, extractErr)
@@ -254,7 +348,15 @@ func (o *Orchestrator) RunMeasure() error {
 			logf("iteration %d extracted YAML, size=%d bytes", i+1, len(yamlContent))
 
 			var importErr error
-			createdIDs, importErr = o.importIssues(outputFile, repo, generation)
+			var res importResult
+			res, importErr = o.importIssues(outputFile, repo, generation)
+			createdIDs = res.Created
+			if errors.Is(importErr, context.Canceled) {
+				// Ctrl-C during the rate-limit wait: keep what was created
+				// and stop measuring instead of retrying.
+				o.saveHistoryIssueIndex(lastHistoryTS, createdIDs)
+				return importErr
+			}
 			if importErr != nil {
 				logf("iteration %d import failed: %v", i+1, importErr)
 				if attempt < maxRetries {
@@ -264,7 +366,8 @@ func (o *Orchestrator) RunMeasure() error {
 				// Retries exhausted: accept with warning (R5).
 				logf("iteration %d retries exhausted, accepting last result with warnings", i+1)
 				var forceErr error
-				createdIDs, forceErr = o.importIssuesForce(outputFile, repo, generation)
+				res, forceErr = o.importIssuesForce(outputFile, repo, generation)
+				createdIDs = res.Created
 				if forceErr != nil {
 					logf("iteration %d force import failed: %v", i+1, forceErr)
 				}
@@ -274,7 +377,8 @@ func (o *Orchestrator) RunMeasure() error {
 
 		logf("iteration %d imported %d issue(s)", i+1, len(createdIDs))
 
-		// Record invocation metrics on each created issue.
+		// Map the created issues to this iteration's history timestamp.
+		o.saveHistoryIssueIndex(lastHistoryTS, createdIDs)
 
 		allCreatedIDs = append(allCreatedIDs, createdIDs...)
 
@@ -287,6 +391,9 @@ func (o *Orchestrator) RunMeasure() error {
 
 	logf("completed %d iteration(s), %d issue(s) created in %s",
 		totalIssues, len(allCreatedIDs), time.Since(measureStart).Round(time.Second))
+	if o.cfg.Cobbler.OpenCreatedIssues && !o.cfg.Cobbler.QuietImport {
+		o.showCreatedIssues(os.Stdout, remoteHost(repoRoot), repo, allCreatedIDs)
+	}
 	return nil
 }
 
@@ -299,6 +406,32 @@ func truncateSHA(sha string) string {
 	return sha
 }
 
+// estimatePromptTokens returns the rough token estimate of a prompt used
+// by TokenStats and the MaxMeasureTokens check: one token per 4 bytes.
+func estimatePromptTokens(prompt string) int {
+	return len(prompt) / 4
+}
+
+// checkMeasureTokenBudget compares the estimated size of a measure prompt
+// with Cobbler.MaxMeasureTokens. It returns an error when the prompt is
+// over the limit, or only logs a warning when MaxMeasureTokensWarnOnly is
+// set. A zero limit disables the check.
+func (o *Orchestrator) checkMeasureTokenBudget(prompt string) error {
+	limit := o.cfg.Cobbler.MaxMeasureTokens
+	if limit <= 0 {
+		return nil
+	}
+	est := estimatePromptTokens(prompt)
+	if est <= limit {
+		return nil
+	}
+	if o.cfg.Cobbler.MaxMeasureTokensWarnOnly {
+		logf("warning: measure prompt is ~%d tokens, over max_measure_tokens %d; proceeding (warn-only)", est, limit)
+		return nil
+	}
+	return fmt.Errorf("measure prompt is ~%d tokens, over max_measure_tokens %d", est, limit)
+}
+
 func (o *Orchestrator) buildMeasurePrompt(userInput, existingIssues string, limit int) (string, error) {
 	tmpl, err := parsePromptTemplate(orDefault(o.cfg.Cobbler.MeasurePrompt, defaultMeasurePrompt))
 	if err != nil {
@@ -319,7 +452,7 @@ func (o *Orchestrator) buildMeasurePrompt(userInput, existingIssues string, limi
 		logf("buildMeasurePrompt: no phase context file, using config defaults")
 	}
 
-	projectCtx, ctxErr := buildProjectContext(existingIssues, o.cfg.Project, phaseCtx)
+	projectCtx, ctxErr := buildProjectContext(existingIssues, o.cfg.Project, phaseCtx, o.cfg.EffectiveAnalysisFileName(), o.cfg.EffectiveMaxSpecFileSize())
 	if ctxErr != nil {
 		logf("buildMeasurePrompt: buildProjectContext error: %v", ctxErr)
 		projectCtx = &ProjectContext{}
@@ -330,6 +463,7 @@ func (o *Orchestrator) buildMeasurePrompt(userInput, existingIssues string, limi
 		"lines_min":        fmt.Sprintf("%d", o.cfg.Cobbler.EstimatedLinesMin),
 		"lines_max":        fmt.Sprintf("%d", o.cfg.Cobbler.EstimatedLinesMax),
 		"max_requirements": fmt.Sprintf("%d", o.cfg.Cobbler.MaxRequirementsPerTask),
+		"files_per_task":   filesPerTask(o.cfg.Cobbler.MaxFilesPerTask),
 	}
 
 	doc := MeasurePromptDoc{
@@ -340,14 +474,24 @@ func (o *Orchestrator) buildMeasurePrompt(userInput, existingIssues string, limi
 		Task:                    substitutePlaceholders(tmpl.Task, placeholders),
 		Constraints:             substitutePlaceholders(tmpl.Constraints, placeholders),
 		OutputFormat:            substitutePlaceholders(tmpl.OutputFormat, placeholders),
-		GoldenExample:           o.cfg.Cobbler.GoldenExample,
+		GoldenExample:           goldenExampleText(o.cfg.Cobbler.GoldenExample, o.cfg.Cobbler.GoldenExamples),
 		AdditionalContext:       userInput,
+		Footer:                  o.cfg.Cobbler.MeasurePromptFooter,
 	}
 
+	for _, w := range validateProjectReleases(o.cfg, o.cfg.EffectiveRoadmapFile()) {
+		logf("config warning: %s", w)
+	}
 	// Enforce releases scope: the roadmap is not filtered by release, so
 	// without an explicit constraint the agent may propose tasks from adjacent
 	// releases after exhausting the configured ones.
 	doc.Constraints += measureReleasesConstraint(o.cfg.Project.Releases, o.cfg.Project.Release)
+	// Appended rather than templated so custom measure prompts state the
+	// cap that validation enforces.
+	doc.Constraints += maxFilesConstraint(o.cfg.Cobbler.MaxFilesPerTask)
+	if o.cfg.Cobbler.GuardImplementedUseCases {
+		doc.Constraints += implementedUseCasesConstraint(o.implementedUseCases())
+	}
 
 	out, err := yaml.Marshal(&doc)
 	if err != nil {
@@ -359,6 +503,42 @@ func (o *Orchestrator) buildMeasurePrompt(userInput, existingIssues string, limi
 	return string(out), nil
 }
 
+// checkMeasureContext returns an error when the measure project context
+// has no roadmap, no specs, and no source code. Claude tends to invent
+// work from such a context instead of planning from the project.
+func (o *Orchestrator) checkMeasureContext() error {
+	ctx, err := buildProjectContext("", o.cfg.Project, nil, o.cfg.EffectiveAnalysisFileName(), o.cfg.EffectiveMaxSpecFileSize())
+	if err != nil {
+		return fmt.Errorf("building measure context: %w", err)
+	}
+	if ctx.Roadmap == nil && ctx.Specs == nil && len(ctx.SourceCode) == 0 {
+		return fmt.Errorf("measure project context is empty (no roadmap, specs, or source code); run from the project root or use cobbler:measureForce")
+	}
+	return nil
+}
+
+// goldenExampleText returns the golden example text for the measure
+// prompt. When byType is non-empty it wins over single: each example is
+// emitted under a "## Golden Example: <Type>" heading, ordered by
+// deliverable type. Otherwise single is returned unchanged.
+func goldenExampleText(single string, byType map[string]string) string {
+	if len(byType) == 0 {
+		return single
+	}
+	var b strings.Builder
+	for _, kind := range slices.Sorted(maps.Keys(byType)) {
+		if b.Len() > 0 {
+			b.WriteString("\n\n")
+		}
+		label := kind
+		if label != "" {
+			label = strings.ToUpper(label[:1]) + label[1:]
+		}
+		fmt.Fprintf(&b, "## Golden Example: %s\n\n%s", label, strings.TrimSpace(byType[kind]))
+	}
+	return b.String()
+}
+
 // measureReleasesConstraint returns a hard constraint string to append to the
 // measure prompt when a release scope is configured. Returns "" when no scope
 // is set. Releases (list) takes precedence over Release (single string).
@@ -378,63 +558,243 @@ func measureReleasesConstraint(releases []string, release string) string {
 	return ""
 }
 
-type proposedIssue struct {
-	Index       int    `yaml:"index"`
-	Title       string `yaml:"title"`
-	Description string `yaml:"description"`
-	Dependency  int    `yaml:"dependency"`
+// maxFilesConstraint returns a hard constraint string to append to the
+// measure prompt stating the per-task file cap, or "" when maxFiles is 0
+// (no cap).
+func maxFilesConstraint(maxFiles int) string {
+	if maxFiles <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n\nFile limit: Each task may list at most %d files; split any task that would touch more.", maxFiles)
+}
+
+// Default per-task file range stated in the measure prompt.
+const (
+	defaultFilesPerTaskMin = 5
+	defaultFilesPerTaskMax = 7
+)
+
+// filesPerTask returns the {files_per_task} text for the measure prompt:
+// the default 5-7 range, capped at maxFiles when it is set and lower.
+func filesPerTask(maxFiles int) string {
+	hi := defaultFilesPerTaskMax
+	if maxFiles > 0 && maxFiles < hi {
+		hi = maxFiles
+	}
+	if hi <= defaultFilesPerTaskMin {
+		return fmt.Sprintf("at most %d", hi)
+	}
+	return fmt.Sprintf("%d-%d", defaultFilesPerTaskMin, hi)
+}
+
+// validateProjectReleases checks the configured release scope against
+// the release versions in the roadmap at roadmapPath with invalidReleases
+// (Analyze Check 0) and returns a warning for each release not found.
+// Returns nil when the roadmap cannot be loaded.
+func validateProjectReleases(cfg Config, roadmapPath string) []string {
+	if len(cfg.Project.Releases) == 0 && cfg.Project.Release == "" {
+		return nil
+	}
+	roadmap := loadSpecYAML[RoadmapDoc](roadmapPath, cfg.EffectiveMaxSpecFileSize())
+	if roadmap == nil {
+		return nil
+	}
+	known := make(map[string]bool, len(roadmap.Releases))
+	for _, rel := range roadmap.Releases {
+		known[rel.Version] = true
+	}
+	return invalidReleases(cfg.Project, known, roadmapPath)
+}
+
+// implementedUseCasesConstraint returns a constraint string telling the
+// agent not to plan work for the given use cases. Returns "" when ucIDs is
+// empty.
+func implementedUseCasesConstraint(ucIDs []string) string {
+	if len(ucIDs) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(
+		"\n\nImplemented use cases: [%s] are already implemented. Do not plan work for these use cases.",
+		strings.Join(ucIDs, ", "),
+	)
+}
+
+// ProposedIssue is a task proposed by the measure phase, as parsed from
+// Claude's YAML output and seen by custom validation rules.
+type ProposedIssue struct {
+	Index       int    `yaml:"index" json:"index"`
+	Title       string `yaml:"title" json:"title"`
+	Description string `yaml:"description" json:"description"`
+	Dependency  int    `yaml:"dependency" json:"dependency"`
+
+	// Effort is the estimated effort for the issue: a label from
+	// Cobbler.EffortValues (e.g. "S", "M", "L") or an hour estimate.
+	// Import fills in Cobbler.DefaultEffort when it is empty.
+	Effort string `yaml:"effort,omitempty" json:"effort,omitempty"`
+
+	// BaseBranch optionally names the branch the stitch worktree for this
+	// issue is created from (e.g. a release branch). Empty means the
+	// current generation branch.
+	BaseBranch string `yaml:"base_branch,omitempty" json:"base_branch,omitempty"`
+
+	// Skip excludes the issue from import; SkipReason records why. Skipped
+	// issues are not validated or created and are reported in
+	// importResult.Skipped.
+	Skip       bool   `yaml:"skip,omitempty" json:"skip,omitempty"`
+	SkipReason string `yaml:"skip_reason,omitempty" json:"skip_reason,omitempty"`
+
+	// Parsed holds the unmarshaled Description when it is valid YAML.
+	// It is populated only for MeasureIssueFilter and never serialized.
+	Parsed *issueDescription `yaml:"-" json:"-"`
+}
+
+// filterProposedIssues applies filter to each issue and returns those for
+// which it returns true. Each issue's Parsed field is populated before the
+// filter is called. A nil filter returns issues unchanged.
+func filterProposedIssues(issues []ProposedIssue, filter func(ProposedIssue) bool) []ProposedIssue {
+	if filter == nil {
+		return issues
+	}
+	var kept []ProposedIssue
+	for _, issue := range issues {
+		var desc issueDescription
+		if err := yaml.Unmarshal([]byte(issue.Description), &desc); err == nil {
+			issue.Parsed = &desc
+		}
+		if !filter(issue) {
+			logf("importIssues: filter dropped [%d] %q", issue.Index, issue.Title)
+			continue
+		}
+		kept = append(kept, issue)
+	}
+	return kept
+}
+
+// createIssueFn creates a single issue during import. Tests replace it to
+// avoid calling gh.
+var createIssueFn = createCobblerIssue
+
+// sleepFn waits for d or until ctx is done, whichever comes first. Tests
+// replace it to observe rate-limit waits without a real clock.
+var sleepFn = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
-func (o *Orchestrator) importIssues(yamlFile, repo, generation string) ([]string, error) {
+// importResult reports the outcome of importing a measure output file.
+type importResult struct {
+	Created []string       // issue numbers created
+	Skipped []skippedIssue // issues marked skip: true
+}
+
+// skippedIssue identifies a proposed issue excluded by its skip marker.
+type skippedIssue struct {
+	Index  int
+	Title  string
+	Reason string
+}
+
+// splitSkippedIssues separates the issues marked skip: true from the rest.
+func splitSkippedIssues(issues []ProposedIssue) ([]ProposedIssue, []skippedIssue) {
+	var kept []ProposedIssue
+	var skipped []skippedIssue
+	for _, issue := range issues {
+		if !issue.Skip {
+			kept = append(kept, issue)
+			continue
+		}
+		reason := orDefault(issue.SkipReason, "no reason given")
+		logf("importIssues: skipping [%d] %q: %s", issue.Index, issue.Title, reason)
+		skipped = append(skipped, skippedIssue{Index: issue.Index, Title: issue.Title, Reason: reason})
+	}
+	return kept, skipped
+}
+
+// clearSkippedDependencies resets the dependency of each kept issue that
+// depends on a skipped one to -1 and returns a warning for each. Without
+// this the dependency names an issue that is never created, and
+// readyLabelChanges promotes the dependent issue to ready at once.
+func clearSkippedDependencies(kept []ProposedIssue, skipped []skippedIssue) []string {
+	skippedIdx := make(map[int]bool, len(skipped))
+	for _, sk := range skipped {
+		skippedIdx[sk.Index] = true
+	}
+	var warnings []string
+	for i := range kept {
+		if dep := kept[i].Dependency; dep >= 0 && skippedIdx[dep] {
+			msg := fmt.Sprintf("[%d] %q: depends on skipped issue %d; dependency cleared", kept[i].Index, kept[i].Title, dep)
+			logf("importIssues: %s", msg)
+			warnings = append(warnings, msg)
+			kept[i].Dependency = -1
+		}
+	}
+	return warnings
+}
+
+func (o *Orchestrator) importIssues(yamlFile, repo, generation string) (importResult, error) {
 	return o.importIssuesImpl(yamlFile, repo, generation, false)
 }
 
 // importIssuesForce imports issues bypassing enforcing validation. Used when
 // retries are exhausted to accept the last result with warnings (R5).
-func (o *Orchestrator) importIssuesForce(yamlFile, repo, generation string) ([]string, error) {
+func (o *Orchestrator) importIssuesForce(yamlFile, repo, generation string) (importResult, error) {
 	return o.importIssuesImpl(yamlFile, repo, generation, true)
 }
 
-func (o *Orchestrator) importIssuesImpl(yamlFile, repo, generation string, skipEnforcement bool) ([]string, error) {
+func (o *Orchestrator) importIssuesImpl(yamlFile, repo, generation string, skipEnforcement bool) (importResult, error) {
 	logf("importIssues: reading %s", yamlFile)
 	data, err := os.ReadFile(yamlFile)
 	if err != nil {
-		return nil, fmt.Errorf("reading YAML file: %w", err)
+		return importResult{}, fmt.Errorf("reading YAML file: %w", err)
 	}
 	logf("importIssues: read %d bytes", len(data))
 
-	var issues []proposedIssue
-	if err := yaml.Unmarshal(data, &issues); err != nil {
-		logf("importIssues: YAML parse error: %v", err)
-		return nil, fmt.Errorf("parsing YAML: %w", err)
-	}
-
-	logf("importIssues: parsed %d proposed issue(s)", len(issues))
-	for i, issue := range issues {
-		logf("importIssues: [%d] title=%q dep=%d", i, issue.Title, issue.Dependency)
-	}
-
-	// Validate proposed issues against P9/P7 rules.
-	vr := validateMeasureOutput(issues, o.cfg.Cobbler.MaxRequirementsPerTask)
-	if len(vr.Warnings) > 0 {
-		logf("importIssues: %d warning(s)", len(vr.Warnings))
+	issues, skipped, vr, err := o.prepareProposedIssues(data)
+	if err != nil {
+		return importResult{}, err
 	}
 	if vr.HasErrors() && o.cfg.Cobbler.EnforceMeasureValidation && !skipEnforcement {
-		return nil, fmt.Errorf("measure validation failed (%d error(s)): %s",
+		return importResult{}, fmt.Errorf("measure validation failed (%d error(s)): %s",
 			len(vr.Errors), strings.Join(vr.Errors, "; "))
 	}
 
 	// Create all issues on GitHub. Dependencies are encoded in the front-matter;
 	// promoteReadyIssues (called by pickReadyIssue) resolves the DAG at pick time.
+	// When a creation delay is configured, wait between issues (never after
+	// the last one). Ctrl-C cancels the wait and stops the import early.
+	delay := o.cfg.IssueCreateDelay()
+	ctx := context.Background()
+	if delay > 0 {
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+	}
+
 	var ids []string
-	for _, issue := range issues {
+	var created []ProposedIssue
+	var interruptErr error
+	for i, issue := range issues {
+		if i > 0 && delay > 0 {
+			if err := sleepFn(ctx, delay); err != nil {
+				logf("importIssues: rate-limit wait interrupted after %d issue(s): %v", i, err)
+				interruptErr = fmt.Errorf("import interrupted after %d issue(s): %w", len(ids), err)
+				break
+			}
+		}
 		logf("importIssues: creating task %d: %s (dep=%d)", issue.Index, issue.Title, issue.Dependency)
-		ghNum, err := createCobblerIssue(repo, generation, issue)
+		ghNum, err := createIssueFn(repo, generation, issue)
 		if err != nil {
 			logf("importIssues: createCobblerIssue failed for %q: %v", issue.Title, err)
 			continue
 		}
 		ids = append(ids, fmt.Sprintf("%d", ghNum))
+		created = append(created, issue)
 	}
 
 	if len(ids) > 0 {
@@ -442,22 +802,117 @@ func (o *Orchestrator) importIssuesImpl(yamlFile, repo, generation string, skipE
 			logf("importIssues: promoteReadyIssues warning: %v", err)
 		}
 	}
-	logf("importIssues: %d of %d issue(s) imported", len(ids), len(issues))
+	logf("importIssues: %d of %d issue(s) imported, %d skipped", len(ids), len(issues), len(skipped))
 
-	// Append new issues to the persistent measure list.
-	appendMeasureLog(o.cfg.Cobbler.Dir, issues)
+	// Append the issues actually created to the persistent measure list.
+	appendMeasureLog(o.measureLogPath(), created)
 
-	return ids, nil
+	res := importResult{Created: ids, Skipped: skipped}
+	if !o.cfg.Cobbler.QuietImport {
+		writeImportSummary(os.Stdout, issues, vr, res)
+	}
+	return res, interruptErr
+}
+
+// writeImportSummary writes the import summary to w: how many issues
+// were validated, how many of them drew warnings of their own, how many
+// cross-issue warnings there were (such as P13 duplicate file paths and
+// custom rule warnings, which name no single issue), how many issues were
+// created and skipped, followed by every validation warning.
+func writeImportSummary(w io.Writer, issues []ProposedIssue, vr ValidationResult, res importResult) {
+	if len(issues) == 0 && len(res.Skipped) == 0 {
+		fmt.Fprintln(w, "Import summary: 0 issues")
+		return
+	}
+	prefixes := make([]string, len(issues))
+	for i, issue := range issues {
+		prefixes[i] = fmt.Sprintf("[%d] ", issue.Index)
+	}
+	warned := map[int]bool{} // positions in issues with a warning of their own
+	crossIssue := 0
+	for _, msg := range vr.Warnings {
+		i := slices.IndexFunc(prefixes, func(prefix string) bool { return strings.HasPrefix(msg, prefix) })
+		if i < 0 {
+			crossIssue++
+			continue
+		}
+		warned[i] = true
+	}
+	fmt.Fprintf(w, "Import summary: %d issue(s) validated, %d with warnings, %d cross-issue warning(s), %d created, %d skipped\n",
+		len(issues), len(warned), crossIssue, len(res.Created), len(res.Skipped))
+	for _, msg := range vr.Warnings {
+		fmt.Fprintf(w, "  warning: %s\n", msg)
+	}
+}
+
+// prepareProposedIssues parses measure output YAML, applies the issue
+// filter, sets aside issues marked skip: true (clearing dependencies on
+// them), applies the default effort, and validates the remaining issues
+// against the P9/P7 rules. Import and MeasureDryRun share it so both see
+// the same issues.
+func (o *Orchestrator) prepareProposedIssues(data []byte) ([]ProposedIssue, []skippedIssue, ValidationResult, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		logf("importIssues: YAML parse error: %v", err)
+		return nil, nil, ValidationResult{}, fmt.Errorf("parsing YAML: %w", err)
+	}
+	var issues []ProposedIssue
+	if len(doc.Content) > 0 {
+		// Catch a single issue written without the list dash before
+		// decoding, where it would surface as a confusing type error.
+		if top := doc.Content[0]; top.Kind != yaml.SequenceNode {
+			logf("importIssues: top-level YAML is a %s, not a list", yamlKindName(top.Kind))
+			return nil, nil, ValidationResult{}, fmt.Errorf("expected a list of issues, got %s", yamlKindName(top.Kind))
+		}
+		if err := doc.Decode(&issues); err != nil {
+			logf("importIssues: YAML parse error: %v", err)
+			return nil, nil, ValidationResult{}, fmt.Errorf("parsing YAML: %w", err)
+		}
+	}
+
+	logf("importIssues: parsed %d proposed issue(s)", len(issues))
+	for i, issue := range issues {
+		logf("importIssues: [%d] title=%q dep=%d", i, issue.Title, issue.Dependency)
+	}
+
+	issues = filterProposedIssues(issues, o.cfg.Cobbler.MeasureIssueFilter)
+	issues, skipped := splitSkippedIssues(issues)
+	depWarnings := clearSkippedDependencies(issues, skipped)
+	applyDefaultEffort(issues, o.cfg.Cobbler.DefaultEffort)
+
+	// Validate proposed issues against P9/P7 rules.
+	vr := validateMeasureOutput(issues, o.measureRules())
+	vr.Warnings = append(depWarnings, vr.Warnings...)
+	if len(vr.Warnings) > 0 {
+		logf("importIssues: %d warning(s)", len(vr.Warnings))
+	}
+	return issues, skipped, vr, nil
+}
+
+// yamlKindName returns a human-readable name for a YAML node kind.
+func yamlKindName(k yaml.Kind) string {
+	switch k {
+	case yaml.SequenceNode:
+		return "sequence"
+	case yaml.MappingNode:
+		return "mapping"
+	case yaml.ScalarNode:
+		return "scalar"
+	case yaml.AliasNode:
+		return "alias"
+	default:
+		return "document"
+	}
 }
 
 // issueDescription is the subset of fields parsed from an issue description
 // YAML for advisory validation.
 type issueDescription struct {
-	DeliverableType    string              `yaml:"deliverable_type"`
-	Files              []issueDescFile     `yaml:"files"`
-	Requirements       []issueDescItem     `yaml:"requirements"`
-	AcceptanceCriteria []issueDescItem     `yaml:"acceptance_criteria"`
-	DesignDecisions    []issueDescItem     `yaml:"design_decisions"`
+	DeliverableType    string          `yaml:"deliverable_type"`
+	Files              []issueDescFile `yaml:"files"`
+	Requirements       []issueDescItem `yaml:"requirements"`
+	AcceptanceCriteria []issueDescItem `yaml:"acceptance_criteria"`
+	DesignDecisions    []issueDescItem `yaml:"design_decisions"`
 }
 
 type issueDescFile struct {
@@ -469,111 +924,599 @@ type issueDescItem struct {
 	Text string `yaml:"text"`
 }
 
-// validationResult holds the outcome of measure output validation.
-type validationResult struct {
+// ValidationResult holds the outcome of measure output validation, or
+// of a single validation rule. Errors block import when
+// EnforceMeasureValidation is set; Warnings are advisory.
+type ValidationResult struct {
 	Warnings []string // advisory issues (logged but do not block import)
 	Errors   []string // blocking issues (cause rejection in enforcing mode)
 }
 
 // HasErrors returns true if the validation found blocking issues.
-func (v validationResult) HasErrors() bool {
+func (v ValidationResult) HasErrors() bool {
 	return len(v.Errors) > 0
 }
 
+// ValidationRule is a custom measure validation function. It receives
+// every proposed issue of one measure output and returns its findings,
+// which are merged with those of the built-in rules.
+type ValidationRule func(issues []ProposedIssue) ValidationResult
+
+// RegisterValidationRule adds a custom rule that validateMeasureOutput
+// runs after the built-in rules. Rules run in registration order.
+// Register rules before starting measure; registration is not safe for
+// concurrent use.
+func (o *Orchestrator) RegisterValidationRule(rule ValidationRule) {
+	o.validationRules = append(o.validationRules, rule)
+}
+
+// measureRules holds the operator-configured parameters that control
+// measure output validation.
+type measureRules struct {
+	MaxReqs        int      // requirement cap per task (0 = unlimited)
+	MaxAC          int      // acceptance criteria cap per task (0 = unlimited)
+	MaxFiles       int      // file cap per task (0 = unlimited)
+	WarnReqIDGaps  bool     // warn when numeric requirement IDs skip numbers
+	EnforceIDForm  bool     // warn on IDs not of the form R<n>, AC<n>, D<n>
+	ImplementedUCs []string // use case IDs that must not be targeted
+	EffortValues   []string // accepted effort labels (empty = no label check)
+	EffortMaxHours int      // upper bound for hour estimates (0 = disabled)
+
+	// Extensions maps deliverable_type to the expected file extensions
+	// (nil = no check).
+	Extensions map[string][]string
+
+	// Custom holds registered ValidationRules. They are excluded from the
+	// validation cache key and never cached.
+	Custom []ValidationRule
+}
+
+// measureRules returns the validation parameters from Config.
+func (o *Orchestrator) measureRules() measureRules {
+	rules := measureRules{
+		MaxReqs:        o.cfg.Cobbler.MaxRequirementsPerTask,
+		MaxAC:          o.cfg.Cobbler.MaxACPerTask,
+		MaxFiles:       o.cfg.Cobbler.MaxFilesPerTask,
+		WarnReqIDGaps:  o.cfg.Cobbler.WarnRequirementIDGaps,
+		EnforceIDForm:  o.cfg.Cobbler.EnforceRequirementIDFormat,
+		EffortValues:   o.cfg.Cobbler.EffortValues,
+		EffortMaxHours: o.cfg.Cobbler.EffortMaxHours,
+		Extensions:     o.cfg.Cobbler.DeliverableExtensions,
+		Custom:         o.validationRules,
+	}
+	if o.cfg.Cobbler.GuardImplementedUseCases {
+		rules.ImplementedUCs = o.implementedUseCases()
+	}
+	return rules
+}
+
 // validateMeasureOutput checks proposed issues against P9 granularity ranges
-// and P7 file naming conventions. Returns structured warnings and errors.
-// All issues are logged regardless of enforcing mode. maxReqs is the
-// operator-configured requirement cap (0 = unlimited).
-func validateMeasureOutput(issues []proposedIssue, maxReqs int) validationResult {
-	var result validationResult
+// and P7 file naming conventions, then checks file paths across issues
+// (P13), then runs any custom rules. Returns structured warnings and
+// errors. All issues are logged regardless of enforcing mode.
+func validateMeasureOutput(issues []ProposedIssue, rules measureRules) ValidationResult {
+	var result ValidationResult
+	for _, issue := range issues {
+		result.merge(validateProposedIssue(issue, rules))
+	}
+	result.merge(validateCrossIssue(issues, rules))
+	return result
+}
+
+// validateCrossIssue runs the checks that look at all issues together:
+// the P13 duplicate file path check and the custom rules in rules.Custom.
+// These results are never cached.
+func validateCrossIssue(issues []ProposedIssue, rules measureRules) ValidationResult {
+	result := validateDuplicateFilePaths(issues)
+	for i, rule := range rules.Custom {
+		r := rule(issues)
+		for _, msg := range r.Errors {
+			logf("validateMeasureOutput: custom rule %d: %s", i+1, msg)
+		}
+		for _, msg := range r.Warnings {
+			logf("validateMeasureOutput: custom rule %d: %s", i+1, msg)
+		}
+		result.merge(r)
+	}
+	return result
+}
+
+// caseInsensitiveFS reports whether normalizeFilePath folds case. It is a
+// variable so tests can exercise both behaviors on any OS.
+var caseInsensitiveFS = runtime.GOOS == "darwin" || runtime.GOOS == "windows"
+
+// normalizeFilePath reduces a proposed file path to a canonical form so
+// that paths naming the same file compare equal: leading "./" and "/"
+// are stripped (issue paths are relative to the repo root), the path is
+// cleaned, and case is folded on case-insensitive filesystems.
+func normalizeFilePath(p string) string {
+	p = filepath.ToSlash(strings.TrimSpace(p))
+	for strings.HasPrefix(p, "./") || strings.HasPrefix(p, "/") {
+		p = strings.TrimPrefix(strings.TrimPrefix(p, "./"), "/")
+	}
+	p = path.Clean(p)
+	if caseInsensitiveFS {
+		p = strings.ToLower(p)
+	}
+	return p
+}
+
+// validateDuplicateFilePaths warns when two issues list the same file
+// (P13), comparing paths after normalizeFilePath. Each shared file is
+// reported once, naming every issue that lists it. Issues whose
+// description does not parse are skipped.
+func validateDuplicateFilePaths(issues []ProposedIssue) ValidationResult {
+	var result ValidationResult
+	owners := map[string][]int{}
+	var order []string
 	for _, issue := range issues {
 		var desc issueDescription
-		if err := yaml.Unmarshal([]byte(issue.Description), &desc); err != nil {
-			msg := fmt.Sprintf("[%d] %q: could not parse description: %v", issue.Index, issue.Title, err)
+		if yaml.Unmarshal([]byte(issue.Description), &desc) != nil {
+			continue
+		}
+		seen := map[string]bool{}
+		for _, f := range desc.Files {
+			key := normalizeFilePath(f.Path)
+			if key == "." || seen[key] {
+				continue
+			}
+			seen[key] = true
+			if _, ok := owners[key]; !ok {
+				order = append(order, key)
+			}
+			owners[key] = append(owners[key], issue.Index)
+		}
+	}
+	for _, key := range order {
+		if idx := owners[key]; len(idx) > 1 {
+			msg := fmt.Sprintf("file %s is listed by issues %v (P13 duplicate file path)", key, idx)
 			logf("validateMeasureOutput: %s", msg)
 			result.Warnings = append(result.Warnings, msg)
-			continue
 		}
+	}
+	return result
+}
+
+// merge appends the warnings and errors of other to v.
+func (v *ValidationResult) merge(other ValidationResult) {
+	v.Warnings = append(v.Warnings, other.Warnings...)
+	v.Errors = append(v.Errors, other.Errors...)
+}
 
-		rCount := len(desc.Requirements)
-		acCount := len(desc.AcceptanceCriteria)
-		dCount := len(desc.DesignDecisions)
+// validateProposedIssue applies the validateMeasureOutput rules to a single
+// issue.
+func validateProposedIssue(issue ProposedIssue, rules measureRules) ValidationResult {
+	var result ValidationResult
+	if msg := validateEffort(issue.Effort, rules); msg != "" {
+		msg = fmt.Sprintf("[%d] %q: %s", issue.Index, issue.Title, msg)
+		logf("validateMeasureOutput: %s", msg)
+		result.Errors = append(result.Errors, msg)
+	}
+	for _, uc := range implementedUCReferences(issue, rules.ImplementedUCs) {
+		msg := fmt.Sprintf("[%d] %q: targets already-implemented use case %s", issue.Index, issue.Title, uc)
+		logf("validateMeasureOutput: %s", msg)
+		result.Warnings = append(result.Warnings, msg)
+	}
+	var desc issueDescription
+	if err := yaml.Unmarshal([]byte(issue.Description), &desc); err != nil {
+		msg := fmt.Sprintf("[%d] %q: could not parse description: %v", issue.Index, issue.Title, err)
+		logf("validateMeasureOutput: %s", msg)
+		result.Warnings = append(result.Warnings, msg)
+		return result
+	}
+
+	rCount := len(desc.Requirements)
+	acCount := len(desc.AcceptanceCriteria)
+	dCount := len(desc.DesignDecisions)
+
+	if rules.MaxReqs > 0 && rCount > rules.MaxReqs {
+		msg := fmt.Sprintf("[%d] %q: has %d requirements, max is %d", issue.Index, issue.Title, rCount, rules.MaxReqs)
+		logf("validateMeasureOutput: %s", msg)
+		result.Errors = append(result.Errors, msg)
+	}
+	if rules.MaxAC > 0 && acCount > rules.MaxAC {
+		msg := fmt.Sprintf("[%d] %q: has %d acceptance criteria, max is %d", issue.Index, issue.Title, acCount, rules.MaxAC)
+		logf("validateMeasureOutput: %s", msg)
+		result.Errors = append(result.Errors, msg)
+	}
+	if fCount := len(desc.Files); rules.MaxFiles > 0 && fCount > rules.MaxFiles {
+		msg := fmt.Sprintf("[%d] %q: has %d files, max is %d", issue.Index, issue.Title, fCount, rules.MaxFiles)
+		logf("validateMeasureOutput: %s", msg)
+		result.Errors = append(result.Errors, msg)
+	}
 
-		if maxReqs > 0 && rCount > maxReqs {
-			msg := fmt.Sprintf("[%d] %q: has %d requirements, max is %d", issue.Index, issue.Title, rCount, maxReqs)
+	if desc.DeliverableType == "code" {
+		if rCount < 5 || rCount > 8 {
+			msg := fmt.Sprintf("[%d] %q: requirement count %d outside P9 range 5-8", issue.Index, issue.Title, rCount)
 			logf("validateMeasureOutput: %s", msg)
 			result.Errors = append(result.Errors, msg)
 		}
+		if acCount == 0 {
+			msg := fmt.Sprintf("[%d] %q: code task has no acceptance criteria", issue.Index, issue.Title)
+			logf("validateMeasureOutput: %s", msg)
+			result.Errors = append(result.Errors, msg)
+		} else if acCount < 5 || acCount > 8 {
+			msg := fmt.Sprintf("[%d] %q: acceptance criteria count %d outside P9 range 5-8", issue.Index, issue.Title, acCount)
+			logf("validateMeasureOutput: %s", msg)
+			result.Errors = append(result.Errors, msg)
+		}
+		if dCount < 3 || dCount > 5 {
+			msg := fmt.Sprintf("[%d] %q: design decision count %d outside P9 range 3-5", issue.Index, issue.Title, dCount)
+			logf("validateMeasureOutput: %s", msg)
+			result.Errors = append(result.Errors, msg)
+		}
+	} else if desc.DeliverableType == "documentation" {
+		if rCount < 2 || rCount > 4 {
+			msg := fmt.Sprintf("[%d] %q: requirement count %d outside P9 doc range 2-4", issue.Index, issue.Title, rCount)
+			logf("validateMeasureOutput: %s", msg)
+			result.Errors = append(result.Errors, msg)
+		}
+		if acCount < 3 || acCount > 5 {
+			msg := fmt.Sprintf("[%d] %q: acceptance criteria count %d outside P9 doc range 3-5", issue.Index, issue.Title, acCount)
+			logf("validateMeasureOutput: %s", msg)
+			result.Errors = append(result.Errors, msg)
+		}
+	}
 
-		if desc.DeliverableType == "code" {
-			if rCount < 5 || rCount > 8 {
-				msg := fmt.Sprintf("[%d] %q: requirement count %d outside P9 range 5-8", issue.Index, issue.Title, rCount)
-				logf("validateMeasureOutput: %s", msg)
-				result.Errors = append(result.Errors, msg)
-			}
-			if acCount < 5 || acCount > 8 {
-				msg := fmt.Sprintf("[%d] %q: acceptance criteria count %d outside P9 range 5-8", issue.Index, issue.Title, acCount)
+	for _, v := range blankItemTexts(desc) {
+		msg := fmt.Sprintf("[%d] %q: %s", issue.Index, issue.Title, v)
+		logf("validateMeasureOutput: %s", msg)
+		result.Errors = append(result.Errors, msg)
+	}
+
+	if rules.WarnReqIDGaps {
+		if gaps := requirementIDGaps(desc.Requirements); len(gaps) > 0 {
+			msg := fmt.Sprintf("[%d] %q: requirement IDs skip %s", issue.Index, issue.Title, strings.Join(gaps, ", "))
+			logf("validateMeasureOutput: %s", msg)
+			result.Warnings = append(result.Warnings, msg)
+		}
+	}
+
+	for _, pair := range duplicateItemTexts(desc.AcceptanceCriteria) {
+		msg := fmt.Sprintf("[%d] %q: acceptance criteria %s and %s have identical text", issue.Index, issue.Title, pair[0], pair[1])
+		logf("validateMeasureOutput: %s", msg)
+		result.Warnings = append(result.Warnings, msg)
+	}
+
+	if rules.EnforceIDForm {
+		for _, v := range nonConformingItemIDs(desc) {
+			msg := fmt.Sprintf("[%d] %q: %s", issue.Index, issue.Title, v)
+			logf("validateMeasureOutput: %s", msg)
+			result.Warnings = append(result.Warnings, msg)
+		}
+	}
+
+	if msg := deliverableExtensionMismatch(desc, rules.Extensions); msg != "" {
+		msg = fmt.Sprintf("[%d] %q: %s", issue.Index, issue.Title, msg)
+		logf("validateMeasureOutput: %s", msg)
+		result.Warnings = append(result.Warnings, msg)
+	}
+
+	// Check for P7 violation: file named after its package.
+	for _, f := range desc.Files {
+		parts := strings.Split(f.Path, "/")
+		if len(parts) >= 2 {
+			dir := parts[len(parts)-2]
+			file := parts[len(parts)-1]
+			if file == dir+".go" || file == dir+"_test.go" {
+				msg := fmt.Sprintf("[%d] %q: file %s matches package name (P7 violation)", issue.Index, issue.Title, f.Path)
 				logf("validateMeasureOutput: %s", msg)
 				result.Errors = append(result.Errors, msg)
 			}
-			if dCount < 3 || dCount > 5 {
-				msg := fmt.Sprintf("[%d] %q: design decision count %d outside P9 range 3-5", issue.Index, issue.Title, dCount)
-				logf("validateMeasureOutput: %s", msg)
-				result.Errors = append(result.Errors, msg)
+		}
+	}
+	return result
+}
+
+// deliverableExtensionMismatch reports a task whose declared files all
+// lack the extensions expected for its deliverable_type. Returns "" when
+// the task lists no files, its type has no configured extensions, or at
+// least one file matches. Extensions compare case-insensitively.
+func deliverableExtensionMismatch(desc issueDescription, extensions map[string][]string) string {
+	want := extensions[desc.DeliverableType]
+	if len(want) == 0 || len(desc.Files) == 0 {
+		return ""
+	}
+	for _, f := range desc.Files {
+		ext := path.Ext(strings.TrimSpace(f.Path))
+		for _, w := range want {
+			if strings.EqualFold(ext, w) {
+				return ""
 			}
-		} else if desc.DeliverableType == "documentation" {
-			if rCount < 2 || rCount > 4 {
-				msg := fmt.Sprintf("[%d] %q: requirement count %d outside P9 doc range 2-4", issue.Index, issue.Title, rCount)
-				logf("validateMeasureOutput: %s", msg)
-				result.Errors = append(result.Errors, msg)
+		}
+	}
+	return fmt.Sprintf("%s task lists no %s files; check deliverable_type",
+		desc.DeliverableType, strings.Join(want, "/"))
+}
+
+// applyDefaultEffort sets Effort to def on every issue that has none.
+func applyDefaultEffort(issues []ProposedIssue, def string) {
+	for i := range issues {
+		if strings.TrimSpace(issues[i].Effort) == "" {
+			issues[i].Effort = def
+		}
+	}
+}
+
+// validateEffort checks effort against the configured labels and hour
+// range. Returns "" when effort is valid, empty, or no effort rules are
+// configured; otherwise a description of the problem.
+func validateEffort(effort string, rules measureRules) string {
+	effort = strings.TrimSpace(effort)
+	if effort == "" || (len(rules.EffortValues) == 0 && rules.EffortMaxHours <= 0) {
+		return ""
+	}
+	for _, v := range rules.EffortValues {
+		if strings.EqualFold(effort, v) {
+			return ""
+		}
+	}
+	if rules.EffortMaxHours > 0 {
+		if h, err := strconv.ParseFloat(effort, 64); err == nil {
+			if h >= 1 && h <= float64(rules.EffortMaxHours) {
+				return ""
 			}
-			if acCount < 3 || acCount > 5 {
-				msg := fmt.Sprintf("[%d] %q: acceptance criteria count %d outside P9 doc range 3-5", issue.Index, issue.Title, acCount)
-				logf("validateMeasureOutput: %s", msg)
-				result.Errors = append(result.Errors, msg)
+			return fmt.Sprintf("effort %s hours outside range 1-%d", effort, rules.EffortMaxHours)
+		}
+	}
+	return fmt.Sprintf("effort %q not one of [%s]", effort, strings.Join(rules.EffortValues, ", "))
+}
+
+// ucRefRe matches a use case prefix such as "rel01.0-uc003" anywhere in
+// an issue's title or description.
+var ucRefRe = regexp.MustCompile(`rel\d+\.\d+-uc\d+`)
+
+// implementedUCReferences returns the use cases from implemented that
+// issue references by prefix in its title or description, in order of
+// first appearance and without duplicates.
+func implementedUCReferences(issue ProposedIssue, implemented []string) []string {
+	if len(implemented) == 0 {
+		return nil
+	}
+	done := make(map[string]string, len(implemented))
+	for _, id := range implemented {
+		done[ucPrefixFromID(id)] = id
+	}
+	var refs []string
+	seen := map[string]bool{}
+	for _, m := range ucRefRe.FindAllString(issue.Title+"\n"+issue.Description, -1) {
+		id, ok := done[m]
+		if !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+		refs = append(refs, id)
+	}
+	return refs
+}
+
+// duplicateItemTexts returns the ID pairs of items whose text is
+// identical after normalizeItemText, pairing each repeat with the first
+// item that had the text. Items with empty text are ignored.
+func duplicateItemTexts(items []issueDescItem) [][2]string {
+	first := map[string]string{}
+	var pairs [][2]string
+	for _, it := range items {
+		key := normalizeItemText(it.Text)
+		if key == "" {
+			continue
+		}
+		if id, ok := first[key]; ok {
+			pairs = append(pairs, [2]string{id, it.ID})
+			continue
+		}
+		first[key] = it.ID
+	}
+	return pairs
+}
+
+// normalizeItemText folds case, collapses whitespace, and drops trailing
+// periods so that trivially different copies of a sentence compare equal.
+func normalizeItemText(s string) string {
+	return strings.TrimRight(strings.ToLower(strings.Join(strings.Fields(s), " ")), ".")
+}
+
+// numericReqIDRe matches plain numeric requirement IDs such as "R3".
+var numericReqIDRe = regexp.MustCompile(`^R(\d+)$`)
+
+// requirementIDGaps returns the IDs missing from the sequence R1..Rmax,
+// where max is the highest numeric requirement ID present. IDs that are
+// not of the plain "R<n>" form are ignored. Returns nil when there are no
+// numeric IDs or the sequence is contiguous.
+func requirementIDGaps(reqs []issueDescItem) []string {
+	seen := map[int]bool{}
+	maxID := 0
+	for _, r := range reqs {
+		m := numericReqIDRe.FindStringSubmatch(strings.TrimSpace(r.ID))
+		if m == nil {
+			continue
+		}
+		n, _ := strconv.Atoi(m[1])
+		seen[n] = true
+		if n > maxID {
+			maxID = n
+		}
+	}
+	var gaps []string
+	for n := 1; n < maxID; n++ {
+		if !seen[n] {
+			gaps = append(gaps, fmt.Sprintf("R%d", n))
+		}
+	}
+	return gaps
+}
+
+// itemIDFormats pairs each numbered description list with the ID pattern
+// its items must match when EnforceRequirementIDFormat is set.
+var itemIDFormats = []struct {
+	kind string
+	form string
+	re   *regexp.Regexp
+	list func(issueDescription) []issueDescItem
+}{
+	{"requirement", "R<n>", numericReqIDRe, func(d issueDescription) []issueDescItem { return d.Requirements }},
+	{"acceptance criterion", "AC<n>", regexp.MustCompile(`^AC\d+$`), func(d issueDescription) []issueDescItem { return d.AcceptanceCriteria }},
+	{"design decision", "D<n>", regexp.MustCompile(`^D\d+$`), func(d issueDescription) []issueDescItem { return d.DesignDecisions }},
+}
+
+// nonConformingItemIDs returns one message per requirement, acceptance
+// criterion, or design decision whose ID does not match its numbered form.
+func nonConformingItemIDs(desc issueDescription) []string {
+	var msgs []string
+	for _, f := range itemIDFormats {
+		for _, item := range f.list(desc) {
+			if !f.re.MatchString(strings.TrimSpace(item.ID)) {
+				msgs = append(msgs, fmt.Sprintf("%s ID %q does not match %s", f.kind, item.ID, f.form))
 			}
 		}
+	}
+	return msgs
+}
 
-		// Check for P7 violation: file named after its package.
-		for _, f := range desc.Files {
-			parts := strings.Split(f.Path, "/")
-			if len(parts) >= 2 {
-				dir := parts[len(parts)-2]
-				file := parts[len(parts)-1]
-				if file == dir+".go" || file == dir+"_test.go" {
-					msg := fmt.Sprintf("[%d] %q: file %s matches package name (P7 violation)", issue.Index, issue.Title, f.Path)
-					logf("validateMeasureOutput: %s", msg)
-					result.Errors = append(result.Errors, msg)
-				}
+// blankItemTexts returns one message per requirement, acceptance
+// criterion, or design decision whose text is empty or whitespace.
+func blankItemTexts(desc issueDescription) []string {
+	var msgs []string
+	for _, f := range itemIDFormats {
+		for _, item := range f.list(desc) {
+			if strings.TrimSpace(item.Text) == "" {
+				msgs = append(msgs, fmt.Sprintf("%s %s has empty text", f.kind, item.ID))
 			}
 		}
 	}
-	return result
+	return msgs
 }
 
-// saveHistory persists measure artifacts (log, issues YAML) to the configured
-// history directory. The prompt is saved separately before runClaude.
+// saveHistory persists measure artifacts (raw log, issues YAML) to the
+// configured history directory. File names come from
+// Cobbler.HistoryFileNaming. The prompt is saved separately before
+// runClaude, and runMeasure records the LOC snapshot for LOCDelta.
 func (o *Orchestrator) saveHistory(ts string, rawOutput []byte, issuesFile string) {
-	o.saveHistoryLog(ts, "measure", rawOutput)
-
 	dir := o.historyDir()
 	if dir == "" {
 		return
 	}
-	base := ts + "-measure"
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		logf("saveHistory: mkdir %s: %v", dir, err)
+		return
+	}
+	rawPath := filepath.Join(dir, o.historyFileName(ts, "log")+".log")
+	if err := os.WriteFile(rawPath, rawOutput, 0o644); err != nil {
+		logf("saveHistory: write raw: %v", err)
+	} else {
+		logf("saveHistory: saved %s", rawPath)
+	}
 	if data, err := os.ReadFile(issuesFile); err == nil {
-		if err := os.WriteFile(filepath.Join(dir, base+"-issues.yaml"), data, 0o644); err != nil {
+		issuesPath := filepath.Join(dir, o.historyFileName(ts, "issues")+".yaml")
+		if err := os.WriteFile(issuesPath, data, 0o644); err != nil {
 			logf("saveHistory: write issues: %v", err)
 		}
 	}
 }
 
-// appendMeasureLog merges newIssues into the persistent measure.yaml list.
-// measure.yaml is a single growing YAML list of all issues proposed across runs.
-func appendMeasureLog(cobblerDir string, newIssues []proposedIssue) {
-	logPath := filepath.Join(cobblerDir, "measure.yaml")
+// historyIssueIndexFile is the file in the history directory that maps
+// each measure history timestamp to the issue IDs imported from it.
+const historyIssueIndexFile = "measure-issue-index.yaml"
 
-	var existing []proposedIssue
+// saveHistoryIssueIndex records ids under ts in historyIssueIndexFile so
+// an issue can be traced back to the measure run that produced it. Entries
+// for other timestamps are preserved. When HistoryDir is empty or ids is
+// empty the call is a no-op.
+func (o *Orchestrator) saveHistoryIssueIndex(ts string, ids []string) {
+	dir := o.historyDir()
+	if dir == "" || len(ids) == 0 {
+		return
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		logf("saveHistoryIssueIndex: mkdir %s: %v", dir, err)
+		return
+	}
+	path := filepath.Join(dir, historyIssueIndexFile)
+	index := loadHistoryIssueIndex(path)
+	index[ts] = append(index[ts], ids...)
+	data, err := yaml.Marshal(index)
+	if err != nil {
+		logf("saveHistoryIssueIndex: marshal: %v", err)
+		return
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		logf("saveHistoryIssueIndex: write %s: %v", path, err)
+		return
+	}
+	logf("saveHistoryIssueIndex: recorded %d issue(s) for %s", len(ids), ts)
+}
+
+// loadHistoryIssueIndex reads the timestamp-to-issue-IDs index at path.
+// A missing or unparsable file yields an empty index.
+func loadHistoryIssueIndex(path string) map[string][]string {
+	index := map[string][]string{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return index
+	}
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		logf("loadHistoryIssueIndex: could not parse %s, starting fresh: %v", path, err)
+		return map[string][]string{}
+	}
+	return index
+}
+
+// defaultHistoryFileNaming is the HistoryFileNaming template used when
+// none is configured.
+const defaultHistoryFileNaming = "{{.Timestamp}}-measure-{{.Type}}"
+
+// historyFileNameData is the data passed to the HistoryFileNaming template.
+type historyFileNameData struct {
+	Timestamp  string
+	Generation string
+	Type       string
+}
+
+// parseHistoryFileNaming parses a HistoryFileNaming template.
+func parseHistoryFileNaming(text string) (*template.Template, error) {
+	tmpl, err := template.New("history_file_naming").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing cobbler.history_file_naming: %w", err)
+	}
+	return tmpl, nil
+}
+
+// historyFileName renders the HistoryFileNaming template for a measure
+// history file of the given type ("issues" or "log"), without extension,
+// for the current generation.
+func (o *Orchestrator) historyFileName(ts, fileType string) string {
+	return o.historyFileNameFor(ts, o.CurrentGeneration(), fileType)
+}
+
+// historyFileNameFor is historyFileName for an explicit generation. It
+// falls back to the default template when the configured one fails to
+// parse or execute.
+func (o *Orchestrator) historyFileNameFor(ts, generation, fileType string) string {
+	data := historyFileNameData{Timestamp: ts, Generation: generation, Type: fileType}
+	text := o.cfg.Cobbler.HistoryFileNaming
+	if text == "" {
+		text = defaultHistoryFileNaming
+	}
+	var b strings.Builder
+	tmpl, err := parseHistoryFileNaming(text)
+	if err == nil {
+		err = tmpl.Execute(&b, data)
+	}
+	if err == nil && b.Len() == 0 {
+		err = fmt.Errorf("template rendered an empty name")
+	}
+	if err != nil {
+		logf("historyFileName: %v; using default naming", err)
+		return ts + "-measure-" + fileType
+	}
+	return b.String()
+}
+
+// appendMeasureLog merges newIssues into the persistent measure.yaml list
+// at logPath. measure.yaml is a single growing YAML list of all issues
+// proposed across runs.
+func appendMeasureLog(logPath string, newIssues []ProposedIssue) {
+	var existing []ProposedIssue
 	if data, err := os.ReadFile(logPath); err == nil {
 		if err := yaml.Unmarshal(data, &existing); err != nil {
 			logf("appendMeasureLog: could not parse existing list, starting fresh: %v", err)
@@ -587,6 +1530,10 @@ func appendMeasureLog(cobblerDir string, newIssues []proposedIssue) {
 		logf("appendMeasureLog: marshal failed: %v", err)
 		return
 	}
+	if err := os.MkdirAll(filepath.Dir(logPath), 0o755); err != nil {
+		logf("appendMeasureLog: mkdir failed: %v", err)
+		return
+	}
 	if err := os.WriteFile(logPath, out, 0o644); err != nil {
 		logf("appendMeasureLog: write failed: %v", err)
 		return
@@ -4,18 +4,26 @@
 package orchestrator
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 func TestValidateMeasureOutput_CodeP9InRange(t *testing.T) {
 	t.Parallel()
-	issues := []proposedIssue{{
+	issues := []ProposedIssue{{
 		Index: 0,
 		Title: "Valid code task",
 		Description: `deliverable_type: code
@@ -51,7 +59,7 @@ design_decisions:
 `,
 	}}
 
-	vr := validateMeasureOutput(issues, 0)
+	vr := validateMeasureOutput(issues, measureRules{})
 	if vr.HasErrors() {
 		t.Errorf("expected no errors for valid code task, got: %v", vr.Errors)
 	}
@@ -59,7 +67,7 @@ design_decisions:
 
 func TestValidateMeasureOutput_CodeP9TooFewRequirements(t *testing.T) {
 	t.Parallel()
-	issues := []proposedIssue{{
+	issues := []ProposedIssue{{
 		Index: 0,
 		Title: "Underconstrained task",
 		Description: `deliverable_type: code
@@ -89,7 +97,7 @@ design_decisions:
 `,
 	}}
 
-	vr := validateMeasureOutput(issues, 0)
+	vr := validateMeasureOutput(issues, measureRules{})
 	if !vr.HasErrors() {
 		t.Error("expected errors for code task with 2 requirements (P9 range 5-8)")
 	}
@@ -100,7 +108,7 @@ design_decisions:
 
 func TestValidateMeasureOutput_CodeP9TooManyRequirements(t *testing.T) {
 	t.Parallel()
-	issues := []proposedIssue{{
+	issues := []ProposedIssue{{
 		Index: 0,
 		Title: "Overconstrained task",
 		Description: `deliverable_type: code
@@ -144,15 +152,55 @@ design_decisions:
 `,
 	}}
 
-	vr := validateMeasureOutput(issues, 0)
+	vr := validateMeasureOutput(issues, measureRules{})
 	if !vr.HasErrors() {
 		t.Error("expected errors for code task with 9 requirements (P9 range 5-8)")
 	}
 }
 
+func TestValidateMeasureOutput_CodeNoAcceptanceCriteria(t *testing.T) {
+	t.Parallel()
+	issues := []ProposedIssue{{
+		Index: 0,
+		Title: "Untestable task",
+		Description: `deliverable_type: code
+requirements:
+  - id: R1
+    text: req1
+  - id: R2
+    text: req2
+  - id: R3
+    text: req3
+  - id: R4
+    text: req4
+  - id: R5
+    text: req5
+acceptance_criteria: []
+design_decisions:
+  - id: D1
+    text: d1
+  - id: D2
+    text: d2
+  - id: D3
+    text: d3
+`,
+	}}
+
+	vr := validateMeasureOutput(issues, measureRules{})
+	if len(vr.Errors) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(vr.Errors), vr.Errors)
+	}
+	if !strings.Contains(vr.Errors[0], "code task has no acceptance criteria") {
+		t.Errorf("error should name the missing acceptance criteria, got: %s", vr.Errors[0])
+	}
+	if strings.Contains(vr.Errors[0], "outside P9 range") {
+		t.Errorf("zero acceptance criteria should not report a range error, got: %s", vr.Errors[0])
+	}
+}
+
 func TestValidateMeasureOutput_DocP9InRange(t *testing.T) {
 	t.Parallel()
-	issues := []proposedIssue{{
+	issues := []ProposedIssue{{
 		Index: 0,
 		Title: "Valid doc task",
 		Description: `deliverable_type: documentation
@@ -173,7 +221,7 @@ acceptance_criteria:
 `,
 	}}
 
-	vr := validateMeasureOutput(issues, 0)
+	vr := validateMeasureOutput(issues, measureRules{})
 	if vr.HasErrors() {
 		t.Errorf("expected no errors for valid doc task, got: %v", vr.Errors)
 	}
@@ -181,7 +229,7 @@ acceptance_criteria:
 
 func TestValidateMeasureOutput_DocP9TooManyRequirements(t *testing.T) {
 	t.Parallel()
-	issues := []proposedIssue{{
+	issues := []ProposedIssue{{
 		Index: 0,
 		Title: "Over-specified doc",
 		Description: `deliverable_type: documentation
@@ -206,7 +254,7 @@ acceptance_criteria:
 `,
 	}}
 
-	vr := validateMeasureOutput(issues, 0)
+	vr := validateMeasureOutput(issues, measureRules{})
 	if !vr.HasErrors() {
 		t.Error("expected errors for doc task with 5 requirements (P9 range 2-4)")
 	}
@@ -214,7 +262,7 @@ acceptance_criteria:
 
 func TestValidateMeasureOutput_P7ViolationFileNameMatchesPackage(t *testing.T) {
 	t.Parallel()
-	issues := []proposedIssue{{
+	issues := []ProposedIssue{{
 		Index: 0,
 		Title: "P7 violation task",
 		Description: `deliverable_type: code
@@ -252,7 +300,7 @@ design_decisions:
 `,
 	}}
 
-	vr := validateMeasureOutput(issues, 0)
+	vr := validateMeasureOutput(issues, measureRules{})
 	if !vr.HasErrors() {
 		t.Error("expected errors for file named after package (P7 violation)")
 	}
@@ -270,7 +318,7 @@ design_decisions:
 
 func TestValidateMeasureOutput_P7NoViolation(t *testing.T) {
 	t.Parallel()
-	issues := []proposedIssue{{
+	issues := []ProposedIssue{{
 		Index: 0,
 		Title: "Good naming task",
 		Description: `deliverable_type: code
@@ -310,7 +358,7 @@ design_decisions:
 
 	// runner.go in pkg/difftest/ is NOT a P7 violation because
 	// the file name does not match the parent directory name.
-	vr := validateMeasureOutput(issues, 0)
+	vr := validateMeasureOutput(issues, measureRules{})
 	p7Errors := 0
 	for _, e := range vr.Errors {
 		if contains(e, "P7 violation") {
@@ -324,13 +372,13 @@ design_decisions:
 
 func TestValidateMeasureOutput_UnparseableDescription(t *testing.T) {
 	t.Parallel()
-	issues := []proposedIssue{{
+	issues := []ProposedIssue{{
 		Index: 0,
 		Title: "Bad YAML task",
 		Description: `{{{not valid yaml`,
 	}}
 
-	vr := validateMeasureOutput(issues, 0)
+	vr := validateMeasureOutput(issues, measureRules{})
 	if len(vr.Warnings) == 0 {
 		t.Error("expected warning for unparseable description")
 	}
@@ -338,7 +386,7 @@ func TestValidateMeasureOutput_UnparseableDescription(t *testing.T) {
 
 func TestValidateMeasureOutput_MultipleIssues(t *testing.T) {
 	t.Parallel()
-	issues := []proposedIssue{
+	issues := []ProposedIssue{
 		{
 			Index: 0,
 			Title: "Valid task",
@@ -388,7 +436,7 @@ acceptance_criteria:
 		},
 	}
 
-	vr := validateMeasureOutput(issues, 0)
+	vr := validateMeasureOutput(issues, measureRules{})
 	if !vr.HasErrors() {
 		t.Error("expected errors from invalid second issue")
 	}
@@ -397,17 +445,17 @@ acceptance_criteria:
 func TestValidationResult_HasErrors(t *testing.T) {
 	t.Parallel()
 
-	empty := validationResult{}
+	empty := ValidationResult{}
 	if empty.HasErrors() {
 		t.Error("empty result should not have errors")
 	}
 
-	warningsOnly := validationResult{Warnings: []string{"warn"}}
+	warningsOnly := ValidationResult{Warnings: []string{"warn"}}
 	if warningsOnly.HasErrors() {
 		t.Error("warnings-only result should not have errors")
 	}
 
-	withErrors := validationResult{Errors: []string{"err"}}
+	withErrors := ValidationResult{Errors: []string{"err"}}
 	if !withErrors.HasErrors() {
 		t.Error("result with errors should have errors")
 	}
@@ -423,12 +471,12 @@ func TestValidateMeasureOutput_MaxReqs_ZeroIsUnlimited(t *testing.T) {
 	for i := 1; i <= 10; i++ {
 		reqs += "  - id: R" + fmt.Sprintf("%d", i) + "\n    text: req\n"
 	}
-	issues := []proposedIssue{{
+	issues := []ProposedIssue{{
 		Index:       0,
 		Title:       "Huge task",
 		Description: "deliverable_type: code\nrequirements:\n" + reqs,
 	}}
-	vr := validateMeasureOutput(issues, 0)
+	vr := validateMeasureOutput(issues, measureRules{})
 	for _, e := range vr.Errors {
 		if contains(e, "max is") {
 			t.Errorf("maxReqs=0 should not produce max-requirements error, got: %s", e)
@@ -439,7 +487,7 @@ func TestValidateMeasureOutput_MaxReqs_ZeroIsUnlimited(t *testing.T) {
 func TestValidateMeasureOutput_MaxReqs_ExactlyAtLimit_NoError(t *testing.T) {
 	t.Parallel()
 	// 5 requirements with maxReqs=5 must not trigger the limit error.
-	issues := []proposedIssue{{
+	issues := []ProposedIssue{{
 		Index: 0,
 		Title: "At-limit task",
 		Description: `deliverable_type: code
@@ -456,7 +504,7 @@ requirements:
     text: req
 `,
 	}}
-	vr := validateMeasureOutput(issues, 5)
+	vr := validateMeasureOutput(issues, measureRules{MaxReqs: 5})
 	for _, e := range vr.Errors {
 		if contains(e, "max is") {
 			t.Errorf("5 requirements at maxReqs=5 should not error, got: %s", e)
@@ -467,7 +515,7 @@ requirements:
 func TestValidateMeasureOutput_MaxReqs_ExceedsLimit_Error(t *testing.T) {
 	t.Parallel()
 	// 6 requirements with maxReqs=5 must produce a max-requirements error.
-	issues := []proposedIssue{{
+	issues := []ProposedIssue{{
 		Index: 0,
 		Title: "Oversized task",
 		Description: `deliverable_type: code
@@ -486,7 +534,7 @@ requirements:
     text: req
 `,
 	}}
-	vr := validateMeasureOutput(issues, 5)
+	vr := validateMeasureOutput(issues, measureRules{MaxReqs: 5})
 	found := false
 	for _, e := range vr.Errors {
 		if contains(e, "max is") {
@@ -502,7 +550,7 @@ requirements:
 func TestValidateMeasureOutput_MaxReqs_ErrorMentionsCountAndLimit(t *testing.T) {
 	t.Parallel()
 	// Error message must include both the actual count and the configured limit.
-	issues := []proposedIssue{{
+	issues := []ProposedIssue{{
 		Index: 1,
 		Title: "Task Title",
 		Description: `deliverable_type: code
@@ -525,7 +573,7 @@ requirements:
     text: req
 `,
 	}}
-	vr := validateMeasureOutput(issues, 5)
+	vr := validateMeasureOutput(issues, measureRules{MaxReqs: 5})
 	found := false
 	for _, e := range vr.Errors {
 		if contains(e, "8") && contains(e, "5") && contains(e, "Task Title") {
@@ -538,6 +586,99 @@ requirements:
 	}
 }
 
+// acDescription builds an issue description with n acceptance criteria.
+func acDescription(deliverable string, n int) string {
+	var b strings.Builder
+	b.WriteString("deliverable_type: " + deliverable + "\nacceptance_criteria:\n")
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, "  - id: AC%d\n    text: ac\n", i)
+	}
+	return b.String()
+}
+
+// maxACErrors returns the acceptance-criteria limit errors in vr.
+func maxACErrors(vr ValidationResult) []string {
+	var out []string
+	for _, e := range vr.Errors {
+		if contains(e, "acceptance criteria, max is") {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func TestValidateMeasureOutput_MaxAC_AtLimit_NoError(t *testing.T) {
+	t.Parallel()
+	issues := []ProposedIssue{{Index: 0, Title: "At-limit task", Description: acDescription("code", 6)}}
+	if errs := maxACErrors(validateMeasureOutput(issues, measureRules{MaxAC: 6})); len(errs) > 0 {
+		t.Errorf("6 acceptance criteria at MaxAC=6 should not error, got: %v", errs)
+	}
+}
+
+func TestValidateMeasureOutput_MaxAC_ExceedsLimit_Error(t *testing.T) {
+	t.Parallel()
+	issues := []ProposedIssue{{Index: 2, Title: "Oversized task", Description: acDescription("code", 7)}}
+	errs := maxACErrors(validateMeasureOutput(issues, measureRules{MaxAC: 6}))
+	if len(errs) != 1 {
+		t.Fatalf("expected one max-AC error, got: %v", errs)
+	}
+	if !contains(errs[0], "Oversized task") || !contains(errs[0], "has 7") || !contains(errs[0], "max is 6") {
+		t.Errorf("error should name title, count (7), and limit (6); got: %s", errs[0])
+	}
+}
+
+func TestValidateMeasureOutput_MaxAC_ZeroIsUnlimited(t *testing.T) {
+	t.Parallel()
+	issues := []ProposedIssue{{Index: 0, Title: "Huge task", Description: acDescription("code", 20)}}
+	if errs := maxACErrors(validateMeasureOutput(issues, measureRules{})); len(errs) > 0 {
+		t.Errorf("MaxAC=0 should not produce max-AC error, got: %v", errs)
+	}
+}
+
+func TestValidateMeasureOutput_MaxAC_AppliesToCodeAndDocumentation(t *testing.T) {
+	t.Parallel()
+	issues := []ProposedIssue{
+		{Index: 0, Title: "Code task", Description: acDescription("code", 5)},
+		{Index: 1, Title: "Doc task", Description: acDescription("documentation", 5)},
+		{Index: 2, Title: "Small doc task", Description: acDescription("documentation", 4)},
+	}
+	errs := maxACErrors(validateMeasureOutput(issues, measureRules{MaxAC: 4}))
+	if len(errs) != 2 || !contains(errs[0], "Code task") || !contains(errs[1], "Doc task") {
+		t.Errorf("expected max-AC errors for code and doc tasks only, got: %v", errs)
+	}
+}
+
+// maxFilesErrors returns the file limit errors in vr.
+func maxFilesErrors(vr ValidationResult) []string {
+	var out []string
+	for _, e := range vr.Errors {
+		if contains(e, "files, max is") {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func TestValidateMeasureOutput_MaxFiles_AtLimit_NoError(t *testing.T) {
+	t.Parallel()
+	issues := typedFilesIssue("code", "pkg/a/a.go", "pkg/a/b.go", "pkg/a/c.go")
+	if errs := maxFilesErrors(validateMeasureOutput(issues, measureRules{MaxFiles: 3})); len(errs) > 0 {
+		t.Errorf("3 files at MaxFiles=3 should not error, got: %v", errs)
+	}
+}
+
+func TestValidateMeasureOutput_MaxFiles_ExceedsLimit_Error(t *testing.T) {
+	t.Parallel()
+	issues := typedFilesIssue("code", "pkg/a/a.go", "pkg/a/b.go", "pkg/a/c.go", "pkg/a/d.go")
+	errs := maxFilesErrors(validateMeasureOutput(issues, measureRules{MaxFiles: 3}))
+	if len(errs) != 1 || errs[0] != `[1] "Task": has 4 files, max is 3` {
+		t.Errorf("expected one max-files error for 4 files, got: %v", errs)
+	}
+	if errs := maxFilesErrors(validateMeasureOutput(issues, measureRules{})); len(errs) > 0 {
+		t.Errorf("MaxFiles=0 should not produce a max-files error, got: %v", errs)
+	}
+}
+
 
 func TestMeasureReleasesConstraint_WithReleases(t *testing.T) {
 	t.Parallel()
@@ -580,6 +721,235 @@ func TestMeasureReleasesConstraint_ReleasesTakePrecedence(t *testing.T) {
 	}
 }
 
+// --- validateProjectReleases ---
+
+// writeReleasesRoadmap writes a roadmap with releases 01.0 and 02.0 and
+// returns its path.
+func writeReleasesRoadmap(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "road-map.yaml")
+	data := "releases:\n  - version: \"01.0\"\n    name: Core\n  - version: \"02.0\"\n    name: Extras\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestValidateProjectReleases_AllValid(t *testing.T) {
+	t.Parallel()
+	cfg := Config{Project: ProjectConfig{Releases: []string{"01.0", "02.0"}}}
+	if got := validateProjectReleases(cfg, writeReleasesRoadmap(t)); len(got) != 0 {
+		t.Errorf("expected no warnings, got %v", got)
+	}
+}
+
+func TestValidateProjectReleases_OneInvalid(t *testing.T) {
+	t.Parallel()
+	cfg := Config{Project: ProjectConfig{Releases: []string{"01.0", "03.0"}}}
+	got := validateProjectReleases(cfg, writeReleasesRoadmap(t))
+	if len(got) != 1 || !contains(got[0], `project.releases: release "03.0" not found`) {
+		t.Errorf("expected one warning for 03.0, got %v", got)
+	}
+}
+
+func TestValidateProjectReleases_LegacyRelease(t *testing.T) {
+	t.Parallel()
+	cfg := Config{Project: ProjectConfig{Release: "09.0"}}
+	got := validateProjectReleases(cfg, writeReleasesRoadmap(t))
+	if len(got) != 1 || !contains(got[0], `project.release: release "09.0" not found`) {
+		t.Errorf("expected one warning for legacy release 09.0, got %v", got)
+	}
+}
+
+func TestValidateProjectReleases_NoRoadmap(t *testing.T) {
+	t.Parallel()
+	cfg := Config{Project: ProjectConfig{Releases: []string{"01.0"}}}
+	if got := validateProjectReleases(cfg, filepath.Join(t.TempDir(), "missing.yaml")); got != nil {
+		t.Errorf("expected nil without a roadmap, got %v", got)
+	}
+}
+
+// --- implemented use case guard ---
+
+func TestImplementedUseCasesConstraint(t *testing.T) {
+	t.Parallel()
+	if got := implementedUseCasesConstraint(nil); got != "" {
+		t.Errorf("expected empty constraint for no use cases, got %q", got)
+	}
+	got := implementedUseCasesConstraint([]string{"rel01.0-uc001-init", "rel01.0-uc002-run"})
+	if !contains(got, "rel01.0-uc001-init, rel01.0-uc002-run") {
+		t.Errorf("expected use case list in constraint, got %q", got)
+	}
+	if !contains(got, "Do not plan work") {
+		t.Errorf("expected do-not-plan instruction, got %q", got)
+	}
+}
+
+func TestValidateMeasureOutput_ImplementedUCWarning(t *testing.T) {
+	t.Parallel()
+	issues := []ProposedIssue{
+		{Index: 1, Title: "rel01.0-uc001 add retries", Description: "deliverable_type: other\n"},
+		{Index: 2, Title: "rel01.0-uc002 add flags", Description: "deliverable_type: other\n"},
+	}
+	rules := measureRules{ImplementedUCs: []string{"rel01.0-uc001-init"}}
+
+	vr := validateMeasureOutput(issues, rules)
+	if len(vr.Warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %v", vr.Warnings)
+	}
+	if !strings.Contains(vr.Warnings[0], "rel01.0-uc001-init") || !strings.Contains(vr.Warnings[0], "[1]") {
+		t.Errorf("warning should name issue 1 and the use case, got %q", vr.Warnings[0])
+	}
+	if vr.HasErrors() {
+		t.Errorf("implemented use case check should be advisory, got errors %v", vr.Errors)
+	}
+	if off := validateMeasureOutput(issues, measureRules{}); len(off.Warnings) != 0 {
+		t.Errorf("guard disabled: got warnings %v", off.Warnings)
+	}
+}
+
+func TestBuildMeasurePrompt_ImplementedUCGuardOffByDefault(t *testing.T) {
+	t.Parallel()
+	o := New(Config{})
+	prompt, err := o.buildMeasurePrompt("", "", 1)
+	if err != nil {
+		t.Fatalf("buildMeasurePrompt() error = %v", err)
+	}
+	if strings.Contains(prompt, "Implemented use cases") {
+		t.Error("prompt should not list implemented use cases when the guard is off")
+	}
+}
+
+// Not parallel: uses os.Chdir.
+func TestBuildMeasurePrompt_ImplementedUCGuard(t *testing.T) {
+	dir := chdirTemp(t)
+	os.MkdirAll(filepath.Join(dir, "docs"), 0o755)
+	os.WriteFile(filepath.Join(dir, "docs", "road-map.yaml"), []byte(roadmapYAML), 0o644)
+	os.MkdirAll(filepath.Join(dir, "tests", "rel01.0", "uc001"), 0o755)
+	os.WriteFile(filepath.Join(dir, "tests", "rel01.0", "uc001", "init_test.go"), []byte("package x\n"), 0o644)
+
+	cfg := Config{}
+	cfg.Cobbler.GuardImplementedUseCases = true
+	o := New(cfg)
+	o.cfg.Cobbler.Dir = t.TempDir()
+
+	prompt, err := o.buildMeasurePrompt("", "", 1)
+	if err != nil {
+		t.Fatalf("buildMeasurePrompt() error = %v", err)
+	}
+	if !strings.Contains(prompt, "rel01.0-uc001-init") {
+		t.Error("prompt should list the implemented use case")
+	}
+	if rules := o.measureRules(); len(rules.ImplementedUCs) != 1 {
+		t.Errorf("measureRules().ImplementedUCs = %v, want one entry", rules.ImplementedUCs)
+	}
+}
+
+// --- P13 duplicate file paths ---
+
+// filesIssue returns a proposed issue whose description lists paths.
+func filesIssue(index int, paths ...string) ProposedIssue {
+	desc := "deliverable_type: documentation\nfiles:\n"
+	for _, p := range paths {
+		desc += "  - path: " + p + "\n"
+	}
+	return ProposedIssue{Index: index, Title: fmt.Sprintf("Issue %d", index), Description: desc}
+}
+
+func p13Warnings(vr ValidationResult) []string {
+	var out []string
+	for _, w := range vr.Warnings {
+		if contains(w, "P13") {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+func TestNormalizeFilePath(t *testing.T) {
+	orig := caseInsensitiveFS
+	t.Cleanup(func() { caseInsensitiveFS = orig })
+
+	caseInsensitiveFS = false
+	for in, want := range map[string]string{
+		"./pkg/foo/bar.go":       "pkg/foo/bar.go",
+		"/pkg/foo/bar.go":        "pkg/foo/bar.go",
+		"pkg//foo/../foo/bar.go": "pkg/foo/bar.go",
+		"PKG/FOO/BAR.GO":         "PKG/FOO/BAR.GO",
+	} {
+		if got := normalizeFilePath(in); got != want {
+			t.Errorf("normalizeFilePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	caseInsensitiveFS = true
+	if got := normalizeFilePath("PKG/FOO/BAR.GO"); got != "pkg/foo/bar.go" {
+		t.Errorf("case-insensitive normalizeFilePath = %q, want lowercase", got)
+	}
+}
+
+func TestValidateMeasureOutput_P13_LeadingDotSlashDuplicate(t *testing.T) {
+	t.Parallel()
+	issues := []ProposedIssue{filesIssue(1, "pkg/foo/bar.go"), filesIssue(2, "./pkg/foo/bar.go")}
+	got := p13Warnings(validateMeasureOutput(issues, measureRules{}))
+	if len(got) != 1 || !contains(got[0], "pkg/foo/bar.go") || !contains(got[0], "[1 2]") {
+		t.Errorf("expected one P13 warning naming issues 1 and 2, got %v", got)
+	}
+}
+
+func TestValidateMeasureOutput_P13_AbsoluteVsRelativeDuplicate(t *testing.T) {
+	t.Parallel()
+	issues := []ProposedIssue{filesIssue(1, "/pkg/foo/bar.go"), filesIssue(2, "pkg/foo/bar.go")}
+	if got := p13Warnings(validateMeasureOutput(issues, measureRules{})); len(got) != 1 {
+		t.Errorf("expected root-absolute and relative paths to collide, got %v", got)
+	}
+}
+
+func TestValidateMeasureOutput_P13_DifferentPathsNoDuplicate(t *testing.T) {
+	t.Parallel()
+	issues := []ProposedIssue{
+		filesIssue(1, "pkg/foo/bar.go", "./pkg/foo/bar.go"),
+		filesIssue(2, "pkg/foo/baz.go"),
+	}
+	if got := p13Warnings(validateMeasureOutput(issues, measureRules{})); len(got) != 0 {
+		t.Errorf("expected no P13 warnings, got %v", got)
+	}
+}
+
+// --- custom validation rules ---
+
+func TestRegisterValidationRule_CustomErrorSurfaces(t *testing.T) {
+	t.Parallel()
+	o := New(Config{Cobbler: CobblerConfig{MaxRequirementsPerTask: 1}})
+	o.RegisterValidationRule(func(issues []ProposedIssue) ValidationResult {
+		var r ValidationResult
+		for _, issue := range issues {
+			if issue.Title == "Forbidden" {
+				r.Errors = append(r.Errors, "custom: title Forbidden is not allowed")
+			}
+		}
+		return r
+	})
+
+	issues := []ProposedIssue{
+		{Index: 1, Title: "Forbidden", Description: "requirements:\n  - id: R1\n    text: a\n  - id: R2\n    text: b\n"},
+		{Index: 2, Title: "Fine"},
+	}
+	vr := validateMeasureOutput(issues, o.measureRules())
+	if len(vr.Errors) != 2 {
+		t.Fatalf("expected built-in and custom errors, got %v", vr.Errors)
+	}
+	if !contains(vr.Errors[0], "max is 1") {
+		t.Errorf("built-in rule should run first, got %q", vr.Errors[0])
+	}
+	if vr.Errors[1] != "custom: title Forbidden is not allowed" {
+		t.Errorf("custom error = %q", vr.Errors[1])
+	}
+	if got := validationRuleKey(o.measureRules()); got != validationRuleKey(New(Config{Cobbler: CobblerConfig{MaxRequirementsPerTask: 1}}).measureRules()) {
+		t.Errorf("custom rules should not change the cache key, got %q", got)
+	}
+}
+
 // --- truncateSHA ---
 
 func TestTruncateSHA_LongSHA(t *testing.T) {
@@ -620,19 +990,19 @@ func TestAppendMeasureLog_NewFile(t *testing.T) {
 	t.Parallel()
 	dir := t.TempDir()
 
-	issues := []proposedIssue{
+	issues := []ProposedIssue{
 		{Index: 1, Title: "Task A", Description: "desc-a"},
 		{Index: 2, Title: "Task B", Description: "desc-b"},
 	}
 
-	appendMeasureLog(dir, issues)
+	appendMeasureLog(filepath.Join(dir, "measure.yaml"), issues)
 
 	data, err := os.ReadFile(filepath.Join(dir, "measure.yaml"))
 	if err != nil {
 		t.Fatalf("measure.yaml not created: %v", err)
 	}
 
-	var loaded []proposedIssue
+	var loaded []ProposedIssue
 	if err := yaml.Unmarshal(data, &loaded); err != nil {
 		t.Fatalf("measure.yaml unmarshal: %v", err)
 	}
@@ -646,18 +1016,18 @@ func TestAppendMeasureLog_AppendsToExisting(t *testing.T) {
 	dir := t.TempDir()
 
 	// Seed with one existing issue.
-	seed := []proposedIssue{{Index: 1, Title: "Existing"}}
+	seed := []ProposedIssue{{Index: 1, Title: "Existing"}}
 	seedData, _ := yaml.Marshal(seed)
 	os.WriteFile(filepath.Join(dir, "measure.yaml"), seedData, 0o644)
 
 	// Append a new issue.
-	appendMeasureLog(dir, []proposedIssue{{Index: 2, Title: "New"}})
+	appendMeasureLog(filepath.Join(dir, "measure.yaml"), []ProposedIssue{{Index: 2, Title: "New"}})
 
 	data, err := os.ReadFile(filepath.Join(dir, "measure.yaml"))
 	if err != nil {
 		t.Fatalf("measure.yaml read: %v", err)
 	}
-	var loaded []proposedIssue
+	var loaded []ProposedIssue
 	if err := yaml.Unmarshal(data, &loaded); err != nil {
 		t.Fatalf("measure.yaml unmarshal: %v", err)
 	}
@@ -677,10 +1047,10 @@ func TestAppendMeasureLog_CorruptExistingStartsFresh(t *testing.T) {
 	os.WriteFile(filepath.Join(dir, "measure.yaml"), []byte("{{{not yaml"), 0o644)
 
 	// Append should recover and write just the new issues.
-	appendMeasureLog(dir, []proposedIssue{{Index: 1, Title: "Fresh"}})
+	appendMeasureLog(filepath.Join(dir, "measure.yaml"), []ProposedIssue{{Index: 1, Title: "Fresh"}})
 
 	data, _ := os.ReadFile(filepath.Join(dir, "measure.yaml"))
-	var loaded []proposedIssue
+	var loaded []ProposedIssue
 	if err := yaml.Unmarshal(data, &loaded); err != nil {
 		t.Fatalf("measure.yaml unmarshal: %v", err)
 	}
@@ -694,20 +1064,65 @@ func TestAppendMeasureLog_EmptyNewIssues(t *testing.T) {
 	dir := t.TempDir()
 
 	// Seed with one issue, then append nothing.
-	seed := []proposedIssue{{Index: 1, Title: "Existing"}}
+	seed := []ProposedIssue{{Index: 1, Title: "Existing"}}
 	seedData, _ := yaml.Marshal(seed)
 	os.WriteFile(filepath.Join(dir, "measure.yaml"), seedData, 0o644)
 
-	appendMeasureLog(dir, nil)
+	appendMeasureLog(filepath.Join(dir, "measure.yaml"), nil)
 
 	data, _ := os.ReadFile(filepath.Join(dir, "measure.yaml"))
-	var loaded []proposedIssue
+	var loaded []ProposedIssue
 	yaml.Unmarshal(data, &loaded)
 	if len(loaded) != 1 {
 		t.Errorf("expected 1 issue after appending nil, got %d", len(loaded))
 	}
 }
 
+func TestImportIssuesImpl_MeasureLogInArtifactsDir(t *testing.T) {
+	// Not parallel: stubs createIssueFn.
+	var events []string
+	var waits []time.Duration
+	stubIssueCreation(t, &events, &waits)
+	cobblerDir := t.TempDir()
+	artifactsDir := filepath.Join(t.TempDir(), "artifacts")
+	o := New(Config{Cobbler: CobblerConfig{Dir: cobblerDir, ArtifactsDir: artifactsDir}})
+	issuesFile := writeProposedIssues(t, t.TempDir(), "Add parser")
+
+	if _, err := o.importIssuesImpl(issuesFile, "owner/repo", "gen", true); err != nil {
+		t.Fatalf("importIssuesImpl: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(artifactsDir, "measure.yaml")); err != nil {
+		t.Errorf("measure.yaml not written to artifacts dir: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cobblerDir, "measure.yaml")); !os.IsNotExist(err) {
+		t.Errorf("measure.yaml should not be written to the cobbler dir when artifacts_dir is set")
+	}
+}
+
+func TestSaveHistory_ArtifactsDir(t *testing.T) {
+	t.Parallel()
+	cobblerDir := t.TempDir()
+	artifactsDir := t.TempDir()
+	o := New(Config{Cobbler: CobblerConfig{Dir: cobblerDir, ArtifactsDir: artifactsDir}})
+
+	issuesFile := filepath.Join(t.TempDir(), "issues.yaml")
+	os.WriteFile(issuesFile, []byte("- title: x\n"), 0o644)
+	o.saveHistory("2026-02-28-12-00-00", []byte("raw output"), issuesFile)
+
+	// The default relative HistoryDir ("history") resolves under ArtifactsDir.
+	for _, name := range []string{
+		"2026-02-28-12-00-00-measure-issues.yaml",
+		"2026-02-28-12-00-00-measure-log.log",
+	} {
+		if _, err := os.Stat(filepath.Join(artifactsDir, "history", name)); err != nil {
+			t.Errorf("expected %s in artifacts history: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(cobblerDir, "history")); !os.IsNotExist(err) {
+		t.Errorf("history should not be created under the cobbler dir when artifacts_dir is set")
+	}
+}
+
 // --- saveHistory ---
 
 func TestSaveHistory_WritesIssuesFile(t *testing.T) {
@@ -736,6 +1151,58 @@ func TestSaveHistory_WritesIssuesFile(t *testing.T) {
 	}
 }
 
+func TestSaveHistory_DefaultNaming(t *testing.T) {
+	t.Parallel()
+	histDir := t.TempDir()
+	o := New(Config{})
+	o.cfg.Cobbler.Dir = t.TempDir()
+	o.cfg.Cobbler.HistoryDir = histDir
+
+	issuesFile := filepath.Join(t.TempDir(), "issues.yaml")
+	os.WriteFile(issuesFile, []byte("- title: x\n"), 0o644)
+	o.saveHistory("2026-02-28-12-00-00", []byte("raw output"), issuesFile)
+
+	// The default template reproduces the names measure has always used.
+	entries, err := os.ReadDir(histDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+	want := []string{
+		"2026-02-28-12-00-00-measure-issues.yaml",
+		"2026-02-28-12-00-00-measure-log.log",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("history files = %v, want %v", got, want)
+	}
+}
+
+// Not parallel: sets the package-level generation name.
+func TestSaveHistory_CustomNamingWithGeneration(t *testing.T) {
+	histDir := t.TempDir()
+	o := New(Config{Cobbler: CobblerConfig{HistoryFileNaming: "{{.Timestamp}}-{{.Generation}}-{{.Type}}"}})
+	o.cfg.Cobbler.Dir = t.TempDir()
+	o.cfg.Cobbler.HistoryDir = histDir
+	setGeneration("generation-abc")
+	defer clearGeneration()
+
+	issuesFile := filepath.Join(t.TempDir(), "issues.yaml")
+	os.WriteFile(issuesFile, []byte("- title: x\n"), 0o644)
+	o.saveHistory("2026-02-28-12-00-00", []byte("raw output"), issuesFile)
+
+	for _, name := range []string{
+		"2026-02-28-12-00-00-generation-abc-issues.yaml",
+		"2026-02-28-12-00-00-generation-abc-log.log",
+	} {
+		if _, err := os.Stat(filepath.Join(histDir, name)); err != nil {
+			t.Errorf("expected %s in history: %v", name, err)
+		}
+	}
+}
+
 func TestSaveHistory_NoHistoryDir(t *testing.T) {
 	t.Parallel()
 	o := New(Config{})
@@ -744,6 +1211,44 @@ func TestSaveHistory_NoHistoryDir(t *testing.T) {
 	// No panic is the assertion.
 }
 
+func TestSaveHistoryIssueIndex_RecordsIDs(t *testing.T) {
+	t.Parallel()
+	histDir := t.TempDir()
+	o := New(Config{})
+	o.cfg.Cobbler.Dir = t.TempDir()
+	o.cfg.Cobbler.HistoryDir = histDir
+
+	o.saveHistoryIssueIndex("2026-02-28-12-00-00", []string{"cs-1", "cs-2"})
+	o.saveHistoryIssueIndex("2026-02-28-13-00-00", []string{"cs-3"})
+
+	data, err := os.ReadFile(filepath.Join(histDir, historyIssueIndexFile))
+	if err != nil {
+		t.Fatalf("index not written: %v", err)
+	}
+	var index map[string][]string
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		t.Fatalf("parsing index: %v", err)
+	}
+	if got := index["2026-02-28-12-00-00"]; !reflect.DeepEqual(got, []string{"cs-1", "cs-2"}) {
+		t.Errorf("first run ids = %v, want [cs-1 cs-2]", got)
+	}
+	if got := index["2026-02-28-13-00-00"]; !reflect.DeepEqual(got, []string{"cs-3"}) {
+		t.Errorf("second run ids = %v, want [cs-3]", got)
+	}
+}
+
+func TestSaveHistoryIssueIndex_NoHistoryDir(t *testing.T) {
+	t.Parallel()
+	cobblerDir := t.TempDir()
+	o := New(Config{})
+	o.cfg.Cobbler.Dir = cobblerDir
+	o.cfg.Cobbler.HistoryDir = ""
+	o.saveHistoryIssueIndex("2026-02-28-12-00-00", []string{"cs-1"})
+	if entries, _ := os.ReadDir(cobblerDir); len(entries) != 0 {
+		t.Errorf("expected no files without HistoryDir, got %d", len(entries))
+	}
+}
+
 func TestSaveHistory_MissingIssuesFile(t *testing.T) {
 	t.Parallel()
 	histDir := t.TempDir()
@@ -781,6 +1286,34 @@ func TestBuildMeasurePrompt_DefaultConfig(t *testing.T) {
 	}
 }
 
+func TestBuildMeasurePrompt_Footer(t *testing.T) {
+	t.Parallel()
+	o := New(Config{})
+
+	prompt, err := o.buildMeasurePrompt("Focus on testing", "", 1)
+	if err != nil {
+		t.Fatalf("buildMeasurePrompt() error = %v", err)
+	}
+	if strings.Contains(prompt, "footer:") {
+		t.Error("prompt has a footer without measure_prompt_footer")
+	}
+
+	o.cfg.Cobbler.MeasurePromptFooter = "Follow the repo conventions."
+	prompt, err = o.buildMeasurePrompt("Focus on testing", "", 1)
+	if err != nil {
+		t.Fatalf("buildMeasurePrompt() error = %v", err)
+	}
+	footer := strings.Index(prompt, "footer: Follow the repo conventions.")
+	if footer < 0 {
+		t.Fatalf("prompt missing footer:\n%s", prompt)
+	}
+	for _, section := range []string{"planning_constitution:", "issue_format_constitution:", "task:", "additional_context:"} {
+		if i := strings.Index(prompt, section); i < 0 || i > footer {
+			t.Errorf("section %q at %d, want before footer at %d", section, i, footer)
+		}
+	}
+}
+
 func TestBuildMeasurePrompt_PlaceholderSubstitution(t *testing.T) {
 	t.Parallel()
 	o := New(Config{})
@@ -871,22 +1404,56 @@ func TestBuildMeasurePrompt_GoldenExample(t *testing.T) {
 	}
 }
 
-// --- importIssuesImpl YAML parsing ---
-
-func TestImportIssuesImpl_NonexistentFile(t *testing.T) {
+func TestGoldenExampleText(t *testing.T) {
 	t.Parallel()
-	o := New(Config{})
-	_, err := o.importIssuesImpl("/nonexistent/file.yaml", "owner/repo", "gen", false)
-	if err == nil {
-		t.Error("expected error for nonexistent file")
+	if got := goldenExampleText("single", map[string]string{}); got != "single" {
+		t.Errorf("empty map: got %q, want single example", got)
+	}
+	if got := goldenExampleText("", map[string]string{"code": "code ex\n"}); got != "## Golden Example: Code\n\ncode ex" {
+		t.Errorf("single type: got %q", got)
+	}
+	got := goldenExampleText("", map[string]string{"documentation": "doc ex", "code": "code ex"})
+	want := "## Golden Example: Code\n\ncode ex\n\n## Golden Example: Documentation\n\ndoc ex"
+	if got != want {
+		t.Errorf("multiple types: got %q, want %q", got, want)
 	}
 }
 
-func TestImportIssuesImpl_InvalidYAML(t *testing.T) {
+func TestBuildMeasurePrompt_GoldenExamplesTakePrecedence(t *testing.T) {
 	t.Parallel()
-	dir := t.TempDir()
-	yamlFile := filepath.Join(dir, "bad.yaml")
-	os.WriteFile(yamlFile, []byte("{{{not valid yaml"), 0o644)
+	cfg := Config{}
+	cfg.Cobbler.GoldenExample = "legacy single example"
+	cfg.Cobbler.GoldenExamples = map[string]string{"code": "typed code example"}
+	o := New(cfg)
+
+	prompt, err := o.buildMeasurePrompt("", "", 1)
+	if err != nil {
+		t.Fatalf("buildMeasurePrompt() error = %v", err)
+	}
+	if !strings.Contains(prompt, "## Golden Example: Code") || !strings.Contains(prompt, "typed code example") {
+		t.Error("prompt should contain the typed golden example")
+	}
+	if strings.Contains(prompt, "legacy single example") {
+		t.Error("GoldenExamples should take precedence over GoldenExample")
+	}
+}
+
+// --- importIssuesImpl YAML parsing ---
+
+func TestImportIssuesImpl_NonexistentFile(t *testing.T) {
+	t.Parallel()
+	o := New(Config{})
+	_, err := o.importIssuesImpl("/nonexistent/file.yaml", "owner/repo", "gen", false)
+	if err == nil {
+		t.Error("expected error for nonexistent file")
+	}
+}
+
+func TestImportIssuesImpl_InvalidYAML(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "bad.yaml")
+	os.WriteFile(yamlFile, []byte("{{{not valid yaml"), 0o644)
 
 	o := New(Config{})
 	_, err := o.importIssuesImpl(yamlFile, "owner/repo", "gen", false)
@@ -909,22 +1476,41 @@ func TestImportIssuesImpl_EmptyIssueList(t *testing.T) {
 	o := New(cfg)
 
 	// Empty list should not error — no issues to create, no GitHub calls.
-	ids, err := o.importIssuesImpl(yamlFile, "owner/repo", "gen", false)
+	res, err := o.importIssuesImpl(yamlFile, "owner/repo", "gen", false)
 	if err != nil {
 		t.Fatalf("importIssuesImpl() error = %v", err)
 	}
+	ids := res.Created
 	if len(ids) != 0 {
 		t.Errorf("expected 0 ids for empty issue list, got %d", len(ids))
 	}
 }
 
+func TestImportIssuesImpl_MappingTopLevel(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "single.yaml")
+	os.WriteFile(yamlFile, []byte("index: 0\ntitle: Lone issue\ndescription: no list dash\n"), 0o644)
+
+	cfg := Config{}
+	cfg.Cobbler.Dir = dir
+	o := New(cfg)
+	_, err := o.importIssuesImpl(yamlFile, "owner/repo", "gen", false)
+	if err == nil {
+		t.Fatal("expected error for mapping top-level")
+	}
+	if !strings.Contains(err.Error(), "expected a list of issues, got mapping") {
+		t.Errorf("error = %v, want mention of 'expected a list of issues, got mapping'", err)
+	}
+}
+
 func TestImportIssuesImpl_ValidationRejectsInEnforcingMode(t *testing.T) {
 	t.Parallel()
 	dir := t.TempDir()
 	yamlFile := filepath.Join(dir, "issues.yaml")
 
 	// Create a code issue with only 1 requirement — violates P9 range 5-8.
-	issues := []proposedIssue{{
+	issues := []ProposedIssue{{
 		Index: 1,
 		Title: "Bad task",
 		Description: `deliverable_type: code
@@ -959,7 +1545,7 @@ func TestImportIssuesImpl_ValidationPassesWhenSkipped(t *testing.T) {
 	yamlFile := filepath.Join(dir, "issues.yaml")
 
 	// Same invalid issue but with skipEnforcement=true.
-	issues := []proposedIssue{{
+	issues := []ProposedIssue{{
 		Index: 1,
 		Title: "Bad task",
 		Description: `deliverable_type: code
@@ -982,14 +1568,771 @@ acceptance_criteria:
 	// skipEnforcement=true should bypass validation errors.
 	// This will fail at createCobblerIssue (no real GitHub), but should NOT
 	// fail at validation.
-	ids, err := o.importIssuesImpl(yamlFile, "owner/repo", "gen", true)
+	res, err := o.importIssuesImpl(yamlFile, "owner/repo", "gen", true)
 	if err != nil {
 		t.Fatalf("importIssuesImpl() with skipEnforcement should not return validation error, got: %v", err)
 	}
+	ids := res.Created
 	// ids will be empty because createCobblerIssue fails (no GitHub), but no error returned.
 	_ = ids
 }
 
+// --- importIssuesImpl rate limiting ---
+
+// stubIssueCreation replaces createIssueFn and sleepFn for the duration of
+// the test. It records the order of creations and waits in events.
+func stubIssueCreation(t *testing.T, events *[]string, waits *[]time.Duration) {
+	t.Helper()
+	origCreate, origSleep := createIssueFn, sleepFn
+	t.Cleanup(func() { createIssueFn, sleepFn = origCreate, origSleep })
+
+	next := 100
+	createIssueFn = func(repo, generation string, issue ProposedIssue) (int, error) {
+		*events = append(*events, "create:"+issue.Title)
+		next++
+		return next, nil
+	}
+	sleepFn = func(ctx context.Context, d time.Duration) error {
+		*events = append(*events, "wait")
+		*waits = append(*waits, d)
+		return nil
+	}
+}
+
+func writeProposedIssues(t *testing.T, dir string, titles ...string) string {
+	t.Helper()
+	var issues []ProposedIssue
+	for i, title := range titles {
+		issues = append(issues, ProposedIssue{Index: i + 1, Title: title, Dependency: -1})
+	}
+	data, err := yaml.Marshal(issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "issues.yaml")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImportIssuesImpl_PrintsSummary(t *testing.T) {
+	// Not parallel: stubs createIssueFn and captures os.Stdout.
+	var events []string
+	var waits []time.Duration
+	stubIssueCreation(t, &events, &waits)
+
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "issues.yaml")
+	os.WriteFile(yamlFile, []byte(`- index: 1
+  title: clean
+  dependency: -1
+- index: 2
+  title: duplicated
+  dependency: -1
+  description: |
+    deliverable_type: documentation
+    acceptance_criteria:
+      - id: AC1
+        text: Same text.
+      - id: AC2
+        text: same text
+- index: 3
+  title: deferred
+  dependency: -1
+  skip: true
+`), 0o644)
+	o := New(Config{Cobbler: CobblerConfig{Dir: dir}})
+
+	var res importResult
+	var err error
+	out := captureStdout(t, func() { res, err = o.importIssuesImpl(yamlFile, "owner/repo", "gen", false) })
+	if err != nil {
+		t.Fatalf("importIssuesImpl: %v", err)
+	}
+	if len(res.Created) != 2 {
+		t.Fatalf("created %v, want 2 issues", res.Created)
+	}
+	want := "Import summary: 2 issue(s) validated, 1 with warnings, 0 cross-issue warning(s), 2 created, 1 skipped\n"
+	if !strings.HasPrefix(out, want) {
+		t.Errorf("summary = %q, want prefix %q", out, want)
+	}
+	if !strings.Contains(out, `  warning: [2] "duplicated": acceptance criteria AC1 and AC2 have identical text`) {
+		t.Errorf("summary should list the validation warnings:\n%s", out)
+	}
+}
+
+func TestWriteImportSummary_CountsCrossIssueWarnings(t *testing.T) {
+	t.Parallel()
+	issues := []ProposedIssue{{Index: 1}, {Index: 2}, {Index: 10}}
+	vr := ValidationResult{Warnings: []string{
+		`[1] "a": too many requirements`,
+		`[1] "a": missing files`,
+		"file pkg/a.go is listed by issues [1 2] (P13 duplicate file path)",
+		"custom rule: batch is too large",
+	}}
+	var buf bytes.Buffer
+	writeImportSummary(&buf, issues, vr, importResult{Created: []string{"7", "8", "9"}})
+	want := "Import summary: 3 issue(s) validated, 1 with warnings, 2 cross-issue warning(s), 3 created, 0 skipped\n"
+	if !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("summary = %q, want prefix %q", buf.String(), want)
+	}
+	if !strings.Contains(buf.String(), "  warning: file pkg/a.go is listed by issues [1 2] (P13 duplicate file path)") {
+		t.Errorf("summary should list the cross-issue warning:\n%s", buf.String())
+	}
+}
+
+func TestImportIssuesImpl_SummaryEmptyAndQuiet(t *testing.T) {
+	// Not parallel: stubs createIssueFn and captures os.Stdout.
+	var events []string
+	var waits []time.Duration
+	stubIssueCreation(t, &events, &waits)
+
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "issues.yaml")
+	os.WriteFile(yamlFile, []byte("[]\n"), 0o644)
+	o := New(Config{Cobbler: CobblerConfig{Dir: dir}})
+	out := captureStdout(t, func() { o.importIssuesImpl(yamlFile, "owner/repo", "gen", false) })
+	if out != "Import summary: 0 issues\n" {
+		t.Errorf("empty import summary = %q, want %q", out, "Import summary: 0 issues\n")
+	}
+
+	o.cfg.Cobbler.QuietImport = true
+	if out := captureStdout(t, func() { o.importIssuesImpl(yamlFile, "owner/repo", "gen", false) }); out != "" {
+		t.Errorf("quiet import printed %q, want nothing", out)
+	}
+}
+
+func TestImportIssuesImpl_SkippedIssueNotCreated(t *testing.T) {
+	var events []string
+	var waits []time.Duration
+	stubIssueCreation(t, &events, &waits)
+
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "issues.yaml")
+	os.WriteFile(yamlFile, []byte(`- index: 1
+  title: normal
+  dependency: -1
+- index: 2
+  title: deferred
+  dependency: -1
+  skip: true
+  skip_reason: duplicate of an open issue
+`), 0o644)
+
+	cfg := Config{}
+	cfg.Cobbler.Dir = dir
+	o := New(cfg)
+
+	res, err := o.importIssuesImpl(yamlFile, "owner/repo", "gen", false)
+	if err != nil {
+		t.Fatalf("importIssuesImpl: %v", err)
+	}
+	if len(res.Created) != 1 || strings.Join(events, ",") != "create:normal" {
+		t.Errorf("created %v (events %v), want only the normal issue", res.Created, events)
+	}
+	want := []skippedIssue{{Index: 2, Title: "deferred", Reason: "duplicate of an open issue"}}
+	if !reflect.DeepEqual(res.Skipped, want) {
+		t.Errorf("Skipped = %+v, want %+v", res.Skipped, want)
+	}
+}
+
+func TestPrepareProposedIssues_ClearsDependencyOnSkippedIssue(t *testing.T) {
+	t.Parallel()
+	o := New(Config{})
+	issues, skipped, vr, err := o.prepareProposedIssues([]byte(`- index: 1
+  title: deferred
+  dependency: -1
+  skip: true
+- index: 2
+  title: follow-up
+  dependency: 1
+- index: 3
+  title: chained
+  dependency: 2
+`))
+	if err != nil {
+		t.Fatalf("prepareProposedIssues: %v", err)
+	}
+	if len(skipped) != 1 || len(issues) != 2 {
+		t.Fatalf("got %d kept and %d skipped, want 2 and 1", len(issues), len(skipped))
+	}
+	if issues[0].Dependency != -1 {
+		t.Errorf("issue 2 dependency = %d, want -1 after its dependency was skipped", issues[0].Dependency)
+	}
+	if issues[1].Dependency != 2 {
+		t.Errorf("issue 3 dependency = %d, want 2 (kept dependency unchanged)", issues[1].Dependency)
+	}
+	want := `[2] "follow-up": depends on skipped issue 1; dependency cleared`
+	if !slices.Contains(vr.Warnings, want) {
+		t.Errorf("warnings = %v, want %q", vr.Warnings, want)
+	}
+}
+
+func TestImportIssuesImpl_DelayAppliedBetweenCreations(t *testing.T) {
+	var events []string
+	var waits []time.Duration
+	stubIssueCreation(t, &events, &waits)
+
+	dir := t.TempDir()
+	yamlFile := writeProposedIssues(t, dir, "a", "b", "c")
+
+	cfg := Config{}
+	cfg.Cobbler.Dir = dir
+	cfg.Cobbler.IssueCreateDelayMs = 250
+	o := New(cfg)
+
+	res, err := o.importIssuesImpl(yamlFile, "owner/repo", "gen", false)
+	if err != nil {
+		t.Fatalf("importIssuesImpl: %v", err)
+	}
+	ids := res.Created
+	if len(ids) != 3 {
+		t.Errorf("got %d ids, want 3", len(ids))
+	}
+	want := []string{"create:a", "wait", "create:b", "wait", "create:c"}
+	if strings.Join(events, ",") != strings.Join(want, ",") {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+	for _, d := range waits {
+		if d != 250*time.Millisecond {
+			t.Errorf("wait = %s, want 250ms", d)
+		}
+	}
+}
+
+func TestImportIssuesImpl_ZeroDelayNoWaits(t *testing.T) {
+	var events []string
+	var waits []time.Duration
+	stubIssueCreation(t, &events, &waits)
+
+	dir := t.TempDir()
+	yamlFile := writeProposedIssues(t, dir, "a", "b")
+
+	cfg := Config{}
+	cfg.Cobbler.Dir = dir
+	o := New(cfg)
+
+	if _, err := o.importIssuesImpl(yamlFile, "owner/repo", "gen", false); err != nil {
+		t.Fatalf("importIssuesImpl: %v", err)
+	}
+	if len(waits) != 0 {
+		t.Errorf("got %d waits with zero delay, want 0", len(waits))
+	}
+}
+
+func TestImportIssuesImpl_InterruptedWaitStopsImport(t *testing.T) {
+	var events []string
+	var waits []time.Duration
+	stubIssueCreation(t, &events, &waits)
+	sleepFn = func(ctx context.Context, d time.Duration) error {
+		return context.Canceled
+	}
+
+	dir := t.TempDir()
+	yamlFile := writeProposedIssues(t, dir, "a", "b", "c")
+
+	cfg := Config{}
+	cfg.Cobbler.Dir = dir
+	cfg.Cobbler.IssueCreateDelayMs = 10
+	o := New(cfg)
+
+	var res importResult
+	var err error
+	captureStdout(t, func() { res, err = o.importIssuesImpl(yamlFile, "owner/repo", "gen", false) })
+	if !errors.Is(err, context.Canceled) || !strings.Contains(err.Error(), "import interrupted after 1 issue(s)") {
+		t.Errorf("importIssuesImpl error = %v, want an interruption error", err)
+	}
+	ids := res.Created
+	if len(ids) != 1 {
+		t.Errorf("got %d ids after interrupt, want 1", len(ids))
+	}
+
+	data, _ := os.ReadFile(filepath.Join(dir, "measure.yaml"))
+	var logged []ProposedIssue
+	yaml.Unmarshal(data, &logged)
+	if len(logged) != 1 || logged[0].Title != "a" {
+		t.Errorf("measure log = %+v, want only the created issue a", logged)
+	}
+}
+
+func TestSleepFn_ReturnsOnCancel(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sleepFn(ctx, time.Hour); err == nil {
+		t.Error("expected error from cancelled wait")
+	}
+}
+
+// --- Requirement ID gaps ---
+
+func TestRequirementIDGaps_Gap(t *testing.T) {
+	t.Parallel()
+	reqs := []issueDescItem{{ID: "R1"}, {ID: "R2"}, {ID: "R4"}, {ID: "R6"}}
+	got := requirementIDGaps(reqs)
+	if strings.Join(got, ",") != "R3,R5" {
+		t.Errorf("requirementIDGaps() = %v, want [R3 R5]", got)
+	}
+}
+
+func TestRequirementIDGaps_Contiguous(t *testing.T) {
+	t.Parallel()
+	reqs := []issueDescItem{{ID: "R2"}, {ID: "R1"}, {ID: "R3"}}
+	if got := requirementIDGaps(reqs); len(got) != 0 {
+		t.Errorf("requirementIDGaps() = %v, want none", got)
+	}
+}
+
+func TestRequirementIDGaps_IgnoresNonNumericAndPrefixed(t *testing.T) {
+	t.Parallel()
+	reqs := []issueDescItem{{ID: "R1"}, {ID: "prd001-R3"}, {ID: "R2a"}, {ID: "NFR5"}}
+	if got := requirementIDGaps(reqs); len(got) != 0 {
+		t.Errorf("requirementIDGaps() = %v, want none", got)
+	}
+}
+
+func TestValidateMeasureOutput_ReqIDGapsGatedByConfig(t *testing.T) {
+	t.Parallel()
+	issues := []ProposedIssue{{
+		Index: 1,
+		Title: "Gappy",
+		Description: `deliverable_type: documentation
+requirements:
+  - id: R1
+    text: a
+  - id: R3
+    text: b
+acceptance_criteria:
+  - id: AC1
+    text: a
+  - id: AC2
+    text: b
+  - id: AC3
+    text: c
+`,
+	}}
+
+	off := validateMeasureOutput(issues, measureRules{})
+	if len(off.Warnings) != 0 {
+		t.Errorf("gap check disabled: got warnings %v", off.Warnings)
+	}
+	on := validateMeasureOutput(issues, measureRules{WarnReqIDGaps: true})
+	if len(on.Warnings) != 1 || !strings.Contains(on.Warnings[0], "R2") {
+		t.Errorf("gap check enabled: got warnings %v, want one listing R2", on.Warnings)
+	}
+	if on.HasErrors() {
+		t.Errorf("gaps should be advisory only, got errors %v", on.Errors)
+	}
+}
+
+// --- Requirement ID format ---
+
+// idFormatIssue returns a documentation issue whose requirement IDs are
+// reqIDs, with conforming acceptance criteria and design decisions.
+func idFormatIssue(reqIDs ...string) []ProposedIssue {
+	var b strings.Builder
+	b.WriteString("deliverable_type: documentation\nrequirements:\n")
+	for _, id := range reqIDs {
+		fmt.Fprintf(&b, "  - id: %s\n    text: r\n", id)
+	}
+	b.WriteString("acceptance_criteria:\n")
+	for i := 1; i <= 3; i++ {
+		fmt.Fprintf(&b, "  - id: AC%d\n    text: a%d\n", i, i)
+	}
+	b.WriteString("design_decisions:\n  - id: D1\n    text: d\n")
+	return []ProposedIssue{{Index: 1, Title: "Ids", Description: b.String()}}
+}
+
+// acTextIssue returns a documentation issue whose acceptance criteria
+// have the given texts, with IDs AC1, AC2, ...
+func acTextIssue(texts ...string) []ProposedIssue {
+	var b strings.Builder
+	b.WriteString("deliverable_type: documentation\nrequirements:\n  - id: R1\n    text: r1\n  - id: R2\n    text: r2\n")
+	b.WriteString("acceptance_criteria:\n")
+	for i, text := range texts {
+		fmt.Fprintf(&b, "  - id: AC%d\n    text: %q\n", i+1, text)
+	}
+	return []ProposedIssue{{Index: 1, Title: "Docs", Description: b.String()}}
+}
+
+func TestValidateMeasureOutput_DuplicateACText(t *testing.T) {
+	t.Parallel()
+	vr := validateMeasureOutput(acTextIssue(
+		"The README lists every target.",
+		"Examples compile.",
+		"the  README lists every target",
+	), measureRules{})
+	want := `[1] "Docs": acceptance criteria AC1 and AC3 have identical text`
+	if len(vr.Warnings) != 1 || vr.Warnings[0] != want {
+		t.Errorf("warnings = %v, want [%s]", vr.Warnings, want)
+	}
+	if len(vr.Errors) != 0 {
+		t.Errorf("errors = %v, want none (duplicate text is a warning)", vr.Errors)
+	}
+}
+
+func TestValidateMeasureOutput_UniqueACText(t *testing.T) {
+	t.Parallel()
+	vr := validateMeasureOutput(acTextIssue("First check.", "Second check.", "Third check."), measureRules{})
+	if len(vr.Warnings) != 0 {
+		t.Errorf("got warnings %v, want none", vr.Warnings)
+	}
+}
+
+// typedFilesIssue returns an issue of the given deliverable_type listing paths.
+func typedFilesIssue(deliverable string, paths ...string) []ProposedIssue {
+	var b strings.Builder
+	fmt.Fprintf(&b, "deliverable_type: %s\nfiles:\n", deliverable)
+	for _, p := range paths {
+		fmt.Fprintf(&b, "  - path: %s\n", p)
+	}
+	return []ProposedIssue{{Index: 1, Title: "Task", Description: b.String()}}
+}
+
+func TestValidateMeasureOutput_DeliverableExtensionMismatch(t *testing.T) {
+	t.Parallel()
+	rules := measureRules{Extensions: New(Config{}).Config().Cobbler.DeliverableExtensions}
+	vr := validateMeasureOutput(typedFilesIssue("documentation", "pkg/auth/token.go", "pkg/auth/token_test.go"), rules)
+	want := `[1] "Task": documentation task lists no .md/.yaml/.yml files; check deliverable_type`
+	if !slices.Contains(vr.Warnings, want) {
+		t.Errorf("warnings = %v, want %s", vr.Warnings, want)
+	}
+	for _, e := range vr.Errors {
+		if strings.Contains(e, "check deliverable_type") {
+			t.Errorf("extension mismatch should be a warning, got error %s", e)
+		}
+	}
+}
+
+func TestValidateMeasureOutput_DeliverableExtensionMatch(t *testing.T) {
+	t.Parallel()
+	rules := measureRules{Extensions: map[string][]string{"code": {".go"}, "documentation": {".md"}}}
+	cases := map[string][]ProposedIssue{
+		"code with one go file":   typedFilesIssue("code", "docs/design.md", "pkg/auth/token.go"),
+		"documentation uppercase": typedFilesIssue("documentation", "README.MD"),
+		"unconfigured type":       typedFilesIssue("test", "pkg/auth/token.md"),
+		"no files":                typedFilesIssue("code"),
+	}
+	for name, issues := range cases {
+		vr := validateMeasureOutput(issues, rules)
+		for _, w := range vr.Warnings {
+			if strings.Contains(w, "check deliverable_type") {
+				t.Errorf("%s: unexpected warning %s", name, w)
+			}
+		}
+	}
+}
+
+func TestValidateMeasureOutput_IDFormatAllValid(t *testing.T) {
+	t.Parallel()
+	vr := validateMeasureOutput(idFormatIssue("R1", "R2", "R3"), measureRules{EnforceIDForm: true})
+	if len(vr.Warnings) != 0 {
+		t.Errorf("got warnings %v, want none", vr.Warnings)
+	}
+}
+
+func TestValidateMeasureOutput_IDFormatNonConforming(t *testing.T) {
+	t.Parallel()
+	vr := validateMeasureOutput(idFormatIssue("R1", "REQ-SECURITY-1", "R3"), measureRules{EnforceIDForm: true})
+	if len(vr.Warnings) != 1 || !strings.Contains(vr.Warnings[0], `"REQ-SECURITY-1" does not match R<n>`) {
+		t.Errorf("got warnings %v, want one for REQ-SECURITY-1", vr.Warnings)
+	}
+	if vr.HasErrors() {
+		t.Errorf("ID format should be advisory only, got errors %v", vr.Errors)
+	}
+}
+
+func TestValidateMeasureOutput_IDFormatDisabled(t *testing.T) {
+	t.Parallel()
+	vr := validateMeasureOutput(idFormatIssue("R1", "REQ-SECURITY-1", "R3"), measureRules{})
+	if len(vr.Warnings) != 0 {
+		t.Errorf("got warnings %v, want none with enforcement disabled", vr.Warnings)
+	}
+}
+
+func TestValidateMeasureOutput_BlankRequirementText(t *testing.T) {
+	t.Parallel()
+	desc := "deliverable_type: documentation\nrequirements:\n  - id: R1\n    text: r1\n  - id: R2\n    text: \"  \"\n" +
+		"acceptance_criteria:\n  - id: AC1\n    text: a1\n  - id: AC2\n    text: a2\n  - id: AC3\n" +
+		"design_decisions:\n  - id: D1\n    text: d\n"
+	vr := validateMeasureOutput([]ProposedIssue{{Index: 1, Title: "Blank", Description: desc}}, measureRules{})
+	for _, want := range []string{
+		`[1] "Blank": requirement R2 has empty text`,
+		`[1] "Blank": acceptance criterion AC3 has empty text`,
+	} {
+		if !slices.Contains(vr.Errors, want) {
+			t.Errorf("errors = %v, want %s", vr.Errors, want)
+		}
+	}
+	if len(vr.Errors) != 2 {
+		t.Errorf("got %d errors %v, want 2", len(vr.Errors), vr.Errors)
+	}
+}
+
+func TestNonConformingItemIDs_ACAndDesignDecisions(t *testing.T) {
+	t.Parallel()
+	desc := issueDescription{
+		AcceptanceCriteria: []issueDescItem{{ID: "AC1"}, {ID: "A2"}},
+		DesignDecisions:    []issueDescItem{{ID: "DD-1"}, {ID: "D2"}},
+	}
+	got := nonConformingItemIDs(desc)
+	if len(got) != 2 || !strings.Contains(got[0], `"A2"`) || !strings.Contains(got[1], `"DD-1"`) {
+		t.Errorf("nonConformingItemIDs() = %v, want A2 and DD-1", got)
+	}
+}
+
+// --- MeasureIssueFilter ---
+
+func filterTestIssues() []ProposedIssue {
+	return []ProposedIssue{
+		{Index: 1, Title: "code task", Description: "deliverable_type: code\n"},
+		{Index: 2, Title: "doc task", Description: "deliverable_type: documentation\n"},
+		{Index: 3, Title: "unparseable", Description: "{{{not yaml"},
+	}
+}
+
+func TestFilterProposedIssues_NilFilterKeepsAll(t *testing.T) {
+	t.Parallel()
+	got := filterProposedIssues(filterTestIssues(), nil)
+	if len(got) != 3 {
+		t.Errorf("got %d issues, want 3", len(got))
+	}
+}
+
+func TestFilterProposedIssues_AlwaysFalseDropsAll(t *testing.T) {
+	t.Parallel()
+	got := filterProposedIssues(filterTestIssues(), func(ProposedIssue) bool { return false })
+	if len(got) != 0 {
+		t.Errorf("got %d issues, want 0", len(got))
+	}
+}
+
+func TestFilterProposedIssues_ByDeliverableType(t *testing.T) {
+	t.Parallel()
+	got := filterProposedIssues(filterTestIssues(), func(issue ProposedIssue) bool {
+		return issue.Parsed != nil && issue.Parsed.DeliverableType == "code"
+	})
+	if len(got) != 1 || got[0].Title != "code task" {
+		t.Errorf("got %+v, want only the code task", got)
+	}
+}
+
+func TestFilterProposedIssues_ParsedNilForInvalidYAML(t *testing.T) {
+	t.Parallel()
+	var sawInvalid bool
+	filterProposedIssues(filterTestIssues(), func(issue ProposedIssue) bool {
+		if issue.Index == 3 {
+			sawInvalid = true
+			if issue.Parsed != nil {
+				t.Error("Parsed should be nil for unparseable description")
+			}
+		}
+		return true
+	})
+	if !sawInvalid {
+		t.Error("filter was not called for the unparseable issue")
+	}
+}
+
+func TestImportIssuesImpl_FilterDropsBeforeCreation(t *testing.T) {
+	var events []string
+	var waits []time.Duration
+	stubIssueCreation(t, &events, &waits)
+
+	dir := t.TempDir()
+	yamlFile := writeProposedIssues(t, dir, "keep", "drop")
+
+	cfg := Config{}
+	cfg.Cobbler.Dir = dir
+	cfg.Cobbler.MeasureIssueFilter = func(issue ProposedIssue) bool { return issue.Title != "drop" }
+	o := New(cfg)
+
+	res, err := o.importIssuesImpl(yamlFile, "owner/repo", "gen", false)
+	if err != nil {
+		t.Fatalf("importIssuesImpl: %v", err)
+	}
+	ids := res.Created
+	if len(ids) != 1 {
+		t.Errorf("got %d ids, want 1", len(ids))
+	}
+	if strings.Join(events, ",") != "create:keep" {
+		t.Errorf("events = %v, want [create:keep]", events)
+	}
+}
+
+// Not parallel: replaces package-level hooks.
+func TestImportIssuesImpl_DefaultEffortRoundTrips(t *testing.T) {
+	var events []string
+	var waits []time.Duration
+	stubIssueCreation(t, &events, &waits)
+
+	dir := t.TempDir()
+	issues := []ProposedIssue{
+		{Index: 1, Title: "sized", Dependency: -1, Effort: "L"},
+		{Index: 2, Title: "unsized", Dependency: -1},
+	}
+	data, _ := yaml.Marshal(issues)
+	yamlFile := filepath.Join(dir, "issues.yaml")
+	os.WriteFile(yamlFile, data, 0o644)
+
+	cfg := Config{}
+	cfg.Cobbler.Dir = dir
+	o := New(cfg)
+	if _, err := o.importIssuesImpl(yamlFile, "owner/repo", "gen", false); err != nil {
+		t.Fatalf("importIssuesImpl: %v", err)
+	}
+
+	logData, err := os.ReadFile(filepath.Join(dir, "measure.yaml"))
+	if err != nil {
+		t.Fatalf("measure.yaml not written: %v", err)
+	}
+	var loaded []ProposedIssue
+	if err := yaml.Unmarshal(logData, &loaded); err != nil {
+		t.Fatalf("measure.yaml unmarshal: %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("expected 2 issues in measure.yaml, got %d", len(loaded))
+	}
+	if loaded[0].Effort != "L" {
+		t.Errorf("explicit effort = %q, want L", loaded[0].Effort)
+	}
+	if loaded[1].Effort != "M" {
+		t.Errorf("default effort = %q, want M", loaded[1].Effort)
+	}
+}
+
+// JSON is a subset of YAML, so importIssuesImpl accepts either format.
+// Not parallel: replaces package-level hooks.
+func TestImportIssuesImpl_YAML_vs_JSON_parity(t *testing.T) {
+	issues := []ProposedIssue{
+		{Index: 0, Title: "valid doc", Dependency: -1, Effort: "S", Description: `deliverable_type: documentation
+requirements:
+  - id: R1
+    text: a
+  - id: R2
+    text: b
+acceptance_criteria:
+  - id: AC1
+    text: a
+  - id: AC2
+    text: b
+  - id: AC3
+    text: c
+`},
+		{Index: 1, Title: "too few reqs", Dependency: 0, Description: "deliverable_type: code\nrequirements:\n  - id: R1\n    text: a\n"},
+		{Index: 2, Title: "bad effort", Dependency: -1, Effort: "XXL", Description: "deliverable_type: other\n"},
+		{Index: 3, Title: "unparseable", Dependency: -1, Description: "{{{not yaml"},
+	}
+	yamlData, err := yaml.Marshal(issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonData, err := json.Marshal(issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{}
+	cfg.Cobbler.EnforceMeasureValidation = true
+	formats := []struct {
+		name string
+		file string
+		data []byte
+	}{
+		{"yaml", "issues.yaml", yamlData},
+		{"json", "issues.json", jsonData},
+	}
+
+	var parsed [][]ProposedIssue
+	var results []ValidationResult
+	var importErrs []string
+	for _, f := range formats {
+		var events []string
+		var waits []time.Duration
+		stubIssueCreation(t, &events, &waits)
+
+		dir := t.TempDir()
+		path := filepath.Join(dir, f.file)
+		if err := os.WriteFile(path, f.data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		var got []ProposedIssue
+		if err := yaml.Unmarshal(f.data, &got); err != nil {
+			t.Fatalf("%s: parse: %v", f.name, err)
+		}
+		parsed = append(parsed, got)
+
+		c := cfg
+		c.Cobbler.Dir = dir
+		o := New(c)
+		results = append(results, validateMeasureOutput(got, o.measureRules()))
+
+		// Enforcing mode rejects the batch before any issue is created, so
+		// importIssuesImpl acts as a validation-only pass.
+		_, err := o.importIssuesImpl(path, "owner/repo", "gen", false)
+		if err == nil {
+			t.Fatalf("%s: expected validation failure, got nil", f.name)
+		}
+		if len(events) != 0 {
+			t.Errorf("%s: expected no issue creation, got %v", f.name, events)
+		}
+		importErrs = append(importErrs, err.Error())
+	}
+
+	if !reflect.DeepEqual(parsed[0], parsed[1]) {
+		t.Errorf("parsed issues differ:\nyaml: %+v\njson: %+v", parsed[0], parsed[1])
+	}
+	if !reflect.DeepEqual(results[0], results[1]) {
+		t.Errorf("validation results differ:\nyaml: %+v\njson: %+v", results[0], results[1])
+	}
+	if importErrs[0] != importErrs[1] {
+		t.Errorf("import errors differ:\nyaml: %s\njson: %s", importErrs[0], importErrs[1])
+	}
+}
+
+// --- effort validation ---
+
+func TestValidateEffort(t *testing.T) {
+	t.Parallel()
+	labels := measureRules{EffortValues: []string{"S", "M", "L"}}
+	hours := measureRules{EffortValues: []string{"S", "M", "L"}, EffortMaxHours: 16}
+	tests := []struct {
+		name    string
+		effort  string
+		rules   measureRules
+		wantErr bool
+	}{
+		{"label", "M", labels, false},
+		{"label case-insensitive", "l", labels, false},
+		{"unknown label", "XL", labels, true},
+		{"empty", "", labels, false},
+		{"no rules", "anything", measureRules{}, false},
+		{"hours disabled", "4", labels, true},
+		{"hours in range", "4", hours, false},
+		{"hours above range", "40", hours, true},
+		{"hours below range", "0", hours, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := validateEffort(tc.effort, tc.rules)
+			if (got != "") != tc.wantErr {
+				t.Errorf("validateEffort(%q) = %q, wantErr %v", tc.effort, got, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMeasureOutput_InvalidEffortIsError(t *testing.T) {
+	t.Parallel()
+	issues := []ProposedIssue{{Index: 3, Title: "Huge", Description: "deliverable_type: other\n", Effort: "XXL"}}
+	vr := validateMeasureOutput(issues, measureRules{EffortValues: []string{"S", "M", "L"}})
+	if len(vr.Errors) != 1 || !strings.Contains(vr.Errors[0], "XXL") {
+		t.Errorf("expected one error naming XXL, got %v", vr.Errors)
+	}
+}
+
 // --- MeasurePrompt (stdout entry point) ---
 
 func TestMeasurePrompt_ProducesOutput(t *testing.T) {
@@ -1010,6 +2353,192 @@ func TestMeasurePrompt_ProducesOutput(t *testing.T) {
 	}
 }
 
+// --- MeasureDryRun (Claude fixture) ---
+
+// writeClaudeFixture writes a stream-json Claude transcript whose assistant
+// text is text, followed by a result event with token usage.
+func writeClaudeFixture(t *testing.T, text string) string {
+	t.Helper()
+	assistant, _ := json.Marshal(map[string]any{
+		"type":    "assistant",
+		"message": map[string]any{"content": []map[string]string{{"type": "text", "text": text}}},
+	})
+	result := `{"type":"result","total_cost_usd":0.5,"usage":{"input_tokens":120,"output_tokens":30}}`
+	path := filepath.Join(t.TempDir(), "claude.jsonl")
+	if err := os.WriteFile(path, []byte(string(assistant)+"\n"+result+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// Not parallel: uses os.Chdir and replaces createIssueFn.
+func TestMeasureDryRun_FixtureCreatesNoIssues(t *testing.T) {
+	chdirTemp(t)
+	origCreate := createIssueFn
+	t.Cleanup(func() { createIssueFn = origCreate })
+	createIssueFn = func(repo, generation string, issue ProposedIssue) (int, error) {
+		t.Errorf("dry run must not create issues, got %q", issue.Title)
+		return 0, nil
+	}
+
+	cfg := Config{}
+	cfg.Claude.Fixture = writeClaudeFixture(t, "```yaml\n- index: 1\n  title: Add parser\n  dependency: -1\n```\n")
+	cfg.Cobbler.DefaultEffort = "M"
+	o := New(cfg)
+	o.cfg.Cobbler.Dir = t.TempDir()
+
+	var buf bytes.Buffer
+	if err := o.measureDryRun(&buf); err != nil {
+		t.Fatalf("measureDryRun: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "would create 1 issue(s)") || !strings.Contains(out, "[1] Add parser (dep=-1, effort=M)") {
+		t.Errorf("unexpected dry run output:\n%s", out)
+	}
+	if !strings.Contains(out, "Critical path: 1 sequential stitch cycle(s): Add parser") {
+		t.Errorf("dry run output should report the critical path:\n%s", out)
+	}
+}
+
+// Not parallel: uses os.Chdir.
+func TestMeasureDryRun_TextOutputFormat(t *testing.T) {
+	chdirTemp(t)
+	fixture := filepath.Join(t.TempDir(), "claude.txt")
+	text := "Here is the task.\n\n```yaml\n- index: 1\n  title: Add parser\n  dependency: -1\n```\n\n" +
+		"Usage: 120 input, 30 output, 0 cache read, 0 cache write\n"
+	if err := os.WriteFile(fixture, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{}
+	cfg.Claude.Fixture = fixture
+	cfg.Claude.OutputFormat = claudeOutputText
+	o := New(cfg)
+	o.cfg.Cobbler.Dir = t.TempDir()
+
+	var buf bytes.Buffer
+	if err := o.measureDryRun(&buf); err != nil {
+		t.Fatalf("measureDryRun in text mode: %v", err)
+	}
+	if !strings.Contains(buf.String(), "[1] Add parser") {
+		t.Errorf("unexpected dry run output:\n%s", buf.String())
+	}
+}
+
+// Not parallel: uses os.Chdir.
+func TestMeasureDryRun_EnforcedValidationFails(t *testing.T) {
+	chdirTemp(t)
+	cfg := Config{}
+	cfg.Claude.Fixture = writeClaudeFixture(t, "```yaml\n- index: 1\n  title: Bad effort\n  effort: XXL\n```\n")
+	cfg.Cobbler.EffortValues = []string{"S", "M", "L"}
+	cfg.Cobbler.EnforceMeasureValidation = true
+	o := New(cfg)
+	o.cfg.Cobbler.Dir = t.TempDir()
+
+	var buf bytes.Buffer
+	if err := o.measureDryRun(&buf); err == nil {
+		t.Fatal("expected validation error in enforcing mode")
+	}
+	if !strings.Contains(buf.String(), "error: ") {
+		t.Errorf("dry run output should list the validation error:\n%s", buf.String())
+	}
+}
+
+// --- RequireMeasureContext ---
+
+// Not parallel: uses os.Chdir.
+func TestRunMeasure_RefusesEmptyContext(t *testing.T) {
+	chdirTemp(t)
+	cfg := Config{}
+	cfg.Claude.Fixture = writeClaudeFixture(t, "```yaml\n- index: 1\n  title: Invented task\n```\n")
+	cfg.Cobbler.RequireMeasureContext = true
+	o := New(cfg)
+	o.cfg.Cobbler.Dir = t.TempDir()
+	o.cfg.Cobbler.HistoryDir = ""
+
+	err := o.RunMeasure()
+	if err == nil || !strings.Contains(err.Error(), "measure project context is empty") {
+		t.Fatalf("RunMeasure error = %v, want empty context refusal", err)
+	}
+	if !strings.Contains(err.Error(), "measureForce") {
+		t.Errorf("error should point at the force option: %v", err)
+	}
+}
+
+// Not parallel: uses os.Chdir.
+func TestCheckMeasureContext_RoadmapPresent(t *testing.T) {
+	dir := chdirTemp(t)
+	os.MkdirAll(filepath.Join(dir, "docs"), 0o755)
+	os.WriteFile(filepath.Join(dir, "docs", "road-map.yaml"), []byte(roadmapYAML), 0o644)
+	o := New(Config{Cobbler: CobblerConfig{RequireMeasureContext: true}})
+	if err := o.checkMeasureContext(); err != nil {
+		t.Errorf("checkMeasureContext with a roadmap: %v", err)
+	}
+}
+
+// --- MaxMeasureTokens ---
+
+// Not parallel: uses os.Chdir.
+func TestMeasureDryRun_TokenBudgetHardModeSkipsClaude(t *testing.T) {
+	chdirTemp(t)
+	cfg := Config{}
+	// A missing fixture makes any Claude call fail with a different error.
+	cfg.Claude.Fixture = filepath.Join(t.TempDir(), "missing.jsonl")
+	cfg.Cobbler.MaxMeasureTokens = 1
+	o := New(cfg)
+	o.cfg.Cobbler.Dir = t.TempDir()
+
+	err := o.measureDryRun(io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "over max_measure_tokens 1") {
+		t.Fatalf("measureDryRun error = %v, want token budget error", err)
+	}
+}
+
+// Not parallel: uses os.Chdir and captures stderr.
+func TestMeasureDryRun_TokenBudgetWarnOnlyProceeds(t *testing.T) {
+	chdirTemp(t)
+	cfg := Config{}
+	cfg.Claude.Fixture = writeClaudeFixture(t, "```yaml\n- index: 1\n  title: Add parser\n```\n")
+	cfg.Cobbler.MaxMeasureTokens = 1
+	cfg.Cobbler.MaxMeasureTokensWarnOnly = true
+	o := New(cfg)
+	o.cfg.Cobbler.Dir = t.TempDir()
+
+	var buf bytes.Buffer
+	var err error
+	stderr := captureStderr(t, func() { err = o.measureDryRun(&buf) })
+	if err != nil {
+		t.Fatalf("measureDryRun: %v", err)
+	}
+	if !strings.Contains(stderr, "over max_measure_tokens 1; proceeding") {
+		t.Errorf("missing token budget warning in stderr:\n%s", stderr)
+	}
+	if !strings.Contains(buf.String(), "would create 1 issue(s)") {
+		t.Errorf("Claude output not processed in warn-only mode:\n%s", buf.String())
+	}
+}
+
+func TestCheckMeasureTokenBudget_ZeroLimitDisabled(t *testing.T) {
+	t.Parallel()
+	prompt := strings.Repeat("x", 4000)
+	for _, warnOnly := range []bool{false, true} {
+		o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{MaxMeasureTokensWarnOnly: warnOnly}}}
+		if err := o.checkMeasureTokenBudget(prompt); err != nil {
+			t.Errorf("warnOnly=%v: checkMeasureTokenBudget = %v, want nil with zero limit", warnOnly, err)
+		}
+	}
+}
+
+func TestCheckMeasureTokenBudget_WithinLimit(t *testing.T) {
+	t.Parallel()
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{MaxMeasureTokens: 1000}}}
+	if err := o.checkMeasureTokenBudget(strings.Repeat("x", 4000)); err != nil {
+		t.Errorf("checkMeasureTokenBudget at the limit = %v, want nil", err)
+	}
+	if err := o.checkMeasureTokenBudget(strings.Repeat("x", 4004)); err == nil {
+		t.Error("expected error one token over the limit")
+	}
+}
+
 // contains checks if substr is in s. Avoids importing strings in test.
 func contains(s, substr string) bool {
 	for i := 0; i+len(substr) <= len(s); i++ {
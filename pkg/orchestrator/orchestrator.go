@@ -4,30 +4,82 @@
 package orchestrator
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"sync"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Orchestrator provides Claude Code orchestration operations.
 // Create one with New() and call its methods from mage targets.
 type Orchestrator struct {
 	cfg Config
+
+	// validationRules holds custom measure validation rules added with
+	// RegisterValidationRule.
+	validationRules []ValidationRule
+
+	// repoRoot is the repository directory whose shared .git holds the
+	// seed manifest. New sets it to the working directory; when empty no
+	// manifest is kept.
+	repoRoot string
 }
 
 // New creates an Orchestrator with the given configuration.
-// It applies defaults to any zero-value Config fields.
+// It applies defaults to any zero-value Config fields and logs a warning
+// for each directory setting that could delete production code.
 func New(cfg Config) *Orchestrator {
 	cfg.applyDefaults()
-	return &Orchestrator{cfg: cfg}
+	for _, w := range validateDangerousPaths(cfg) {
+		logf("config warning: %s", w)
+	}
+	repoRoot, _ := os.Getwd()
+	return &Orchestrator{cfg: cfg, repoRoot: repoRoot}
 }
 
 // Config returns a copy of the Orchestrator's configuration.
 func (o *Orchestrator) Config() Config { return o.cfg }
 
+// ConfigShow prints the configuration this Orchestrator uses, after
+// defaults and environment overrides, as YAML to stdout. It dumps the
+// Config held since New and does not re-read the file. Fields that
+// LoadConfig resolves from files (prompts, constitutions, seed files)
+// show the loaded content. Credentials are never read: only the token
+// file name and its resolved path appear.
+//
+// Exposed as a mage target (e.g., mage config:show).
+func (o *Orchestrator) ConfigShow() error {
+	return o.writeEffectiveConfig(os.Stdout)
+}
+
+// writeEffectiveConfig writes the ConfigShow output to w.
+func (o *Orchestrator) writeEffectiveConfig(w io.Writer) error {
+	cfg := o.cfg
+	cfg.Claude.Fixture = o.cfg.ClaudeFixture()
+	data, err := yaml.Marshal(&cfg)
+	if err != nil {
+		return fmt.Errorf("marshalling effective config: %w", err)
+	}
+	fmt.Fprintf(w, "# Effective configuration (defaults and overrides applied).\n")
+	fmt.Fprintf(w, "# Token file: %s\n\n", filepath.Join(cfg.Claude.SecretsDir, cfg.EffectiveTokenFile()))
+	_, err = w.Write(data)
+	return err
+}
+
+// ConfigValidate checks configuration that is otherwise only exercised
+// at run time, currently the prompt templates (ValidatePromptTemplates).
+// Returns all problems joined into one error, or nil.
+//
+// Exposed as a mage target (e.g., mage validate).
+func (o *Orchestrator) ConfigValidate() error {
+	return errors.Join(o.ValidatePromptTemplates()...)
+}
+
 // NewFromFile reads configuration from a YAML file at the given path,
 // applies defaults, and returns a configured Orchestrator.
 func NewFromFile(path string) (*Orchestrator, error) {
@@ -38,6 +90,40 @@ func NewFromFile(path string) (*Orchestrator, error) {
 	return New(cfg), nil
 }
 
+// NewFromProjectRoot searches upward from the current directory for
+// DefaultConfigFile and loads the first one found via NewFromFile. This
+// lets tools invoked from a subdirectory find the repository's config.
+// Relative paths inside the config are still resolved against the current
+// directory. Returns an error if no config file exists between the current
+// directory and the filesystem root.
+func NewFromProjectRoot() (*Orchestrator, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("getting working directory: %w", err)
+	}
+	path, err := findConfigUpward(wd, DefaultConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewFromFile(path)
+}
+
+// findConfigUpward walks from dir toward the filesystem root and returns
+// the path of the first file named name.
+func findConfigUpward(dir, name string) (string, error) {
+	for d := dir; ; {
+		candidate := filepath.Join(d, name)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return "", fmt.Errorf("no %s found in %s or any parent directory", name, dir)
+		}
+		d = parent
+	}
+}
+
 // phaseMu protects the currentGeneration, currentPhase, and phaseStart
 // variables from concurrent access. Writers use Lock, logf uses RLock.
 var phaseMu sync.RWMutex
@@ -61,6 +147,26 @@ func clearGeneration() {
 	phaseMu.Unlock()
 }
 
+// SetGeneration sets the active generation name used to tag log lines.
+// Magefiles in consuming repos call this to scope their own log output
+// to a generation.
+func (o *Orchestrator) SetGeneration(genID string) {
+	setGeneration(genID)
+}
+
+// ClearGeneration removes the generation tag from subsequent log lines.
+func (o *Orchestrator) ClearGeneration() {
+	clearGeneration()
+}
+
+// CurrentGeneration returns the active generation name, or "" when none
+// is set.
+func (o *Orchestrator) CurrentGeneration() string {
+	phaseMu.RLock()
+	defer phaseMu.RUnlock()
+	return currentGeneration
+}
+
 // currentPhase holds the active workflow phase (e.g. "measure", "stitch").
 // When set, logf includes it and the elapsed time since the phase started.
 var currentPhase string
@@ -4,9 +4,13 @@
 package orchestrator
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 // --- Config ---
@@ -36,6 +40,35 @@ func TestOrchestratorConfig_ReturnsCopy(t *testing.T) {
 	}
 }
 
+// --- ConfigShow ---
+
+func TestWriteEffectiveConfig_ShowsDefaultsAndOverrides(t *testing.T) {
+	cfg := Config{}
+	cfg.Project.ModulePath = "example.com/test"
+	cfg.Claude.TokenFile = "team.json"
+	o := New(cfg)
+	t.Setenv(claudeFixtureEnv, "fixture.jsonl")
+
+	var buf bytes.Buffer
+	if err := o.writeEffectiveConfig(&buf); err != nil {
+		t.Fatalf("writeEffectiveConfig: %v", err)
+	}
+	out := buf.String()
+	var got Config
+	if err := yaml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid YAML: %v\n%s", err, out)
+	}
+	if got.Project.ModulePath != "example.com/test" || got.Project.BinaryDir != "bin" {
+		t.Errorf("expected configured and default values, got project %+v", got.Project)
+	}
+	if got.Claude.Fixture != "fixture.jsonl" {
+		t.Errorf("Fixture = %q, want env override", got.Claude.Fixture)
+	}
+	if !strings.Contains(out, "# Token file: "+filepath.Join(got.Claude.SecretsDir, "team.json")) {
+		t.Errorf("header should name the effective token file:\n%s", out)
+	}
+}
+
 // --- NewFromFile ---
 
 func TestNewFromFile_ValidYAML(t *testing.T) {
@@ -78,6 +111,61 @@ func TestNewFromFile_InvalidYAML(t *testing.T) {
 	}
 }
 
+// --- NewFromProjectRoot ---
+
+func TestNewFromProjectRoot_FindsConfigFromNestedDir(t *testing.T) {
+	root := t.TempDir()
+	content := "project:\n  module_path: example.com/rooted\n"
+	if err := os.WriteFile(filepath.Join(root, DefaultConfigFile), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	nested := filepath.Join(root, "pkg", "sub", "deep")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	orig, _ := os.Getwd()
+	os.Chdir(nested)
+	defer os.Chdir(orig)
+
+	o, err := NewFromProjectRoot()
+	if err != nil {
+		t.Fatalf("NewFromProjectRoot: %v", err)
+	}
+	if o.cfg.Project.ModulePath != "example.com/rooted" {
+		t.Errorf("ModulePath = %q, want %q", o.cfg.Project.ModulePath, "example.com/rooted")
+	}
+}
+
+func TestFindConfigUpward_NotFound(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	_, err := findConfigUpward(dir, "no-such-config-file.yaml")
+	if err == nil {
+		t.Fatal("expected error when no config file exists")
+	}
+	if !strings.Contains(err.Error(), "no-such-config-file.yaml") {
+		t.Errorf("error should name the missing file, got: %v", err)
+	}
+}
+
+func TestFindConfigUpward_PrefersNearest(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	nested := filepath.Join(root, "a")
+	os.MkdirAll(nested, 0o755)
+	os.WriteFile(filepath.Join(root, "cfg.yaml"), []byte("x"), 0o644)
+	os.WriteFile(filepath.Join(nested, "cfg.yaml"), []byte("x"), 0o644)
+
+	got, err := findConfigUpward(nested, "cfg.yaml")
+	if err != nil {
+		t.Fatalf("findConfigUpward: %v", err)
+	}
+	if got != filepath.Join(nested, "cfg.yaml") {
+		t.Errorf("got %q, want nearest config in %q", got, nested)
+	}
+}
+
 // --- setGeneration / clearGeneration ---
 
 func TestSetClearGeneration(t *testing.T) {
@@ -104,6 +192,44 @@ func TestSetClearGeneration(t *testing.T) {
 	}
 }
 
+// --- SetGeneration / ClearGeneration / CurrentGeneration ---
+
+func TestOrchestratorSetGeneration_SetsPackageState(t *testing.T) {
+	t.Cleanup(clearGeneration)
+	o := New(Config{})
+
+	o.SetGeneration("gen-public")
+	phaseMu.RLock()
+	got := currentGeneration
+	phaseMu.RUnlock()
+	if got != "gen-public" {
+		t.Errorf("currentGeneration = %q, want %q", got, "gen-public")
+	}
+	if cur := o.CurrentGeneration(); cur != "gen-public" {
+		t.Errorf("CurrentGeneration() = %q, want %q", cur, "gen-public")
+	}
+}
+
+func TestOrchestratorClearGeneration_ClearsPackageState(t *testing.T) {
+	t.Cleanup(clearGeneration)
+	o := New(Config{})
+
+	o.SetGeneration("gen-public")
+	o.ClearGeneration()
+	if cur := o.CurrentGeneration(); cur != "" {
+		t.Errorf("CurrentGeneration() = %q, want empty after ClearGeneration", cur)
+	}
+}
+
+func TestOrchestratorCurrentGeneration_EmptyByDefault(t *testing.T) {
+	t.Cleanup(clearGeneration)
+	clearGeneration()
+	o := New(Config{})
+	if cur := o.CurrentGeneration(); cur != "" {
+		t.Errorf("CurrentGeneration() = %q, want empty", cur)
+	}
+}
+
 // --- setPhase / clearPhase ---
 
 func TestSetClearPhase(t *testing.T) {
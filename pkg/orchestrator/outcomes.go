@@ -47,13 +47,15 @@ func (o *Orchestrator) Outcomes() error {
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "Branch\tTokens-In\tTokens-Out\tCost-USD\tLOC-Prod-Δ\tLOC-Test-Δ\tDuration")
+	model := o.cfg.Claude.CostModel
+	fmt.Fprintln(w, "Branch\tTokens-In\tTokens-Out\tCost-USD\tEst-Cost-USD\tLOC-Prod-Δ\tLOC-Test-Δ\tDuration")
 	for _, r := range records {
 		prodDelta := r.LocProdAfter - r.LocProdBefore
 		testDelta := r.LocTestAfter - r.LocTestBefore
 		dur := formatDuration(r.DurationSeconds)
-		fmt.Fprintf(w, "%s\t%d\t%d\t$%.4f\t%+d\t%+d\t%s\n",
-			r.TaskBranch, r.TokensInput, r.TokensOutput, r.TokensCostUSD,
+		est := model.Estimate(r.TokensInput, r.TokensOutput, r.TokensCacheCreation, r.TokensCacheRead)
+		fmt.Fprintf(w, "%s\t%d\t%d\t$%.4f\t$%.4f\t%+d\t%+d\t%s\n",
+			r.TaskBranch, r.TokensInput, r.TokensOutput, r.TokensCostUSD, est,
 			prodDelta, testDelta, dur)
 	}
 	return w.Flush()
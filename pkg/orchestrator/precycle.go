@@ -4,14 +4,21 @@
 package orchestrator
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
-const analysisFileName = "analysis.yaml"
+// defaultAnalysisFileName is the analysis file name used when
+// Cobbler.AnalysisFileName is empty.
+const defaultAnalysisFileName = "analysis.yaml"
 
 // AnalysisDoc holds the combined results of cross-artifact consistency
 // checks and code implementation status. It is written to the cobbler
@@ -35,6 +42,10 @@ type AnalysisDoc struct {
 
 	// CodeStatus holds per-release and per-use-case implementation status.
 	CodeStatus *CodeStatusReport `yaml:"code_status,omitempty"`
+
+	// Warnings records problems in the analysis run itself, such as a
+	// failing PostAnalysisHook.
+	Warnings []string `yaml:"warnings,omitempty"`
 }
 
 // totalIssues returns the total count of consistency errors and code gaps.
@@ -46,34 +57,93 @@ func (a *AnalysisDoc) totalIssues() int {
 	return n
 }
 
+// consistencyCategory is one kind of AnalyzeResult finding: its label in
+// collectConsistencyDetails, the values, and the spec kind whose ID leads
+// each value ("" for release-level findings with no single source file).
+type consistencyCategory struct {
+	label  string
+	values []string
+	kind   string
+}
+
+// Spec kinds used by consistencyCategory to locate a finding's file.
+const (
+	specKindPRD       = "prd"
+	specKindUseCase   = "use case"
+	specKindTestSuite = "test suite"
+)
+
+// consistencyCategories lists the AnalyzeResult findings that belong in
+// Claude's project context, in report order.
+func consistencyCategories(r *AnalyzeResult) []consistencyCategory {
+	return []consistencyCategory{
+		{"orphaned PRD", r.OrphanedPRDs, specKindPRD},
+		{"release without test suite", r.ReleasesWithoutTestSuites, ""},
+		{"orphaned test suite", r.OrphanedTestSuites, specKindTestSuite},
+		{"broken touchpoint", r.BrokenTouchpoints, specKindUseCase},
+		{"use case not in roadmap", r.UseCasesNotInRoadmap, specKindUseCase},
+		{"broken citation", r.BrokenCitations, specKindUseCase},
+		{"invalid release", r.InvalidReleases, ""},
+		{"duplicate touchpoint target", r.DuplicateTouchpointTargets, specKindUseCase},
+		{"roadmap release out of order", r.UnorderedReleases, ""},
+		{"uncited requirement", r.UncitedRequirements, specKindPRD},
+		{"colliding use case ID", r.CollidingUseCaseIDs, ""},
+		{"dangling test suite trace", r.DanglingTestSuiteTraces, specKindTestSuite},
+	}
+}
+
 // collectConsistencyDetails flattens an AnalyzeResult into a single list
 // of human-readable issue strings for Claude's project context. Schema errors
 // and constitution drift are excluded — they are routed to the target repo as
 // defects via collectDefects (prd003 R11.2, R11.7).
 func collectConsistencyDetails(r *AnalyzeResult) []string {
 	var details []string
-	for _, v := range r.OrphanedPRDs {
-		details = append(details, "orphaned PRD: "+v)
-	}
-	for _, v := range r.ReleasesWithoutTestSuites {
-		details = append(details, "release without test suite: "+v)
-	}
-	for _, v := range r.OrphanedTestSuites {
-		details = append(details, "orphaned test suite: "+v)
+	for _, c := range consistencyCategories(r) {
+		for _, v := range c.values {
+			details = append(details, c.label+": "+v)
+		}
 	}
-	for _, v := range r.BrokenTouchpoints {
-		details = append(details, "broken touchpoint: "+v)
+	return details
+}
+
+// consistencyGlobalKey groups findings that have no single source file.
+const consistencyGlobalKey = "global"
+
+// ConsistencyDetailsByFile groups the collectConsistencyDetails findings
+// of r by the spec file they originate from. Each finding starts with a
+// PRD, use case, or test suite ID, which maps to {dir}/{id}.yaml under the
+// configured spec directories. Release-level findings, and any whose ID
+// cannot be parsed, are grouped under "global".
+func (o *Orchestrator) ConsistencyDetailsByFile(r *AnalyzeResult) map[string][]string {
+	dirs := map[string]string{
+		specKindPRD:       o.cfg.EffectivePRDDir(),
+		specKindUseCase:   o.cfg.EffectiveUseCaseDir(),
+		specKindTestSuite: o.cfg.EffectiveTestSuiteDir(),
 	}
-	for _, v := range r.UseCasesNotInRoadmap {
-		details = append(details, "use case not in roadmap: "+v)
+	byFile := map[string][]string{}
+	for _, c := range consistencyCategories(r) {
+		for _, v := range c.values {
+			key := consistencyGlobalKey
+			if id := leadingSpecID(v); c.kind != "" && id != "" {
+				key = filepath.Join(dirs[c.kind], id+".yaml")
+			}
+			byFile[key] = append(byFile[key], c.label+": "+v)
+		}
 	}
-	for _, v := range r.BrokenCitations {
-		details = append(details, "broken citation: "+v)
+	return byFile
+}
+
+// leadingSpecID returns the spec ID at the start of a finding value, which
+// ends at the first space, colon, or "->" (e.g. "rel01.0-uc001: cites ..."
+// and "uc001->prd002" yield "rel01.0-uc001" and "uc001").
+func leadingSpecID(v string) string {
+	if i := strings.IndexAny(v, " :"); i >= 0 {
+		v = v[:i]
 	}
-	for _, v := range r.InvalidReleases {
-		details = append(details, "invalid release: "+v)
+	if i := strings.Index(v, "->"); i >= 0 {
+		v = v[:i]
 	}
-	return details
+	return v
 }
 
 // collectDefects extracts schema errors and constitution drift from an
@@ -94,10 +164,58 @@ func collectDefects(r *AnalyzeResult) []string {
 // RunPreCycleAnalysis performs cross-artifact consistency checks and code
 // status detection, writes the combined result to {ScratchDir}/analysis.yaml,
 // and logs a summary. Errors are logged but do not fail the caller — the
-// analysis is advisory, not blocking.
-func (o *Orchestrator) RunPreCycleAnalysis() {
+// analysis is advisory, not blocking. The code status section reads the
+// roadmap and tests/ from fsys when given, otherwise from the current
+// directory; consistency checks always read the current directory.
+func (o *Orchestrator) RunPreCycleAnalysis(fsys ...fs.FS) {
+	_, _ = o.runPreCycleAnalysis(fsys) // best-effort; the analysis is advisory and write errors are logged
+}
+
+// RunPreCycleAnalysisJSON runs RunPreCycleAnalysis and then prints a
+// compact JSON summary of the result (consistency errors, defects, code
+// gaps, and total issues) on one line to stdout for piping into jq.
+// Unlike RunPreCycleAnalysis it returns an error, and prints nothing,
+// when the analysis file cannot be written.
+//
+// Exposed as a mage target (e.g., mage cobbler:precycleJson).
+func (o *Orchestrator) RunPreCycleAnalysisJSON(fsys ...fs.FS) error {
+	doc, err := o.runPreCycleAnalysis(fsys)
+	if err != nil {
+		return err
+	}
+	return writeAnalysisSummaryJSON(os.Stdout, &doc)
+}
+
+// analysisSummary is the RunPreCycleAnalysisJSON output.
+type analysisSummary struct {
+	ConsistencyErrors int `json:"consistency_errors"`
+	Defects           int `json:"defects"`
+	Gaps              int `json:"gaps"`
+	TotalIssues       int `json:"total_issues"`
+}
+
+// writeAnalysisSummaryJSON writes the analysisSummary of doc to w as one
+// line of JSON.
+func writeAnalysisSummaryJSON(w io.Writer, doc *AnalysisDoc) error {
+	s := analysisSummary{
+		ConsistencyErrors: doc.ConsistencyErrors,
+		Defects:           len(doc.Defects),
+		TotalIssues:       doc.totalIssues(),
+	}
+	if doc.CodeStatus != nil {
+		s.Gaps = len(doc.CodeStatus.Gaps)
+	}
+	return json.NewEncoder(w).Encode(s)
+}
+
+// runPreCycleAnalysis implements RunPreCycleAnalysis and returns the
+// analysis it wrote. When the analysis file cannot be written it returns
+// the analysis it would have written and the write error.
+func (o *Orchestrator) runPreCycleAnalysis(fsys []fs.FS) (AnalysisDoc, error) {
 	logf("precycle: running pre-cycle analysis")
 
+	o.runPreCycleHook()
+
 	doc := AnalysisDoc{}
 
 	// Cross-artifact consistency checks.
@@ -116,24 +234,75 @@ func (o *Orchestrator) RunPreCycleAnalysis() {
 	}
 
 	// Code implementation status.
-	roadmap := loadYAML[RoadmapDoc]("docs/road-map.yaml")
+	root := resolveFS(fsys)
+	roadmapPath := o.cfg.EffectiveRoadmapFile()
+	roadmap := readRoadmap(fsys, roadmapPath, o.cfg.EffectiveMaxSpecFileSize())
 	if roadmap != nil {
-		testScan := scanTestDirectories("tests")
+		testScan := scanTestDirectoriesFS(root, "tests", o.cfg.Cobbler.TestFileSuffixes)
 		report := computeCodeStatus(roadmap, testScan)
+		applyReadinessThresholds(&report, o.readinessThreshold)
 		report.Gaps = detectSpecCodeGaps(&report)
 		doc.CodeStatus = &report
 	} else {
-		logf("precycle: cannot load road-map.yaml, skipping code status")
+		logf("precycle: cannot load %s, skipping code status", roadmapPath)
 	}
 
 	// Write to scratch directory.
-	outPath := filepath.Join(o.cfg.Cobbler.Dir, analysisFileName)
+	outPath := filepath.Join(o.cfg.Cobbler.Dir, o.cfg.EffectiveAnalysisFileName())
 	if err := writeAnalysisDoc(&doc, outPath); err != nil {
 		logf("precycle: failed to write %s: %v", outPath, err)
-		return
+		return doc, fmt.Errorf("writing %s: %w", outPath, err)
 	}
 
 	logf("precycle: wrote %s (total_issues=%d)", outPath, doc.totalIssues())
+
+	if err := o.runPostAnalysisHook(outPath, doc.totalIssues()); err != nil {
+		logf("precycle: post-analysis hook failed: %v", err)
+		doc.Warnings = append(doc.Warnings, fmt.Sprintf("post-analysis hook failed: %v", err))
+		if err := writeAnalysisDoc(&doc, outPath); err != nil {
+			logf("precycle: failed to record hook warning in %s: %v", outPath, err)
+		}
+	}
+	return doc, nil
+}
+
+// runPreCycleHook executes Cobbler.PreCycleHook through the shell in the
+// current working directory (the repository root). Failures are logged
+// and otherwise ignored so the analysis still runs.
+func (o *Orchestrator) runPreCycleHook() {
+	hook := o.cfg.Cobbler.PreCycleHook
+	if hook == "" {
+		return
+	}
+	logf("precycle: running hook: %s", hook)
+	out, err := exec.Command(binSh, "-c", hook).CombinedOutput()
+	if len(out) > 0 {
+		logf("precycle: hook output:\n%s", strings.TrimRight(string(out), "\n"))
+	}
+	if err != nil {
+		logf("precycle: hook failed, continuing: %v", err)
+	}
+}
+
+// runPostAnalysisHook executes Cobbler.PostAnalysisHook through the shell
+// with COBBLER_ANALYSIS_FILE and COBBLER_TOTAL_ISSUES set. Returns the
+// hook's error, or nil when no hook is configured.
+func (o *Orchestrator) runPostAnalysisHook(analysisPath string, totalIssues int) error {
+	hook := o.cfg.Cobbler.PostAnalysisHook
+	if hook == "" {
+		return nil
+	}
+	logf("precycle: running post-analysis hook: %s", hook)
+	cmd := exec.Command(binSh, "-c", hook)
+	cmd.Env = append(os.Environ(),
+		"COBBLER_ANALYSIS_FILE="+analysisPath,
+		fmt.Sprintf("COBBLER_TOTAL_ISSUES=%d", totalIssues),
+	)
+	out, err := cmd.CombinedOutput()
+	if len(out) > 0 {
+		logf("precycle: post-analysis hook output:\n%s", strings.TrimRight(string(out), "\n"))
+	}
+	return err
 }
 
 // writeAnalysisDoc marshals an AnalysisDoc to YAML and writes it to path.
@@ -148,8 +317,12 @@ func writeAnalysisDoc(doc *AnalysisDoc, path string) error {
 	return os.WriteFile(path, data, 0o644)
 }
 
-// loadAnalysisDoc loads an AnalysisDoc from {cobblerDir}/analysis.yaml.
-// Returns nil if the file does not exist or cannot be parsed.
-func loadAnalysisDoc(cobblerDir string) *AnalysisDoc {
-	return loadYAML[AnalysisDoc](filepath.Join(cobblerDir, analysisFileName))
+// loadAnalysisDoc loads an AnalysisDoc from {cobblerDir}/{fileName}. An
+// empty fileName means "analysis.yaml". Returns nil if the file does not
+// exist or cannot be parsed.
+func loadAnalysisDoc(cobblerDir, fileName string) *AnalysisDoc {
+	if fileName == "" {
+		fileName = defaultAnalysisFileName
+	}
+	return loadYAML[AnalysisDoc](filepath.Join(cobblerDir, fileName))
 }
@@ -4,10 +4,14 @@
 package orchestrator
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 // --- totalIssues ---
@@ -106,6 +110,43 @@ func TestCollectConsistencyDetails_MultiplePerField(t *testing.T) {
 	}
 }
 
+// --- ConsistencyDetailsByFile ---
+
+func TestConsistencyDetailsByFile_MixedResult(t *testing.T) {
+	t.Parallel()
+	o := New(Config{})
+	r := &AnalyzeResult{
+		OrphanedPRDs:               []string{"prd003-extras"},
+		ReleasesWithoutTestSuites:  []string{"02.0"},
+		OrphanedTestSuites:         []string{"test-rel99.0"},
+		BrokenTouchpoints:          []string{"rel01.0-uc001 -> prd009 (missing)"},
+		BrokenCitations:            []string{"rel01.0-uc001: cites prd001 R9 (requirement group not found)"},
+		DuplicateTouchpointTargets: []string{"rel01.0-uc002: prd001 R1"},
+		UnorderedReleases:          []string{"release 02.0 listed before 01.0"},
+	}
+	got := o.ConsistencyDetailsByFile(r)
+
+	uc001 := filepath.Join(o.cfg.EffectiveUseCaseDir(), "rel01.0-uc001.yaml")
+	want := map[string]int{
+		filepath.Join(o.cfg.EffectivePRDDir(), "prd003-extras.yaml"):      1,
+		filepath.Join(o.cfg.EffectiveTestSuiteDir(), "test-rel99.0.yaml"): 1,
+		uc001: 2,
+		filepath.Join(o.cfg.EffectiveUseCaseDir(), "rel01.0-uc002.yaml"): 1,
+		consistencyGlobalKey: 2,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d files, want %d: %v", len(got), len(want), got)
+	}
+	for file, n := range want {
+		if len(got[file]) != n {
+			t.Errorf("%s: got %v, want %d finding(s)", file, got[file], n)
+		}
+	}
+	if got[uc001][0] != "broken touchpoint: rel01.0-uc001 -> prd009 (missing)" {
+		t.Errorf("findings should keep their collectConsistencyDetails label, got %q", got[uc001][0])
+	}
+}
+
 // --- collectDefects ---
 
 func TestCollectDefects_Empty(t *testing.T) {
@@ -166,7 +207,7 @@ func TestAnalysisDocDefectsRoundTrip(t *testing.T) {
 		t.Fatalf("writeAnalysisDoc: %v", err)
 	}
 
-	loaded := loadAnalysisDoc(dir)
+	loaded := loadAnalysisDoc(dir, "")
 	if loaded == nil {
 		t.Fatal("loadAnalysisDoc returned nil")
 	}
@@ -211,7 +252,7 @@ func TestWriteAndLoadAnalysisDoc(t *testing.T) {
 	}
 
 	// Load it back.
-	loaded := loadAnalysisDoc(dir)
+	loaded := loadAnalysisDoc(dir, "")
 	if loaded == nil {
 		t.Fatal("loadAnalysisDoc returned nil")
 	}
@@ -251,7 +292,7 @@ func TestWriteAnalysisDoc_EmptyDoc(t *testing.T) {
 		t.Fatalf("writeAnalysisDoc: %v", err)
 	}
 
-	loaded := loadAnalysisDoc(dir)
+	loaded := loadAnalysisDoc(dir, "")
 	if loaded == nil {
 		t.Fatal("loadAnalysisDoc returned nil")
 	}
@@ -265,7 +306,7 @@ func TestWriteAnalysisDoc_EmptyDoc(t *testing.T) {
 
 func TestLoadAnalysisDoc_NoFile(t *testing.T) {
 	dir := t.TempDir()
-	loaded := loadAnalysisDoc(dir)
+	loaded := loadAnalysisDoc(dir, "")
 	if loaded != nil {
 		t.Errorf("expected nil for missing file, got %+v", loaded)
 	}
@@ -273,10 +314,10 @@ func TestLoadAnalysisDoc_NoFile(t *testing.T) {
 
 func TestLoadAnalysisDoc_InvalidYAML(t *testing.T) {
 	dir := t.TempDir()
-	path := filepath.Join(dir, analysisFileName)
+	path := filepath.Join(dir, defaultAnalysisFileName)
 	os.WriteFile(path, []byte("{{invalid yaml"), 0o644)
 
-	loaded := loadAnalysisDoc(dir)
+	loaded := loadAnalysisDoc(dir, "")
 	if loaded != nil {
 		t.Errorf("expected nil for invalid YAML, got %+v", loaded)
 	}
@@ -307,9 +348,9 @@ func TestRunPreCycleAnalysis_WritesFile(t *testing.T) {
 	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: scratchDir}}}
 	o.RunPreCycleAnalysis()
 
-	outPath := filepath.Join(scratchDir, analysisFileName)
+	outPath := filepath.Join(scratchDir, defaultAnalysisFileName)
 	if _, err := os.Stat(outPath); os.IsNotExist(err) {
-		t.Fatalf("expected %s to exist after RunPreCycleAnalysis", analysisFileName)
+		t.Fatalf("expected %s to exist after RunPreCycleAnalysis", defaultAnalysisFileName)
 	}
 	data, err := os.ReadFile(outPath)
 	if err != nil {
@@ -321,6 +362,52 @@ func TestRunPreCycleAnalysis_WritesFile(t *testing.T) {
 	}
 }
 
+func TestRunPreCycleAnalysis_WritesDefects(t *testing.T) {
+	// Not parallel: uses os.Chdir.
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	t.Cleanup(func() { os.Chdir(orig) })
+
+	// Minimal doc set whose use case lacks the required title field.
+	os.MkdirAll("docs/specs/product-requirements", 0o755)
+	os.MkdirAll("docs/specs/use-cases", 0o755)
+	os.MkdirAll("docs/specs/test-suites", 0o755)
+	os.WriteFile("docs/road-map.yaml", []byte("releases:\n  - id: rel01.0\n    use_cases:\n      - id: rel01.0-uc001-init\n        summary: Init\n        status: done\n"), 0o644)
+	os.WriteFile("docs/specs/use-cases/rel01.0-uc001-init.yaml",
+		[]byte("id: rel01.0-uc001-init\nsummary: Init\nactor: Developer\ntrigger: Runs init\ntouchpoints:\n  - T1: prd001-core R1\n"), 0o644)
+	os.WriteFile("docs/specs/product-requirements/prd001-core.yaml",
+		[]byte("id: prd001-core\ntitle: Core\nrequirements:\n  - id: R1\n    title: Req 1\n"), 0o644)
+	os.WriteFile("docs/specs/test-suites/test-rel01.0.yaml",
+		[]byte("id: test-rel01.0\ntitle: Tests\nrelease: rel01.0\ntraces:\n  - rel01.0-uc001-init\n"), 0o644)
+
+	scratchDir := filepath.Join(dir, ".cobbler")
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: scratchDir}}}
+	o.RunPreCycleAnalysis()
+
+	doc := loadYAML[AnalysisDoc](filepath.Join(scratchDir, defaultAnalysisFileName))
+	if doc == nil {
+		t.Fatalf("could not load %s after RunPreCycleAnalysis", defaultAnalysisFileName)
+	}
+	if len(doc.Defects) == 0 {
+		t.Fatal("defects is empty, want the use case schema error")
+	}
+	found := false
+	for _, d := range doc.Defects {
+		if strings.Contains(d, "rel01.0-uc001-init.yaml") && strings.Contains(d, "title is required") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("defects missing use case title error: %v", doc.Defects)
+	}
+	for _, d := range doc.ConsistencyDetails {
+		if strings.Contains(d, "title is required") {
+			t.Errorf("schema error leaked into consistency_details: %q", d)
+		}
+	}
+}
+
 func TestRunPreCycleAnalysis_NoRoadmap(t *testing.T) {
 	// Not parallel: uses os.Chdir.
 	dir := t.TempDir()
@@ -335,8 +422,312 @@ func TestRunPreCycleAnalysis_NoRoadmap(t *testing.T) {
 	o.RunPreCycleAnalysis()
 
 	// Should still write a file even if analysis had errors.
-	outPath := filepath.Join(scratchDir, analysisFileName)
+	outPath := filepath.Join(scratchDir, defaultAnalysisFileName)
 	if _, err := os.Stat(outPath); os.IsNotExist(err) {
-		t.Fatalf("expected %s even with empty docs", analysisFileName)
+		t.Fatalf("expected %s even with empty docs", defaultAnalysisFileName)
+	}
+}
+
+func TestRunPreCycleAnalysisJSON_PrintsSummary(t *testing.T) {
+	// Not parallel: uses os.Chdir and captures os.Stdout.
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	t.Cleanup(func() { os.Chdir(orig) })
+
+	scratchDir := filepath.Join(dir, ".cobbler")
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: scratchDir}}}
+	var runErr error
+	out := captureStdout(t, func() { runErr = o.RunPreCycleAnalysisJSON() })
+	if runErr != nil {
+		t.Fatalf("RunPreCycleAnalysisJSON: %v", runErr)
+	}
+
+	if _, err := os.Stat(filepath.Join(scratchDir, defaultAnalysisFileName)); err != nil {
+		t.Fatalf("expected %s to still be written: %v", defaultAnalysisFileName, err)
+	}
+	if strings.Count(strings.TrimSpace(out), "\n") != 0 {
+		t.Errorf("expected a single line of JSON, got %q", out)
+	}
+	var got map[string]int
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, out)
+	}
+	for _, key := range []string{"consistency_errors", "defects", "gaps", "total_issues"} {
+		if _, ok := got[key]; !ok {
+			t.Errorf("summary missing %q: %s", key, out)
+		}
+	}
+	doc := loadAnalysisDoc(scratchDir, defaultAnalysisFileName)
+	if doc == nil {
+		t.Fatal("loadAnalysisDoc returned nil")
+	}
+	if got["consistency_errors"] != doc.ConsistencyErrors || got["defects"] != len(doc.Defects) || got["total_issues"] != doc.totalIssues() {
+		t.Errorf("summary %v does not match %s", got, defaultAnalysisFileName)
+	}
+}
+
+func TestRunPreCycleAnalysisJSON_WriteFailure(t *testing.T) {
+	// Not parallel: uses os.Chdir and captures os.Stdout.
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	t.Cleanup(func() { os.Chdir(orig) })
+
+	// A regular file where the scratch directory should be makes the
+	// analysis file unwritable.
+	scratchDir := filepath.Join(dir, ".cobbler")
+	os.WriteFile(scratchDir, []byte("not a directory"), 0o644)
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: scratchDir}}}
+	var runErr error
+	out := captureStdout(t, func() { runErr = o.RunPreCycleAnalysisJSON() })
+	if runErr == nil || !strings.Contains(runErr.Error(), defaultAnalysisFileName) {
+		t.Errorf("RunPreCycleAnalysisJSON error = %v, want the write failure", runErr)
+	}
+	if out != "" {
+		t.Errorf("stdout = %q, want no summary when the analysis was not written", out)
+	}
+}
+
+func TestWriteAnalysisSummaryJSON(t *testing.T) {
+	t.Parallel()
+	doc := &AnalysisDoc{
+		ConsistencyErrors: 2,
+		Defects:           []string{"a", "b", "c"},
+		CodeStatus:        &CodeStatusReport{Gaps: []string{"g1"}},
+	}
+	var buf strings.Builder
+	if err := writeAnalysisSummaryJSON(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"consistency_errors":2,"defects":3,"gaps":1,"total_issues":3}` + "\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRunPreCycleAnalysis_CustomAnalysisFileName(t *testing.T) {
+	// Not parallel: uses os.Chdir.
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	t.Cleanup(func() { os.Chdir(orig) })
+
+	scratchDir := filepath.Join(dir, ".cobbler")
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: scratchDir, AnalysisFileName: "analysis-ci.yaml"}}}
+	o.RunPreCycleAnalysis()
+
+	if _, err := os.Stat(filepath.Join(scratchDir, "analysis-ci.yaml")); err != nil {
+		t.Fatalf("expected analysis-ci.yaml to be written: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(scratchDir, defaultAnalysisFileName)); !os.IsNotExist(err) {
+		t.Errorf("expected no %s when a custom name is set", defaultAnalysisFileName)
+	}
+	if doc := loadAnalysisDoc(scratchDir, o.cfg.EffectiveAnalysisFileName()); doc == nil {
+		t.Error("loadAnalysisDoc with the configured name returned nil")
+	}
+}
+
+func TestRunPreCycleAnalysis_InjectedFSIgnoresHostFiles(t *testing.T) {
+	// Not parallel: uses os.Chdir.
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	t.Cleanup(func() { os.Chdir(orig) })
+
+	// Decoy roadmap and tests on the host that must not reach code status.
+	os.MkdirAll("docs", 0o755)
+	os.MkdirAll("tests/rel01.0/uc001", 0o755)
+	os.WriteFile("docs/road-map.yaml", []byte(roadmapYAML), 0o644)
+	os.WriteFile("tests/rel01.0/uc001/init_test.go", []byte("package x\n"), 0o644)
+	hostRoadmap := filepath.Join(dir, "docs", "road-map.yaml")
+
+	fsys := fstest.MapFS{
+		"docs/road-map.yaml":                 {Data: []byte(twoReleaseRoadmapYAML)},
+		"tests/rel02.0/uc001/browse_test.go": {Data: []byte("package x\n")},
+	}
+
+	t.Run("relative roadmap_file", func(t *testing.T) {
+		scratchDir := filepath.Join(dir, ".cobbler-relative")
+		o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{Dir: scratchDir}}}
+		o.RunPreCycleAnalysis(fsys)
+
+		doc := loadAnalysisDoc(scratchDir, defaultAnalysisFileName)
+		if doc == nil || doc.CodeStatus == nil {
+			t.Fatalf("analysis has no code status: %+v", doc)
+		}
+		got := map[string]string{}
+		for _, rel := range doc.CodeStatus.Releases {
+			for _, uc := range rel.UseCases {
+				got[uc.ID] = uc.CodeStatus
+			}
+		}
+		want := map[string]string{
+			"rel01.0-uc001-init":   "not started",
+			"rel02.0-uc001-browse": "implemented",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("use case code status = %v, want %v (host roadmap or tests/ were read)", got, want)
+		}
+	})
+
+	t.Run("absolute roadmap_file", func(t *testing.T) {
+		scratchDir := filepath.Join(dir, ".cobbler-absolute")
+		o := &Orchestrator{cfg: Config{
+			Cobbler: CobblerConfig{Dir: scratchDir},
+			Project: ProjectConfig{RoadmapFile: hostRoadmap},
+		}}
+		o.RunPreCycleAnalysis(fsys)
+
+		doc := loadAnalysisDoc(scratchDir, defaultAnalysisFileName)
+		if doc == nil {
+			t.Fatal("analysis was not written")
+		}
+		if doc.CodeStatus != nil {
+			t.Errorf("code status = %+v, want none: the absolute roadmap is outside the injected fs.FS", doc.CodeStatus)
+		}
+	})
+}
+
+func TestRunPreCycleAnalysis_CustomDocsLayout(t *testing.T) {
+	// Not parallel: uses os.Chdir.
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	t.Cleanup(func() { os.Chdir(orig) })
+
+	os.MkdirAll("spec/prds", 0o755)
+	os.MkdirAll("spec/ucs", 0o755)
+	os.MkdirAll("spec/suites", 0o755)
+	os.WriteFile("spec/roadmap.yaml", []byte("releases:\n  - version: \"01.0\"\n    use_cases:\n      - id: rel01.0-uc001-init\n        status: done\n"), 0o644)
+	os.WriteFile("spec/ucs/rel01.0-uc001-init.yaml",
+		[]byte("id: rel01.0-uc001-init\ntitle: Init\ntouchpoints:\n  - T1: prd001-core R1\n"), 0o644)
+	os.WriteFile("spec/prds/prd001-core.yaml",
+		[]byte("id: prd001-core\ntitle: Core\nrequirements:\n  R1:\n    title: Req 1\n"), 0o644)
+	os.WriteFile("spec/suites/test-rel01.0.yaml",
+		[]byte("id: test-rel01.0\ntitle: Tests\nrelease: rel01.0\ntraces:\n  - rel01.0-uc001-init\n"), 0o644)
+
+	scratchDir := filepath.Join(dir, ".cobbler")
+	o := &Orchestrator{cfg: Config{
+		Project: ProjectConfig{
+			PRDDir:       "spec/prds",
+			UseCaseDir:   "spec/ucs",
+			TestSuiteDir: "spec/suites",
+			RoadmapFile:  "spec/roadmap.yaml",
+		},
+		Cobbler: CobblerConfig{Dir: scratchDir},
+	}}
+	o.RunPreCycleAnalysis()
+
+	doc := loadAnalysisDoc(scratchDir, "")
+	if doc == nil {
+		t.Fatalf("expected %s to be written", defaultAnalysisFileName)
+	}
+	for _, d := range doc.ConsistencyDetails {
+		if strings.Contains(d, "orphaned PRD") || strings.Contains(d, "release without test suite") {
+			t.Errorf("custom layout not read: %s", d)
+		}
+	}
+	if doc.CodeStatus == nil || len(doc.CodeStatus.Releases) != 1 {
+		t.Fatalf("expected code status from custom roadmap, got %+v", doc.CodeStatus)
+	}
+}
+
+func TestRunPreCycleAnalysis_HookCreatesSentinel(t *testing.T) {
+	// Not parallel: uses os.Chdir.
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	t.Cleanup(func() { os.Chdir(orig) })
+
+	scratchDir := filepath.Join(dir, ".cobbler")
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{
+		Dir:          scratchDir,
+		PreCycleHook: "touch hook-ran.txt",
+	}}}
+	o.RunPreCycleAnalysis()
+
+	if _, err := os.Stat(filepath.Join(dir, "hook-ran.txt")); err != nil {
+		t.Errorf("expected sentinel file created by hook: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(scratchDir, defaultAnalysisFileName)); err != nil {
+		t.Errorf("expected %s after hook: %v", defaultAnalysisFileName, err)
+	}
+}
+
+func TestRunPreCycleAnalysis_HookFailureDoesNotAbort(t *testing.T) {
+	// Not parallel: uses os.Chdir.
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	t.Cleanup(func() { os.Chdir(orig) })
+
+	scratchDir := filepath.Join(dir, ".cobbler")
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{
+		Dir:          scratchDir,
+		PreCycleHook: "exit 3",
+	}}}
+	o.RunPreCycleAnalysis()
+
+	if _, err := os.Stat(filepath.Join(scratchDir, defaultAnalysisFileName)); err != nil {
+		t.Errorf("expected %s despite failing hook: %v", defaultAnalysisFileName, err)
+	}
+}
+
+func TestRunPreCycleAnalysis_PostAnalysisHookEnv(t *testing.T) {
+	// Not parallel: uses os.Chdir.
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	t.Cleanup(func() { os.Chdir(orig) })
+
+	scratchDir := filepath.Join(dir, ".cobbler")
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{
+		Dir:              scratchDir,
+		PostAnalysisHook: `printf '%s\n%s\n' "$COBBLER_ANALYSIS_FILE" "$COBBLER_TOTAL_ISSUES" > post-hook.txt`,
+	}}}
+	o.RunPreCycleAnalysis()
+
+	data, err := os.ReadFile(filepath.Join(dir, "post-hook.txt"))
+	if err != nil {
+		t.Fatalf("expected sentinel file written by post-analysis hook: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	wantPath := filepath.Join(scratchDir, defaultAnalysisFileName)
+	if len(lines) != 2 || lines[0] != wantPath {
+		t.Fatalf("COBBLER_ANALYSIS_FILE = %q, want %q", lines, wantPath)
+	}
+	doc := loadAnalysisDoc(scratchDir, "")
+	if doc == nil {
+		t.Fatal("expected analysis file")
+	}
+	if want := fmt.Sprintf("%d", doc.totalIssues()); lines[1] != want {
+		t.Errorf("COBBLER_TOTAL_ISSUES = %q, want %q", lines[1], want)
+	}
+	if len(doc.Warnings) != 0 {
+		t.Errorf("successful hook should not add warnings, got %v", doc.Warnings)
+	}
+}
+
+func TestRunPreCycleAnalysis_PostAnalysisHookFailureRecorded(t *testing.T) {
+	// Not parallel: uses os.Chdir.
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	os.Chdir(dir)
+	t.Cleanup(func() { os.Chdir(orig) })
+
+	scratchDir := filepath.Join(dir, ".cobbler")
+	o := &Orchestrator{cfg: Config{Cobbler: CobblerConfig{
+		Dir:              scratchDir,
+		PostAnalysisHook: "exit 3",
+	}}}
+	o.RunPreCycleAnalysis()
+
+	doc := loadAnalysisDoc(scratchDir, "")
+	if doc == nil {
+		t.Fatal("expected analysis file")
+	}
+	if len(doc.Warnings) != 1 || !strings.Contains(doc.Warnings[0], "post-analysis hook") {
+		t.Errorf("expected one post-analysis hook warning, got %v", doc.Warnings)
 	}
 }
@@ -17,13 +17,14 @@ import (
 type MeasurePromptDoc struct {
 	Role                    string          `yaml:"role"`
 	ProjectContext          *ProjectContext `yaml:"project_context,omitempty"`
-	PlanningConstitution    *yaml.Node     `yaml:"planning_constitution,omitempty"`
-	IssueFormatConstitution *yaml.Node     `yaml:"issue_format_constitution,omitempty"`
+	PlanningConstitution    *yaml.Node      `yaml:"planning_constitution,omitempty"`
+	IssueFormatConstitution *yaml.Node      `yaml:"issue_format_constitution,omitempty"`
 	Task                    string          `yaml:"task"`
 	Constraints             string          `yaml:"constraints"`
 	OutputFormat            string          `yaml:"output_format"`
 	GoldenExample           string          `yaml:"golden_example,omitempty"`
 	AdditionalContext       string          `yaml:"additional_context,omitempty"`
+	Footer                  string          `yaml:"footer,omitempty"`
 }
 
 // StitchPromptDoc is the complete stitch prompt as a YAML document.
@@ -32,11 +33,13 @@ type StitchPromptDoc struct {
 	RepositoryFiles       []string        `yaml:"repository_files,omitempty"`
 	ProjectContext        *ProjectContext `yaml:"project_context,omitempty"`
 	Context               string          `yaml:"context"`
-	ExecutionConstitution *yaml.Node     `yaml:"execution_constitution,omitempty"`
-	GoStyleConstitution   *yaml.Node     `yaml:"go_style_constitution,omitempty"`
+	ExecutionConstitution *yaml.Node      `yaml:"execution_constitution,omitempty"`
+	GoStyleConstitution   *yaml.Node      `yaml:"go_style_constitution,omitempty"`
 	Task                  string          `yaml:"task"`
 	Constraints           string          `yaml:"constraints"`
 	Description           string          `yaml:"description"`
+	Extensions            []string        `yaml:"extensions,omitempty"`
+	Footer                string          `yaml:"footer,omitempty"`
 }
 
 // promptTemplate holds the static text fields parsed from a prompt
@@ -58,6 +61,29 @@ func parsePromptTemplate(yamlContent string) (promptTemplate, error) {
 	return tmpl, nil
 }
 
+// ValidatePromptTemplates parses the configured MeasurePrompt and
+// StitchPrompt templates without building a prompt, so a malformed
+// template is reported up front instead of when measure or stitch runs.
+// Templates are YAML documents parsed with parsePromptTemplate, the same
+// way buildMeasurePrompt and buildStitchPrompt read them. Empty fields
+// select the embedded defaults and are skipped. Returns one error per
+// template that fails to parse.
+func (o *Orchestrator) ValidatePromptTemplates() []error {
+	var errs []error
+	for _, t := range []struct{ name, content string }{
+		{"measure_prompt", o.cfg.Cobbler.MeasurePrompt},
+		{"stitch_prompt", o.cfg.Cobbler.StitchPrompt},
+	} {
+		if t.content == "" {
+			continue
+		}
+		if _, err := parsePromptTemplate(t.content); err != nil {
+			errs = append(errs, fmt.Errorf("cobbler.%s: %w", t.name, err))
+		}
+	}
+	return errs
+}
+
 // validatePromptTemplate reads a YAML file and parses it as a
 // promptTemplate. Returns a list of errors if the file is malformed.
 // Returns nil if the file doesn't exist.
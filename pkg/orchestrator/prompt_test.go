@@ -106,6 +106,38 @@ func TestValidatePromptTemplate_InvalidYAML(t *testing.T) {
 	}
 }
 
+// --- ValidatePromptTemplates ---
+
+func TestValidatePromptTemplates(t *testing.T) {
+	t.Parallel()
+	const valid = "role: r\ntask: t\n"
+	const invalid = "role: [unclosed bracket"
+	cases := []struct {
+		name            string
+		measure, stitch string
+		want            int
+	}{
+		{"all valid", valid, valid, 0},
+		{"defaults", "", "", 0},
+		{"invalid measure", invalid, valid, 1},
+		{"invalid stitch", valid, invalid, 1},
+		{"both invalid", invalid, invalid, 2},
+	}
+	for _, tc := range cases {
+		cfg := Config{}
+		cfg.Cobbler.MeasurePrompt = tc.measure
+		cfg.Cobbler.StitchPrompt = tc.stitch
+		o := New(cfg)
+		errs := o.ValidatePromptTemplates()
+		if len(errs) != tc.want {
+			t.Errorf("%s: got %d error(s) %v, want %d", tc.name, len(errs), errs, tc.want)
+		}
+		if err := o.ConfigValidate(); (err != nil) != (tc.want > 0) {
+			t.Errorf("%s: ConfigValidate() = %v, want error=%v", tc.name, err, tc.want > 0)
+		}
+	}
+}
+
 // --- parseYAMLNode ---
 
 func TestParseYAMLNode_ValidYAML(t *testing.T) {
@@ -227,6 +259,69 @@ func TestBuildMeasurePrompt_MaxRequirementsPlaceholder(t *testing.T) {
 	}
 }
 
+func TestBuildMeasurePrompt_MaxFilesConstraint(t *testing.T) {
+	cfg := Config{}
+	cfg.applyDefaults()
+	cfg.Cobbler.MaxFilesPerTask = 4
+	prompt, err := New(cfg).buildMeasurePrompt("", "[]", 1)
+	if err != nil {
+		t.Fatalf("buildMeasurePrompt: %v", err)
+	}
+	if strings.Contains(prompt, "{files_per_task}") {
+		t.Error("measure prompt has unsubstituted {files_per_task} placeholder")
+	}
+	if !strings.Contains(prompt, "touching at most 4 files") {
+		t.Error("measure prompt file range does not follow max_files_per_task (4)")
+	}
+	if !strings.Contains(prompt, "at most 4 files; split") {
+		t.Error("measure prompt does not state the configured max_files_per_task (4)")
+	}
+
+	cfg.Cobbler.MaxFilesPerTask = 0
+	prompt, err = New(cfg).buildMeasurePrompt("", "[]", 1)
+	if err != nil {
+		t.Fatalf("buildMeasurePrompt: %v", err)
+	}
+	if !strings.Contains(prompt, "touching 5-7 files") {
+		t.Error("measure prompt should keep the default 5-7 file range when max_files_per_task is 0")
+	}
+	if strings.Contains(prompt, "files; split") {
+		t.Error("measure prompt should omit the file cap when max_files_per_task is 0")
+	}
+}
+
+func TestBuildMeasurePrompt_MaxFilesConstraintCustomTemplate(t *testing.T) {
+	cfg := Config{}
+	cfg.applyDefaults()
+	cfg.Cobbler.MaxFilesPerTask = 3
+	cfg.Cobbler.MeasurePrompt = "role: planner\nconstraints: |\n  - Keep tasks small.\n"
+	prompt, err := New(cfg).buildMeasurePrompt("", "[]", 1)
+	if err != nil {
+		t.Fatalf("buildMeasurePrompt: %v", err)
+	}
+	if !strings.Contains(prompt, "at most 3 files; split") {
+		t.Error("custom measure prompt does not state the configured max_files_per_task (3)")
+	}
+}
+
+func TestFilesPerTask(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		maxFiles int
+		want     string
+	}{
+		{0, "5-7"},
+		{10, "5-7"},
+		{6, "5-6"},
+		{5, "at most 5"},
+		{2, "at most 2"},
+	} {
+		if got := filesPerTask(tc.maxFiles); got != tc.want {
+			t.Errorf("filesPerTask(%d) = %q, want %q", tc.maxFiles, got, tc.want)
+		}
+	}
+}
+
 func TestMeasurePromptNoWriteToolReferences(t *testing.T) {
 	o := New(Config{})
 	prompt, err := o.buildMeasurePrompt("", "[]", 1)
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ReplayInvocation re-runs a recorded stitch invocation of task issueID
+// with the prompt saved for it. invocationIndex selects among the task's
+// invocations in the history directory, oldest first, starting at 0.
+// Claude runs in a throwaway worktree branched from the current HEAD,
+// which is removed with its branch afterwards, so the main checkout is
+// never edited. The output is saved to the history directory as
+// {ts}-replay-log.log.
+//
+// Returns an error when the index is out of range or the saved prompt is
+// missing, since the original task data is not kept after a stitch.
+func (o *Orchestrator) ReplayInvocation(issueID string, invocationIndex int) (ClaudeResult, error) {
+	dir := o.historyDir()
+	if dir == "" {
+		return ClaudeResult{}, fmt.Errorf("history directory is not configured")
+	}
+	stamps := stitchInvocationTimestamps(dir, issueID)
+	if invocationIndex < 0 || invocationIndex >= len(stamps) {
+		return ClaudeResult{}, fmt.Errorf("invocation index %d out of range: task %s has %d recorded invocation(s)",
+			invocationIndex, issueID, len(stamps))
+	}
+	ts := stamps[invocationIndex]
+
+	prompt, err := os.ReadFile(filepath.Join(dir, ts+"-stitch-prompt.yaml"))
+	if err != nil {
+		return ClaudeResult{}, fmt.Errorf("cannot reconstruct prompt for task %s invocation %d (%s): %w",
+			issueID, invocationIndex, ts, err)
+	}
+
+	if err := o.checkClaudeOrFixture(); err != nil {
+		return ClaudeResult{}, err
+	}
+	task := stitchTask{
+		id:          issueID,
+		branchName:  "replay/" + issueID + "-" + ts,
+		worktreeDir: filepath.Join(worktreeBasePath(), "replay-"+issueID+"-"+ts),
+	}
+	if err := createWorktree(task); err != nil {
+		return ClaudeResult{}, fmt.Errorf("creating replay worktree: %w", err)
+	}
+	defer discardReplayWorktree(task)
+
+	logf("replayInvocation: task %s invocation %d (%s), promptLen=%d", issueID, invocationIndex, ts, len(prompt))
+	result, err := o.runClaudeOrFixture(string(prompt), task.worktreeDir, o.cfg.Silence())
+	o.saveHistoryLog(time.Now().Format("2006-01-02-15-04-05"), "replay", result.RawOutput)
+	return result, err
+}
+
+// discardReplayWorktree removes the replay worktree and force-deletes its
+// branch, dropping whatever Claude changed.
+func discardReplayWorktree(task stitchTask) {
+	if err := gitWorktreeRemove(task.worktreeDir, "."); err != nil {
+		logf("replayInvocation: worktree remove warning: %v", err)
+	}
+	if err := gitForceDeleteBranch(task.branchName, "."); err != nil {
+		logf("replayInvocation: branch delete warning: %v", err)
+	}
+}
+
+// stitchInvocationTimestamps returns the history timestamps of the stitch
+// invocations recorded for taskID, oldest first. History file names start
+// with a sortable timestamp, so lexical order is chronological.
+func stitchInvocationTimestamps(dir, taskID string) []string {
+	paths, _ := filepath.Glob(filepath.Join(dir, "*-stitch-stats.yaml"))
+	sort.Strings(paths)
+	var stamps []string
+	for _, p := range paths {
+		if s := loadYAML[HistoryStats](p); s != nil && s.TaskID == taskID {
+			stamps = append(stamps, strings.TrimSuffix(filepath.Base(p), "-stitch-stats.yaml"))
+		}
+	}
+	return stamps
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeStitchInvocation records a stitch invocation of taskID at ts in
+// histDir, with a saved prompt unless prompt is empty.
+func writeStitchInvocation(t *testing.T, histDir, ts, taskID, prompt string) {
+	t.Helper()
+	os.MkdirAll(histDir, 0o755)
+	stats := "caller: stitch\ntask_id: \"" + taskID + "\"\nstatus: failed\n"
+	if err := os.WriteFile(filepath.Join(histDir, ts+"-stitch-stats.yaml"), []byte(stats), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if prompt != "" {
+		if err := os.WriteFile(filepath.Join(histDir, ts+"-stitch-prompt.yaml"), []byte(prompt), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func replayOrchestrator(t *testing.T) (*Orchestrator, string) {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := Config{Claude: ClaudeConfig{Fixture: writeClaudeFixture(t, "replayed")}}
+	cfg.Cobbler.Dir = dir
+	cfg.Cobbler.HistoryDir = "history"
+	return New(cfg), filepath.Join(dir, "history")
+}
+
+// --- ReplayInvocation ---
+
+func TestReplayInvocation_ValidIndex(t *testing.T) {
+	// Not parallel: changes directory and captures stderr.
+	initTestGitRepo(t)
+	o, histDir := replayOrchestrator(t)
+	writeStitchInvocation(t, histDir, "2026-03-01-10-00-00", "42", "first prompt")
+	writeStitchInvocation(t, histDir, "2026-03-01-11-00-00", "7", "other task")
+	writeStitchInvocation(t, histDir, "2026-03-01-12-00-00", "42", "second prompt")
+
+	var res ClaudeResult
+	var err error
+	stderr := captureStderr(t, func() { res, err = o.ReplayInvocation("42", 1) })
+	if err != nil {
+		t.Fatalf("ReplayInvocation: %v", err)
+	}
+	if want := "task 42 invocation 1 (2026-03-01-12-00-00), promptLen=13"; !strings.Contains(stderr, want) {
+		t.Errorf("stderr = %q, want it to contain %q", stderr, want)
+	}
+	if !strings.Contains(string(res.RawOutput), "replayed") {
+		t.Errorf("RawOutput = %q, want the fixture output", res.RawOutput)
+	}
+	logs, _ := filepath.Glob(filepath.Join(histDir, "*-replay-log.log"))
+	if len(logs) != 1 {
+		t.Errorf("got %d replay logs, want 1", len(logs))
+	}
+
+	wtDir := filepath.Join(worktreeBasePath(), "replay-42-2026-03-01-12-00-00")
+	if want := `dir="` + wtDir + `"`; !strings.Contains(stderr, want) {
+		t.Errorf("Claude should run in the replay worktree %s; stderr = %q", wtDir, stderr)
+	}
+	if _, err := os.Stat(wtDir); !os.IsNotExist(err) {
+		t.Errorf("replay worktree %s should be removed, stat err = %v", wtDir, err)
+	}
+	if gitBranchExists("replay/42-2026-03-01-12-00-00", ".") {
+		t.Error("replay branch should be deleted")
+	}
+}
+
+func TestReplayInvocation_IndexOutOfRange(t *testing.T) {
+	t.Parallel()
+	o, histDir := replayOrchestrator(t)
+	writeStitchInvocation(t, histDir, "2026-03-01-10-00-00", "42", "first prompt")
+
+	for _, idx := range []int{-1, 1} {
+		_, err := o.ReplayInvocation("42", idx)
+		if err == nil || !strings.Contains(err.Error(), "out of range") {
+			t.Errorf("ReplayInvocation(42, %d) error = %v, want out of range", idx, err)
+		}
+	}
+}
+
+func TestReplayInvocation_PromptMissing(t *testing.T) {
+	t.Parallel()
+	o, histDir := replayOrchestrator(t)
+	writeStitchInvocation(t, histDir, "2026-03-01-10-00-00", "42", "")
+
+	_, err := o.ReplayInvocation("42", 0)
+	if err == nil || !strings.Contains(err.Error(), "cannot reconstruct prompt") {
+		t.Errorf("ReplayInvocation error = %v, want cannot reconstruct prompt", err)
+	}
+}
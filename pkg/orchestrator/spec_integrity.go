@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SpecIntegrityReport lists problems with spec file identifiers found by
+// VerifySpecIntegrity.
+type SpecIntegrityReport struct {
+	Errors []string
+}
+
+// Err returns nil when the report has no errors, otherwise an error
+// listing every problem.
+func (r *SpecIntegrityReport) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d spec integrity error(s):\n  %s", len(r.Errors), strings.Join(r.Errors, "\n  "))
+}
+
+// specIDHeader is the part of a spec file VerifySpecIntegrity reads.
+type specIDHeader struct {
+	ID string `yaml:"id"`
+}
+
+// VerifySpecIntegrity loads every PRD, use case, and test suite YAML and
+// checks their id fields: each id must be unique among files of the same
+// type, and must equal the file name without its .yaml suffix. Files that
+// cannot be read or parsed are skipped; Analyze reports those as schema
+// errors.
+func (o *Orchestrator) VerifySpecIntegrity() *SpecIntegrityReport {
+	report := &SpecIntegrityReport{}
+	for _, kind := range []struct{ label, dir, pattern string }{
+		{"PRD", o.cfg.EffectivePRDDir(), "prd*.yaml"},
+		{"use case", o.cfg.EffectiveUseCaseDir(), "rel*.yaml"},
+		{"test suite", o.cfg.EffectiveTestSuiteDir(), "test-rel*.yaml"},
+	} {
+		paths, _ := filepath.Glob(filepath.Join(kind.dir, kind.pattern)) // pattern is static; no error possible
+		report.Errors = append(report.Errors, checkSpecIDs(kind.label, paths, o.cfg.EffectiveMaxSpecFileSize())...)
+	}
+	for _, e := range report.Errors {
+		logf("verifySpecIntegrity: %s", e)
+	}
+	return report
+}
+
+// checkSpecIDs returns the duplicate-id and id-filename mismatch errors
+// for the spec files at paths, all of one kind. A duplicated id is
+// reported once; the files sharing it are not also reported as
+// mismatches, since at most one of them can match.
+func checkSpecIDs(kind string, paths []string, maxFileSize int64) []string {
+	var errs []string
+	filesByID := map[string][]string{}
+	for _, path := range paths {
+		doc := loadSpecYAML[specIDHeader](path, maxFileSize)
+		if doc == nil {
+			continue
+		}
+		if doc.ID == "" {
+			errs = append(errs, fmt.Sprintf("%s %s: missing id", kind, path))
+			continue
+		}
+		filesByID[doc.ID] = append(filesByID[doc.ID], path)
+	}
+
+	ids := make([]string, 0, len(filesByID))
+	for id := range filesByID {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		files := filesByID[id]
+		if len(files) > 1 {
+			errs = append(errs, fmt.Sprintf("%s id %q is declared by %d files: %s", kind, id, len(files), strings.Join(files, ", ")))
+			continue
+		}
+		if want := extractID(files[0]); id != want {
+			errs = append(errs, fmt.Sprintf("%s %s: id %q does not match file name %q", kind, files[0], id, want))
+		}
+	}
+	return errs
+}
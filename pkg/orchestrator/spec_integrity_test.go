@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeSpecFile writes a spec YAML with the given id under dir.
+func writeSpecFile(t *testing.T, dir, name, id string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "id: " + id + "\ntitle: t\n"
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Not parallel: uses os.Chdir.
+func TestVerifySpecIntegrity_AllUnique(t *testing.T) {
+	chdirTemp(t)
+	o := New(Config{})
+	writeSpecFile(t, o.cfg.EffectivePRDDir(), "prd001-core.yaml", "prd001-core")
+	writeSpecFile(t, o.cfg.EffectivePRDDir(), "prd002-cli.yaml", "prd002-cli")
+	writeSpecFile(t, o.cfg.EffectiveUseCaseDir(), "rel01.0-uc001-init.yaml", "rel01.0-uc001-init")
+	writeSpecFile(t, o.cfg.EffectiveTestSuiteDir(), "test-rel01.0.yaml", "test-rel01.0")
+
+	r := o.VerifySpecIntegrity()
+	if len(r.Errors) != 0 || r.Err() != nil {
+		t.Errorf("expected no errors, got %v", r.Errors)
+	}
+}
+
+// Not parallel: uses os.Chdir.
+func TestVerifySpecIntegrity_DuplicateID(t *testing.T) {
+	chdirTemp(t)
+	o := New(Config{})
+	dir := o.cfg.EffectiveUseCaseDir()
+	writeSpecFile(t, dir, "rel01.0-uc001-init.yaml", "rel01.0-uc001-init")
+	writeSpecFile(t, dir, "rel01.0-uc001-init-copy.yaml", "rel01.0-uc001-init")
+
+	r := o.VerifySpecIntegrity()
+	if len(r.Errors) != 1 || !strings.Contains(r.Errors[0], "declared by 2 files") {
+		t.Errorf("expected one duplicate-id error, got %v", r.Errors)
+	}
+}
+
+// Not parallel: uses os.Chdir.
+func TestVerifySpecIntegrity_IDFilenameMismatch(t *testing.T) {
+	chdirTemp(t)
+	o := New(Config{})
+	writeSpecFile(t, o.cfg.EffectivePRDDir(), "prd001-core.yaml", "prd001-kernel")
+
+	r := o.VerifySpecIntegrity()
+	if len(r.Errors) != 1 || !strings.Contains(r.Errors[0], `"prd001-kernel" does not match file name "prd001-core"`) {
+		t.Errorf("expected one mismatch error, got %v", r.Errors)
+	}
+	if r.Err() == nil {
+		t.Error("Err() should be non-nil when the report has errors")
+	}
+}
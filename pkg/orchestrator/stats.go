@@ -5,10 +5,16 @@ package orchestrator
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 	"unicode"
 
 	"gopkg.in/yaml.v3"
@@ -16,20 +22,68 @@ import (
 
 // StatsRecord holds collected LOC and documentation word counts.
 type StatsRecord struct {
-	GoProdLOC int            `yaml:"go_loc_prod"`
-	GoTestLOC int            `yaml:"go_loc_test"`
-	GoLOC     int            `yaml:"go_loc"`
-	SpecWords map[string]int `yaml:"spec_words"`
+	GoProdLOC int            `yaml:"go_loc_prod" json:"go_loc_prod"`
+	GoTestLOC int            `yaml:"go_loc_test" json:"go_loc_test"`
+	GoLOC     int            `yaml:"go_loc" json:"go_loc"`
+	SpecWords map[string]int `yaml:"spec_words" json:"spec_words"`
 }
 
-// CollectStats gathers Go LOC and documentation word counts.
+// statsJSONRecord is the StatsJSON output: a StatsRecord stamped with the
+// time it was collected.
+type statsJSONRecord struct {
+	Timestamp string `json:"timestamp"`
+	StatsRecord
+}
+
+// CollectStats gathers Go LOC and documentation word counts. Go files are
+// read by up to Cobbler.StatsWorkers goroutines; Cobbler.StatsProgress
+// logs how many have been processed.
 func (o *Orchestrator) CollectStats() (StatsRecord, error) {
-	var prodLines, testLines int
+	files, err := o.goSourceFiles()
+	if err != nil {
+		return StatsRecord{}, err
+	}
+	prodLines, testLines := countGoLines(files, o.cfg.Cobbler.StatsWorkers, o.cfg.Cobbler.StatsProgress)
+
+	specWords := make(map[string]int)
+	for _, path := range resolveStandardFiles() {
+		cat := classifyContextFile(path)
+		if cat == "prd" || cat == "use_case" || cat == "test_suite" {
+			words, wordErr := countWordsInFile(path)
+			if wordErr != nil {
+				continue
+			}
+			specWords[cat] += words
+		}
+	}
+	for _, path := range resolveContextSources(strings.Join(o.cfg.Project.SpecGlobs, "\n")) {
+		if words, err := countWordsInFile(path); err == nil {
+			specWords["spec_globs"] += words
+		}
+	}
+
+	return StatsRecord{
+		GoProdLOC: prodLines,
+		GoTestLOC: testLines,
+		GoLOC:     prodLines + testLines,
+		SpecWords: specWords,
+	}, nil
+}
 
+// goSourceFiles walks the working directory and returns the Go files that
+// count toward LOC, skipping vendor, .git, the binary directory,
+// magefiles, and symlinks.
+func (o *Orchestrator) goSourceFiles() ([]string, error) {
+	var files []string
 	err := filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return nil
 		}
+		// Walk reports symlinks via Lstat; skip them so linked files and
+		// directories are neither followed nor double-counted.
+		if info.Mode()&os.ModeSymlink != 0 {
+			return nil
+		}
 		if info.IsDir() {
 			if path == "vendor" || path == ".git" || path == o.cfg.Project.BinaryDir {
 				return filepath.SkipDir
@@ -43,39 +97,60 @@ func (o *Orchestrator) CollectStats() (StatsRecord, error) {
 		if strings.HasPrefix(path, o.cfg.Project.MagefilesDir) {
 			return nil
 		}
-		count, countErr := countLines(path)
-		if countErr != nil {
-			return nil
-		}
-		if strings.HasSuffix(path, "_test.go") {
-			testLines += count
-		} else {
-			prodLines += count
-		}
+		files = append(files, path)
 		return nil
 	})
-	if err != nil {
-		return StatsRecord{}, err
-	}
+	return files, err
+}
 
-	specWords := make(map[string]int)
-	for _, path := range resolveStandardFiles() {
-		cat := classifyContextFile(path)
-		if cat == "prd" || cat == "use_case" || cat == "test_suite" {
-			words, wordErr := countWordsInFile(path)
-			if wordErr != nil {
-				continue
+// statsProgressInterval is how many files CollectStats processes between
+// progress lines.
+const statsProgressInterval = 500
+
+// countGoLines counts the lines of files with a pool of workers goroutines
+// (GOMAXPROCS when workers <= 0) and returns the production and test
+// totals. Unreadable files count as zero. When progress is set, the number
+// of processed files is logged every statsProgressInterval files and at
+// the end.
+func countGoLines(files []string, workers int, progress bool) (prod, test int) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	counts := make([]int, len(files))
+	jobs := make(chan int)
+	var processed atomic.Int64
+	var wg sync.WaitGroup
+	for range min(workers, len(files)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if n, err := countLines(files[i]); err == nil {
+					counts[i] = n
+				}
+				if n := processed.Add(1); progress && n%statsProgressInterval == 0 {
+					logf("collectStats: processed %d/%d Go files", n, len(files))
+				}
 			}
-			specWords[cat] += words
-		}
+		}()
+	}
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	if progress {
+		logf("collectStats: processed %d Go files", len(files))
 	}
 
-	return StatsRecord{
-		GoProdLOC: prodLines,
-		GoTestLOC: testLines,
-		GoLOC:     prodLines + testLines,
-		SpecWords: specWords,
-	}, nil
+	for i, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			test += counts[i]
+		} else {
+			prod += counts[i]
+		}
+	}
+	return prod, test
 }
 
 // Stats prints Go lines of code and documentation word counts as YAML.
@@ -92,6 +167,130 @@ func (o *Orchestrator) Stats() error {
 	return nil
 }
 
+// StatsJSON prints the CollectStats record as a single JSON object with
+// an RFC 3339 UTC timestamp, for ingestion by time-series tooling.
+func (o *Orchestrator) StatsJSON() error {
+	rec, err := o.CollectStats()
+	if err != nil {
+		return err
+	}
+	return writeStatsJSON(os.Stdout, rec, time.Now())
+}
+
+// writeStatsJSON writes rec stamped with now to w as one line of JSON.
+func writeStatsJSON(w io.Writer, rec StatsRecord, now time.Time) error {
+	return json.NewEncoder(w).Encode(statsJSONRecord{
+		Timestamp:   now.UTC().Format(time.RFC3339),
+		StatsRecord: rec,
+	})
+}
+
+// CheckTestRatio fails when the ratio of Go test LOC to production LOC
+// from CollectStats is below Cobbler.MinTestRatio. It prints both counts
+// and the ratio. A zero MinTestRatio disables the check.
+func (o *Orchestrator) CheckTestRatio() error {
+	if o.cfg.Cobbler.MinTestRatio <= 0 {
+		logf("checkTestRatio: min_test_ratio not set, skipping")
+		return nil
+	}
+	rec, err := o.CollectStats()
+	if err != nil {
+		return err
+	}
+	return checkTestRatio(os.Stdout, rec, o.cfg.Cobbler.MinTestRatio)
+}
+
+// checkTestRatio writes rec's test and production LOC and their ratio to
+// w and returns an error when the ratio is below minRatio. A tree with no
+// production code passes.
+func checkTestRatio(w io.Writer, rec StatsRecord, minRatio float64) error {
+	if rec.GoProdLOC == 0 {
+		fmt.Fprintf(w, "test LOC %d, prod LOC 0: no production code, skipping ratio check\n", rec.GoTestLOC)
+		return nil
+	}
+	ratio := float64(rec.GoTestLOC) / float64(rec.GoProdLOC)
+	fmt.Fprintf(w, "test LOC %d, prod LOC %d, ratio %.2f (minimum %.2f)\n", rec.GoTestLOC, rec.GoProdLOC, ratio, minRatio)
+	if ratio < minRatio {
+		return fmt.Errorf("test/prod LOC ratio %.2f is below min_test_ratio %.2f", ratio, minRatio)
+	}
+	return nil
+}
+
+// locSnapshotFile is the name of the LOC snapshot written to the cobbler
+// directory by saveHistory and read by LOCDelta.
+const locSnapshotFile = "loc-snapshot.yaml"
+
+// LOCDelta holds the change in Go lines of code between the last saved
+// snapshot and the current tree. Counts are derived from per-category
+// totals, so growth shows up only as Added and shrinkage only as Removed.
+type LOCDelta struct {
+	ProdAdded   int `yaml:"prod_added"`
+	ProdRemoved int `yaml:"prod_removed"`
+	TestAdded   int `yaml:"test_added"`
+	TestRemoved int `yaml:"test_removed"`
+}
+
+// saveLOCSnapshot writes the current StatsRecord to
+// {Cobbler.Dir}/loc-snapshot.yaml. Errors are logged and otherwise
+// ignored because stats collection is best-effort.
+func (o *Orchestrator) saveLOCSnapshot() {
+	rec, err := o.CollectStats()
+	if err != nil {
+		logf("saveLOCSnapshot: collectStats error: %v", err)
+		return
+	}
+	data, err := yaml.Marshal(rec)
+	if err != nil {
+		logf("saveLOCSnapshot: marshal: %v", err)
+		return
+	}
+	if err := os.MkdirAll(o.cfg.Cobbler.Dir, 0o755); err != nil {
+		logf("saveLOCSnapshot: mkdir %s: %v", o.cfg.Cobbler.Dir, err)
+		return
+	}
+	path := filepath.Join(o.cfg.Cobbler.Dir, locSnapshotFile)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		logf("saveLOCSnapshot: write %s: %v", path, err)
+	}
+}
+
+// LOCDelta compares the current Go LOC counts with the snapshot saved by
+// the last measure run in {Cobbler.Dir}/loc-snapshot.yaml. Returns an
+// error when no snapshot exists or it cannot be parsed.
+func (o *Orchestrator) LOCDelta() (*LOCDelta, error) {
+	path := filepath.Join(o.cfg.Cobbler.Dir, locSnapshotFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading LOC snapshot: %w", err)
+	}
+	var before StatsRecord
+	if err := yaml.Unmarshal(data, &before); err != nil {
+		return nil, fmt.Errorf("parsing LOC snapshot %s: %w", path, err)
+	}
+	after, err := o.CollectStats()
+	if err != nil {
+		return nil, err
+	}
+	return computeLOCDelta(before, after), nil
+}
+
+// computeLOCDelta splits the production and test LOC differences between
+// two records into added and removed counts.
+func computeLOCDelta(before, after StatsRecord) *LOCDelta {
+	d := &LOCDelta{}
+	d.ProdAdded, d.ProdRemoved = splitDelta(after.GoProdLOC - before.GoProdLOC)
+	d.TestAdded, d.TestRemoved = splitDelta(after.GoTestLOC - before.GoTestLOC)
+	return d
+}
+
+// splitDelta returns (n, 0) for a positive n and (0, -n) for a negative n.
+func splitDelta(n int) (added, removed int) {
+	if n >= 0 {
+		return n, 0
+	}
+	return 0, -n
+}
+
 func countLines(path string) (int, error) {
 	f, err := os.Open(path)
 	if err != nil {
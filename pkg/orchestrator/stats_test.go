@@ -4,11 +4,16 @@
 package orchestrator
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 // --- CollectStats ---
@@ -82,6 +87,147 @@ func TestCollectStats_SkipsVendorAndBinaryDir(t *testing.T) {
 	}
 }
 
+func TestCollectStats_SymlinksAreSkipped(t *testing.T) {
+	// Not parallel: uses os.Chdir.
+	dir := t.TempDir()
+	// The linked directory lives outside the walked tree so its files are
+	// reachable only through the symlink.
+	outside := t.TempDir()
+	os.WriteFile(filepath.Join(outside, "linked.go"), []byte("skip\nskip\nskip\n"), 0644)
+	os.WriteFile(filepath.Join(outside, "linked_test.go"), []byte("skip\n"), 0644)
+	if err := os.Symlink(outside, filepath.Join(dir, "pkg")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	os.WriteFile(filepath.Join(dir, "real.go"), []byte("counted\n"), 0644)
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	o := New(Config{})
+	rec, err := o.CollectStats()
+	if err != nil {
+		t.Fatalf("CollectStats: %v", err)
+	}
+	if rec.GoProdLOC != 1 {
+		t.Errorf("GoProdLOC = %d, want 1 (symlinked directory skipped)", rec.GoProdLOC)
+	}
+	if rec.GoTestLOC != 0 {
+		t.Errorf("GoTestLOC = %d, want 0", rec.GoTestLOC)
+	}
+}
+
+func TestCollectStats_SymlinkedGoFileIsSkipped(t *testing.T) {
+	// Not parallel: uses os.Chdir.
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "real.go"), []byte("counted\n"), 0644)
+	if err := os.Symlink(filepath.Join(dir, "real.go"), filepath.Join(dir, "alias.go")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	o := New(Config{})
+	rec, err := o.CollectStats()
+	if err != nil {
+		t.Fatalf("CollectStats: %v", err)
+	}
+	if rec.GoProdLOC != 1 {
+		t.Errorf("GoProdLOC = %d, want 1 (alias.go symlink skipped)", rec.GoProdLOC)
+	}
+}
+
+// writeStatsTree creates n production and n test Go files of varying
+// length under dir, plus files in the skipped vendor, bin, and magefiles
+// directories. It returns the expected production and test LOC.
+func writeStatsTree(t *testing.T, dir string, n int) (prod, test int) {
+	t.Helper()
+	for _, skip := range []string{"vendor", "bin", "magefiles"} {
+		os.MkdirAll(filepath.Join(dir, skip), 0o755)
+		os.WriteFile(filepath.Join(dir, skip, "skip.go"), []byte("skip\nskip\n"), 0o644)
+	}
+	for i := range n {
+		sub := filepath.Join(dir, "pkg", fmt.Sprintf("p%d", i%7))
+		os.MkdirAll(sub, 0o755)
+		lines := strings.Repeat("x\n", i%13+1)
+		os.WriteFile(filepath.Join(sub, fmt.Sprintf("f%d.go", i)), []byte(lines), 0o644)
+		os.WriteFile(filepath.Join(sub, fmt.Sprintf("f%d_test.go", i)), []byte(lines+"y\n"), 0o644)
+		prod += i%13 + 1
+		test += i%13 + 2
+	}
+	return prod, test
+}
+
+func TestCollectStats_ParallelMatchesSerial(t *testing.T) {
+	// Not parallel: uses os.Chdir.
+	dir := t.TempDir()
+	wantProd, wantTest := writeStatsTree(t, dir, 300)
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	for _, workers := range []int{1, 0, 4, 64} {
+		cfg := Config{}
+		cfg.Cobbler.StatsWorkers = workers
+		rec, err := New(cfg).CollectStats()
+		if err != nil {
+			t.Fatalf("workers=%d: CollectStats: %v", workers, err)
+		}
+		if rec.GoProdLOC != wantProd || rec.GoTestLOC != wantTest {
+			t.Errorf("workers=%d: prod=%d test=%d, want prod=%d test=%d",
+				workers, rec.GoProdLOC, rec.GoTestLOC, wantProd, wantTest)
+		}
+	}
+}
+
+func TestCollectStats_ProgressOutput(t *testing.T) {
+	// Not parallel: uses os.Chdir and captures stderr.
+	dir := t.TempDir()
+	writeStatsTree(t, dir, statsProgressInterval/2)
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	quiet := captureStderr(t, func() { New(Config{}).CollectStats() })
+	if strings.Contains(quiet, "collectStats:") {
+		t.Errorf("progress logged with StatsProgress off:\n%s", quiet)
+	}
+
+	cfg := Config{}
+	cfg.Cobbler.StatsProgress = true
+	verbose := captureStderr(t, func() { New(cfg).CollectStats() })
+	if !strings.Contains(verbose, fmt.Sprintf("collectStats: processed %d/%d Go files", statsProgressInterval, statsProgressInterval)) {
+		t.Errorf("missing interval progress line:\n%s", verbose)
+	}
+	if !strings.Contains(verbose, fmt.Sprintf("collectStats: processed %d Go files", statsProgressInterval)) {
+		t.Errorf("missing final progress line:\n%s", verbose)
+	}
+}
+
 // --- countLines ---
 
 func TestCountLines_MultipleLines(t *testing.T) {
@@ -266,3 +412,149 @@ func TestCollectStats_CountsSpecWords(t *testing.T) {
 		t.Error("expected non-zero use_case word count")
 	}
 }
+
+// --- StatsJSON ---
+
+func TestWriteStatsJSON_RoundTrip(t *testing.T) {
+	t.Parallel()
+	rec := StatsRecord{
+		GoProdLOC: 120,
+		GoTestLOC: 45,
+		GoLOC:     165,
+		SpecWords: map[string]int{"prd": 300, "use_case": 150},
+	}
+	now := time.Date(2026, 3, 1, 12, 30, 0, 0, time.FixedZone("X", 3600))
+
+	var buf bytes.Buffer
+	if err := writeStatsJSON(&buf, rec, now); err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if got["timestamp"] != "2026-03-01T11:30:00Z" {
+		t.Errorf("timestamp = %v, want 2026-03-01T11:30:00Z", got["timestamp"])
+	}
+
+	var back statsJSONRecord
+	if err := json.Unmarshal(buf.Bytes(), &back); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back.StatsRecord, rec) {
+		t.Errorf("round trip = %+v, want %+v", back.StatsRecord, rec)
+	}
+	if back.GoLOC != back.GoProdLOC+back.GoTestLOC {
+		t.Errorf("go_loc %d != go_loc_prod %d + go_loc_test %d", back.GoLOC, back.GoProdLOC, back.GoTestLOC)
+	}
+}
+
+// --- CheckTestRatio ---
+
+func TestCheckTestRatio_AboveThreshold(t *testing.T) {
+	t.Parallel()
+	var buf strings.Builder
+	rec := StatsRecord{GoProdLOC: 100, GoTestLOC: 80}
+	if err := checkTestRatio(&buf, rec, 0.5); err != nil {
+		t.Errorf("checkTestRatio() = %v, want nil", err)
+	}
+	if want := "test LOC 80, prod LOC 100, ratio 0.80"; !strings.Contains(buf.String(), want) {
+		t.Errorf("output = %q, want it to contain %q", buf.String(), want)
+	}
+}
+
+func TestCheckTestRatio_BelowThreshold(t *testing.T) {
+	t.Parallel()
+	var buf strings.Builder
+	rec := StatsRecord{GoProdLOC: 100, GoTestLOC: 20}
+	err := checkTestRatio(&buf, rec, 0.5)
+	if err == nil {
+		t.Fatal("checkTestRatio() = nil, want error below threshold")
+	}
+	if !strings.Contains(err.Error(), "0.20") || !strings.Contains(err.Error(), "0.50") {
+		t.Errorf("error = %q, want ratio and threshold", err)
+	}
+	if want := "test LOC 20, prod LOC 100, ratio 0.20"; !strings.Contains(buf.String(), want) {
+		t.Errorf("output = %q, want it to contain %q", buf.String(), want)
+	}
+}
+
+func TestCheckTestRatio_NoProductionCode(t *testing.T) {
+	t.Parallel()
+	if err := checkTestRatio(io.Discard, StatsRecord{GoTestLOC: 5}, 0.5); err != nil {
+		t.Errorf("checkTestRatio() = %v, want nil without production code", err)
+	}
+}
+
+func TestCheckTestRatio_ZeroThresholdDisabled(t *testing.T) {
+	// Not parallel: uses os.Chdir.
+	dir := t.TempDir()
+	writeStatsTree(t, dir, 2)
+	os.WriteFile(filepath.Join(dir, "big.go"), []byte(strings.Repeat("// x\n", 1000)), 0o644)
+
+	origDir, _ := os.Getwd()
+	os.Chdir(dir)
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	if err := New(Config{}).CheckTestRatio(); err != nil {
+		t.Errorf("CheckTestRatio() with zero threshold = %v, want nil", err)
+	}
+	cfg := Config{}
+	cfg.Cobbler.MinTestRatio = 0.9
+	if err := New(cfg).CheckTestRatio(); err == nil {
+		t.Error("CheckTestRatio() = nil, want error with min_test_ratio 0.9")
+	}
+}
+
+// --- LOCDelta ---
+
+func TestLOCDelta_BeforeAfterSnapshots(t *testing.T) {
+	// Not parallel: uses os.Chdir.
+	dir := t.TempDir()
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	os.WriteFile("a.go", []byte("1\n2\n3\n4\n"), 0644)
+	os.WriteFile("a_test.go", []byte("1\n2\n3\n4\n5\n6\n"), 0644)
+
+	o := New(Config{Cobbler: CobblerConfig{Dir: filepath.Join(dir, ".cobbler")}})
+	o.saveLOCSnapshot()
+
+	// Grow production code by 3 lines and shrink tests by 2.
+	os.WriteFile("a.go", []byte("1\n2\n3\n4\n5\n6\n7\n"), 0644)
+	os.WriteFile("a_test.go", []byte("1\n2\n3\n4\n"), 0644)
+
+	got, err := o.LOCDelta()
+	if err != nil {
+		t.Fatalf("LOCDelta: %v", err)
+	}
+	want := LOCDelta{ProdAdded: 3, ProdRemoved: 0, TestAdded: 0, TestRemoved: 2}
+	if *got != want {
+		t.Errorf("LOCDelta = %+v, want %+v", *got, want)
+	}
+}
+
+func TestLOCDelta_NoSnapshot(t *testing.T) {
+	t.Parallel()
+	o := New(Config{Cobbler: CobblerConfig{Dir: t.TempDir()}})
+	if _, err := o.LOCDelta(); err == nil {
+		t.Error("expected error when no snapshot exists")
+	}
+}
+
+func TestComputeLOCDelta(t *testing.T) {
+	t.Parallel()
+	before := StatsRecord{GoProdLOC: 100, GoTestLOC: 40}
+	after := StatsRecord{GoProdLOC: 80, GoTestLOC: 55}
+	got := computeLOCDelta(before, after)
+	want := LOCDelta{ProdRemoved: 20, TestAdded: 15}
+	if *got != want {
+		t.Errorf("computeLOCDelta = %+v, want %+v", *got, want)
+	}
+}
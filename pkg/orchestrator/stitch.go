@@ -4,12 +4,14 @@
 package orchestrator
 
 import (
+	"cmp"
 	_ "embed"
 	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -118,49 +120,91 @@ func (o *Orchestrator) RunStitchN(limit int) (int, error) {
 		return 0, fmt.Errorf("recovery: %w", err)
 	}
 
+	totalTasks, stop, err := stitchLoop(limit, o.cfg.Cobbler.MaxConsecutiveStitchFailures,
+		func() (stitchTask, error) { return pickTask(baseBranch, worktreeBase, ghRepo, generation) },
+		func(task stitchTask) error { return o.doOneTask(task, baseBranch, repoRoot) })
+	if err != nil {
+		return totalTasks, err
+	}
+
+	logf("completed %d task(s) in %s (%s)", totalTasks, time.Since(stitchStart).Round(time.Second), stop)
+	return totalTasks, nil
+}
+
+// stitchStopReason records why the stitch loop ended, so the log tells
+// users whether ready work may remain.
+type stitchStopReason string
+
+const (
+	// stitchStopCap means the loop hit MaxStitchIssuesPerCycle; more
+	// ready issues may remain for the next cycle.
+	stitchStopCap stitchStopReason = "MaxStitchIssuesPerCycle cap reached, ready issues may remain"
+	// stitchStopEmpty means no ready issue could be picked.
+	stitchStopEmpty stitchStopReason = "no more ready issues"
+	// stitchStopRepeatFailure means a task reset earlier in this cycle
+	// was picked again.
+	stitchStopRepeatFailure stitchStopReason = "task already failed this cycle"
+)
+
+// stitchStopCircuitBreaker means maxFailures (MaxConsecutiveStitchFailures)
+// tasks in a row failed.
+func stitchStopCircuitBreaker(maxFailures int) stitchStopReason {
+	return stitchStopReason(fmt.Sprintf("%d consecutive failures (MaxConsecutiveStitchFailures), circuit breaker tripped", maxFailures))
+}
+
+// stitchLoop picks and runs tasks until limit tasks complete (0 means no
+// limit), pick finds no ready task, or a task reset earlier in the loop is
+// picked again, or maxFailures tasks in a row are reset (0 disables this
+// check). It returns the number of completed tasks and why it stopped. A
+// run error other than errTaskReset ends the loop with that error.
+func stitchLoop(limit, maxFailures int, pick func() (stitchTask, error), run func(stitchTask) error) (int, stitchStopReason, error) {
 	totalTasks := 0
+	consecutiveFailures := 0
 	// failedTaskIDs tracks tasks that returned errTaskReset in this cycle.
 	// A task whose in-progress label is removed is re-eligible immediately,
 	// so without this set the stitch loop retries the same task indefinitely.
 	failedTaskIDs := map[string]struct{}{}
 	for {
 		if limit > 0 && totalTasks >= limit {
-			logf("reached per-cycle limit (%d), pausing for measure", limit)
-			break
+			logf("stopped after %d issues (MaxStitchIssuesPerCycle cap), pausing for measure", totalTasks)
+			return totalTasks, stitchStopCap, nil
 		}
 
 		logf("looking for next ready task (completed %d so far)", totalTasks)
-		task, err := pickTask(baseBranch, worktreeBase, ghRepo, generation)
+		task, err := pick()
 		if err != nil {
-			logf("no more tasks: %v", err)
-			break
+			logf("no more ready issues: %v", err)
+			return totalTasks, stitchStopEmpty, nil
 		}
 
 		// If this task already failed in the current cycle, stop. It was
 		// reset to open and will be retried in the next measure+stitch cycle.
 		if _, alreadyFailed := failedTaskIDs[task.id]; alreadyFailed {
 			logf("task %s already failed this cycle, stopping stitch", task.id)
-			break
+			return totalTasks, stitchStopRepeatFailure, nil
 		}
 
 		taskStart := time.Now()
 		logf("executing task %d: id=%s title=%q", totalTasks+1, task.id, task.title)
-		if err := o.doOneTask(task, baseBranch, repoRoot); err != nil {
+		if err := run(task); err != nil {
 			if errors.Is(err, errTaskReset) {
 				logf("task %s was reset after %s, continuing", task.id, time.Since(taskStart).Round(time.Second))
 				failedTaskIDs[task.id] = struct{}{}
+				consecutiveFailures++
+				if maxFailures > 0 && consecutiveFailures >= maxFailures {
+					logf("%d consecutive task failures (MaxConsecutiveStitchFailures=%d), aborting stitch", consecutiveFailures, maxFailures)
+					return totalTasks, stitchStopCircuitBreaker(maxFailures), nil
+				}
 				continue
 			}
 			logf("task %s failed after %s: %v", task.id, time.Since(taskStart).Round(time.Second), err)
-			return totalTasks, fmt.Errorf("executing task %s: %w", task.id, err)
+			return totalTasks, "", fmt.Errorf("executing task %s: %w", task.id, err)
 		}
 		logf("task %s completed in %s", task.id, time.Since(taskStart).Round(time.Second))
 
+		consecutiveFailures = 0
 		totalTasks++
 	}
-
-	logf("completed %d task(s) in %s", totalTasks, time.Since(stitchStart).Round(time.Second))
-	return totalTasks, nil
 }
 
 // taskBranchName returns the git branch name for a stitch task.
@@ -181,10 +225,31 @@ type stitchTask struct {
 	description string
 	issueType   string
 	branchName  string
+	baseBranch  string // branch the worktree starts from; empty = current branch
 	worktreeDir string
 	ghNumber    int    // GitHub issue number — used for closing/labelling
 	generation  string // generation label value
 	repo        string // GitHub owner/repo
+	priority    int    // description priority field; lower runs first (default 50)
+}
+
+// defaultTaskPriority is the priority of a task whose description has no
+// priority field.
+const defaultTaskPriority = 50
+
+// compareStitchTasks orders tasks for processing: lower priority first,
+// ties broken by task ID. IDs that are both issue numbers compare
+// numerically (#9 before #10); any other IDs compare lexicographically.
+func compareStitchTasks(a, b stitchTask) int {
+	if c := cmp.Compare(a.priority, b.priority); c != 0 {
+		return c
+	}
+	na, errA := strconv.Atoi(a.id)
+	nb, errB := strconv.Atoi(b.id)
+	if errA == nil && errB == nil {
+		return cmp.Compare(na, nb)
+	}
+	return strings.Compare(a.id, b.id)
 }
 
 // recoverStaleTasks cleans up task branches and orphaned in_progress issues
@@ -297,10 +362,12 @@ func pickTask(baseBranch, worktreeBase, repo, generation string) (stitchTask, er
 		description: iss.Description,
 		issueType:   "task",
 		branchName:  taskBranchName(baseBranch, id),
+		baseBranch:  iss.BaseBranch,
 		worktreeDir: filepath.Join(worktreeBase, id),
 		ghNumber:    iss.Number,
 		generation:  generation,
 		repo:        repo,
+		priority:    parseTaskPriority(iss.Description),
 	}
 
 	// Validate the issue description as YAML with required fields.
@@ -309,7 +376,7 @@ func pickTask(baseBranch, worktreeBase, repo, generation string) (stitchTask, er
 	}
 
 	logf("pickTask: picked #%d id=%s branch=%s worktree=%s", iss.Number, task.id, task.branchName, task.worktreeDir)
-	logf("pickTask: title=%q", task.title)
+	logf("pickTask: title=%q priority=%d", task.title, task.priority)
 	logf("pickTask: descriptionLen=%d", len(task.description))
 	return task, nil
 }
@@ -330,6 +397,19 @@ func parseRequiredReading(description string) []string {
 	return parsed.RequiredReading
 }
 
+// parseTaskPriority extracts the priority field from a YAML task
+// description. Returns defaultTaskPriority if the field is absent or the
+// description is unparseable.
+func parseTaskPriority(description string) int {
+	var parsed struct {
+		Priority *int `yaml:"priority"`
+	}
+	if err := yaml.Unmarshal([]byte(description), &parsed); err != nil || parsed.Priority == nil {
+		return defaultTaskPriority
+	}
+	return *parsed.Priority
+}
+
 // validateIssueDescription checks that a description parses as valid YAML
 // and contains the required top-level keys defined by the issue-format
 // constitution. Returns an error describing what is missing; callers
@@ -364,6 +444,13 @@ func (o *Orchestrator) doOneTask(task stitchTask, baseBranch, repoRoot string) e
 	// The cobbler-in-progress label was added by pickReadyIssue; no separate claim step is needed.
 	logf("doOneTask: task #%d claimed via pickReadyIssue label", task.ghNumber)
 
+	// A per-issue base branch must exist before a worktree can start from it.
+	if err := checkTaskBaseBranch(task); err != nil {
+		logf("doOneTask: %v", err)
+		o.resetTask(task, "missing base branch")
+		return errTaskReset
+	}
+
 	// Create worktree.
 	logf("doOneTask: creating worktree for %s", task.id)
 	wtStart := time.Now()
@@ -416,9 +503,23 @@ func (o *Orchestrator) doOneTask(task stitchTask, baseBranch, repoRoot string) e
 	}
 	logf("doOneTask: Claude completed for %s in %s", task.id, time.Since(claudeStart).Round(time.Second))
 
+	// Optionally format the Go files Claude touched so the task commit
+	// carries no formatting noise. A formatter failure is not fatal.
+	if o.cfg.Cobbler.FormatStitchChanges {
+		formatted, err := formatStitchChanges(task.worktreeDir, o.cfg.Cobbler.FormatStitchGoimports)
+		switch {
+		case err != nil:
+			logf("doOneTask: format warning for %s: %v", task.id, err)
+		case len(formatted) > 0:
+			logf("doOneTask: formatted %d file(s) for %s: %s", len(formatted), task.id, strings.Join(formatted, ", "))
+		default:
+			logf("doOneTask: formatting made no changes for %s", task.id)
+		}
+	}
+
 	// Commit Claude's changes in the worktree. Claude does not run git;
 	// the orchestrator manages all git operations externally.
-	if err := commitWorktreeChanges(task); err != nil {
+	if err := commitWorktreeChanges(task, o.stitchCommitAuthor()); err != nil {
 		logf("doOneTask: worktree commit failed for %s: %v", task.id, err)
 		o.saveHistoryStats(historyTS, "stitch", HistoryStats{
 			Caller:    "stitch",
@@ -437,6 +538,34 @@ func (o *Orchestrator) doOneTask(task stitchTask, baseBranch, repoRoot string) e
 		return errTaskReset
 	}
 
+	// Optionally run the tests in the worktree and record the result on
+	// the issue. A failing run parks the task for review when configured.
+	if o.cfg.Cobbler.StitchTests {
+		res := runStitchTests(task.worktreeDir, o.cfg.Cobbler.StitchTestCommand)
+		o.saveHistoryLog(historyTS, "stitch-tests", res.Output)
+		logf("doOneTask: %s for %s", res.summary(), task.id)
+		if err := commentOnIssue(task.repo, task.ghNumber, formatStitchTestComment(task.id, res)); err != nil {
+			logf("doOneTask: test result comment warning for #%d: %v", task.ghNumber, err)
+		}
+		if res.Err != nil && o.cfg.Cobbler.StitchTestFailureReview {
+			o.saveHistoryStats(historyTS, "stitch", HistoryStats{
+				Caller:    "stitch",
+				TaskID:    task.id,
+				TaskTitle: task.title,
+				Status:    "failed",
+				Error:     fmt.Sprintf("test failure: %v", res.Err),
+				StartedAt: claudeStart.UTC().Format(time.RFC3339),
+				Duration:  time.Since(taskStart).Round(time.Second).String(),
+				DurationS: int(time.Since(taskStart).Seconds()),
+				Tokens:    historyTokens{Input: tokens.InputTokens, Output: tokens.OutputTokens, CacheCreation: tokens.CacheCreationTokens, CacheRead: tokens.CacheReadTokens},
+				CostUSD:   tokens.CostUSD,
+				LOCBefore: locBefore,
+			})
+			o.markTaskForReview(task, "test failure")
+			return errTaskReset
+		}
+	}
+
 	// Append outcome trailers to the worktree commit before merging.
 	// Trailers must be on the pre-merge commit so they travel into the
 	// generation branch history. LOCAfter and Diff are not yet available
@@ -551,6 +680,15 @@ func (o *Orchestrator) doOneTask(task stitchTask, baseBranch, repoRoot string) e
 	return nil
 }
 
+// checkTaskBaseBranch returns an error when the task names a base branch
+// that does not exist locally. Tasks without a base branch always pass.
+func checkTaskBaseBranch(task stitchTask) error {
+	if task.baseBranch == "" || gitBranchExists(task.baseBranch, ".") {
+		return nil
+	}
+	return fmt.Errorf("base branch %s for task %s does not exist", task.baseBranch, task.id)
+}
+
 func createWorktree(task stitchTask) error {
 	logf("createWorktree: dir=%s branch=%s", task.worktreeDir, task.branchName)
 
@@ -560,7 +698,14 @@ func createWorktree(task stitchTask) error {
 
 	if !gitBranchExists(task.branchName, ".") {
 		logf("createWorktree: branch %s does not exist, creating", task.branchName)
-		if err := gitCreateBranch(task.branchName, "."); err != nil {
+		var err error
+		if task.baseBranch != "" {
+			logf("createWorktree: branching from %s", task.baseBranch)
+			err = gitCreateBranchFrom(task.branchName, task.baseBranch, ".")
+		} else {
+			err = gitCreateBranch(task.branchName, ".")
+		}
+		if err != nil {
 			logf("createWorktree: gitCreateBranch failed: %v", err)
 			return fmt.Errorf("creating branch %s: %w", task.branchName, err)
 		}
@@ -616,7 +761,7 @@ func (o *Orchestrator) buildStitchPrompt(task stitchTask) (string, error) {
 			logf("buildStitchPrompt: chdir to worktree error: %v", err)
 		} else {
 			defer os.Chdir(orig)
-			ctx, ctxErr := buildProjectContext("", o.cfg.Project, phaseCtx)
+			ctx, ctxErr := buildProjectContext("", o.cfg.Project, phaseCtx, o.cfg.EffectiveAnalysisFileName(), o.cfg.EffectiveMaxSpecFileSize())
 			if ctxErr != nil {
 				logf("buildStitchPrompt: buildProjectContext error: %v", ctxErr)
 			} else {
@@ -668,6 +813,8 @@ func (o *Orchestrator) buildStitchPrompt(task stitchTask) (string, error) {
 		Task:                  tmpl.Task,
 		Constraints:           tmpl.Constraints,
 		Description:           task.description,
+		Extensions:            stitchPromptExtensions(o.cfg.Cobbler.StitchPromptExtensions, o.cfg.Cobbler.Dir, task.id),
+		Footer:                o.cfg.Cobbler.StitchPromptFooter,
 	}
 
 	out, err := yaml.Marshal(&doc)
@@ -679,6 +826,29 @@ func (o *Orchestrator) buildStitchPrompt(task stitchTask) (string, error) {
 	return string(out), nil
 }
 
+// stitchPromptExtensions returns the contents of the global extension
+// files followed by the per-issue extension {cobblerDir}/extensions/{taskID}.txt.
+// Files that cannot be read are logged and skipped.
+func stitchPromptExtensions(globals []string, cobblerDir, taskID string) []string {
+	paths := append([]string{}, globals...)
+	if taskID != "" {
+		paths = append(paths, filepath.Join(cobblerDir, "extensions", taskID+".txt"))
+	}
+	var exts []string
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				logf("stitchPromptExtensions: skipping %s: %v", p, err)
+			}
+			continue
+		}
+		logf("stitchPromptExtensions: appending %s (%d bytes)", p, len(data))
+		exts = append(exts, string(data))
+	}
+	return exts
+}
+
 func mergeBranch(branchName, baseBranch, repoRoot string) error {
 	logf("mergeBranch: %s into %s (repoRoot=%s)", branchName, baseBranch, repoRoot)
 
@@ -703,10 +873,52 @@ func mergeBranch(branchName, baseBranch, repoRoot string) error {
 	return nil
 }
 
+// commitAuthor overrides the author identity of stitch commits. Empty
+// fields leave the ambient git configuration in effect.
+type commitAuthor struct {
+	Name  string
+	Email string
+}
+
+// stitchCommitAuthor returns the configured stitch commit author.
+func (o *Orchestrator) stitchCommitAuthor() commitAuthor {
+	return commitAuthor{Name: o.cfg.Cobbler.CommitAuthorName, Email: o.cfg.Cobbler.CommitAuthorEmail}
+}
+
+// env returns the GIT_AUTHOR_* variables for the fields that are set.
+func (a commitAuthor) env() []string {
+	var env []string
+	if a.Name != "" {
+		env = append(env, "GIT_AUTHOR_NAME="+a.Name)
+	}
+	if a.Email != "" {
+		env = append(env, "GIT_AUTHOR_EMAIL="+a.Email)
+	}
+	return env
+}
+
+// gitCommitWorktreeFn runs the stitch commit in a worktree. Tests replace
+// it to inspect the message and author without running git.
+var gitCommitWorktreeFn = gitCommitWorktree
+
+// gitCommitWorktree commits the staged changes in dir with msg, applying
+// any author override through the environment.
+func gitCommitWorktree(dir, msg string, author commitAuthor) error {
+	cmd := cmdGit(dir, "commit", "--no-verify", "-m", msg)
+	if env := author.env(); len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git commit: %w\n%s", err, out)
+	}
+	return nil
+}
+
 // commitWorktreeChanges stages and commits all changes Claude made in the
 // worktree. Claude does not run git commands; the orchestrator handles git
-// externally. Returns nil if there are no changes to commit.
-func commitWorktreeChanges(task stitchTask) error {
+// externally. author overrides the commit author where set. Returns nil if
+// there are no changes to commit.
+func commitWorktreeChanges(task stitchTask, author commitAuthor) error {
 	logf("commitWorktreeChanges: staging changes in %s", task.worktreeDir)
 
 	addCmd := exec.Command(binGit, "add", "-A")
@@ -725,10 +937,8 @@ func commitWorktreeChanges(task stitchTask) error {
 
 	msg := fmt.Sprintf("Task %s: %s", task.id, task.title)
 	logf("commitWorktreeChanges: committing %q", msg)
-	commitCmd := exec.Command(binGit, "commit", "--no-verify", "-m", msg)
-	commitCmd.Dir = task.worktreeDir
-	if out, err := commitCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("git commit: %w\n%s", err, out)
+	if err := gitCommitWorktreeFn(task.worktreeDir, msg, author); err != nil {
+		return err
 	}
 
 	logf("commitWorktreeChanges: committed in worktree for %s", task.id)
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// changedGoFiles returns the .go files added, modified, or renamed in the
+// worktree at dir relative to HEAD, including untracked files. Deleted
+// files are excluded. Paths are relative to dir.
+func changedGoFiles(dir string) ([]string, error) {
+	if out, err := cmdGit(dir, "add", "-A").CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("git add -A: %w\n%s", err, out)
+	}
+	out, err := cmdGit(dir, "diff", "--cached", "--name-only", "--diff-filter=d").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff --cached: %w", err)
+	}
+	var files []string
+	for line := range strings.SplitSeq(strings.TrimSpace(string(out)), "\n") {
+		if strings.HasSuffix(line, ".go") {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// formatFiles runs bin with -l -w over files in dir and returns the files
+// the tool rewrote.
+func formatFiles(bin, dir string, files []string) ([]string, error) {
+	cmd := exec.Command(bin, append([]string{"-l", "-w"}, files...)...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("%s: %w\n%s", bin, err, ee.Stderr)
+		}
+		return nil, fmt.Errorf("%s: %w", bin, err)
+	}
+	var changed []string
+	for line := range strings.SplitSeq(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			changed = append(changed, line)
+		}
+	}
+	return changed, nil
+}
+
+// formatStitchChanges formats the .go files changed in the worktree at
+// dir with gofmt and, when goimports is true, goimports. It returns the
+// sorted list of files whose content changed. A missing goimports binary
+// is logged and skipped.
+func formatStitchChanges(dir string, goimports bool) ([]string, error) {
+	files, err := changedGoFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	changed, err := formatFiles(binGofmt, dir, files)
+	if err != nil {
+		return nil, err
+	}
+	if goimports {
+		if _, lookErr := exec.LookPath(binGoimports); lookErr != nil {
+			logf("formatStitchChanges: %s not found on PATH, skipping", binGoimports)
+		} else {
+			more, err := formatFiles(binGoimports, dir, files)
+			if err != nil {
+				return nil, err
+			}
+			changed = append(changed, more...)
+		}
+	}
+	slices.Sort(changed)
+	return slices.Compact(changed), nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// --- formatStitchChanges ---
+
+func TestFormatStitchChanges_FormatsOnlyChangedGoFiles(t *testing.T) {
+	dir := initTestGitRepo(t)
+
+	// A committed, unformatted Go file that is not part of the diff.
+	untouched := "package x\nfunc  A() {}\n"
+	os.WriteFile(filepath.Join(dir, "old.go"), []byte(untouched), 0o644)
+	gitRun(t, "add", "-A")
+	gitRun(t, "commit", "--no-verify", "-m", "add old.go")
+
+	// Changes in the worktree: one unformatted Go file, one already
+	// formatted Go file, and a non-Go file with odd spacing.
+	os.WriteFile(filepath.Join(dir, "new.go"), []byte("package x\nfunc  B() {\n}\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "clean.go"), []byte("package x\n\nfunc C() {}\n"), 0o644)
+	notes := "func  B() {}\n"
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte(notes), 0o644)
+
+	changed, err := formatStitchChanges(dir, false)
+	if err != nil {
+		t.Fatalf("formatStitchChanges: %v", err)
+	}
+	if !slices.Equal(changed, []string{"new.go"}) {
+		t.Errorf("changed = %v, want [new.go]", changed)
+	}
+
+	got, _ := os.ReadFile(filepath.Join(dir, "new.go"))
+	if want := "package x\n\nfunc B() {\n}\n"; string(got) != want {
+		t.Errorf("new.go = %q, want %q", got, want)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, "old.go")); string(got) != untouched {
+		t.Errorf("old.go was modified: %q", got)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, "notes.txt")); string(got) != notes {
+		t.Errorf("notes.txt was modified: %q", got)
+	}
+}
+
+func TestFormatStitchChanges_NoGoChanges(t *testing.T) {
+	dir := initTestGitRepo(t)
+	os.WriteFile(filepath.Join(dir, "README.md"), []byte("# hi\n"), 0o644)
+
+	changed, err := formatStitchChanges(dir, true)
+	if err != nil {
+		t.Fatalf("formatStitchChanges: %v", err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("changed = %v, want none", changed)
+	}
+}
@@ -4,9 +4,11 @@
 package orchestrator
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -17,6 +19,94 @@ func TestErrTaskReset_MentionsOpen(t *testing.T) {
 	}
 }
 
+// --- stitchLoop stop reasons ---
+
+// fakeStitchQueue returns a pick function that hands out n ready tasks
+// and then reports an empty queue.
+func fakeStitchQueue(n int) func() (stitchTask, error) {
+	next := 0
+	return func() (stitchTask, error) {
+		if next >= n {
+			return stitchTask{}, fmt.Errorf("no ready tasks")
+		}
+		next++
+		return stitchTask{id: fmt.Sprintf("%d", next), title: "task"}, nil
+	}
+}
+
+func TestStitchLoop_CapReachedWithRemainingIssues(t *testing.T) {
+	var n int
+	var stop stitchStopReason
+	out := captureStderr(t, func() {
+		n, stop, _ = stitchLoop(2, 0, fakeStitchQueue(5), func(stitchTask) error { return nil })
+	})
+	if n != 2 || stop != stitchStopCap {
+		t.Errorf("stitchLoop = (%d, %q), want (2, %q)", n, stop, stitchStopCap)
+	}
+	if !strings.Contains(out, "stopped after 2 issues (MaxStitchIssuesPerCycle cap)") {
+		t.Errorf("log should report the cap, got:\n%s", out)
+	}
+	if strings.Contains(out, "no more ready issues") {
+		t.Errorf("log should not report an empty queue when the cap is hit, got:\n%s", out)
+	}
+}
+
+func TestStitchLoop_QueueEmptyBeforeCap(t *testing.T) {
+	var n int
+	var stop stitchStopReason
+	out := captureStderr(t, func() {
+		n, stop, _ = stitchLoop(5, 0, fakeStitchQueue(2), func(stitchTask) error { return nil })
+	})
+	if n != 2 || stop != stitchStopEmpty {
+		t.Errorf("stitchLoop = (%d, %q), want (2, %q)", n, stop, stitchStopEmpty)
+	}
+	if strings.Contains(out, "MaxStitchIssuesPerCycle cap") {
+		t.Errorf("log should not report the cap when the queue empties, got:\n%s", out)
+	}
+}
+
+func TestStitchLoop_CircuitBreakerTrips(t *testing.T) {
+	var n int
+	var stop stitchStopReason
+	out := captureStderr(t, func() {
+		n, stop, _ = stitchLoop(0, 3, fakeStitchQueue(10), func(stitchTask) error { return errTaskReset })
+	})
+	if want := stitchStopReason("3 consecutive failures (MaxConsecutiveStitchFailures), circuit breaker tripped"); n != 0 || stop != want {
+		t.Errorf("stitchLoop = (%d, %q), want (0, %q)", n, stop, want)
+	}
+	if !strings.Contains(out, "3 consecutive task failures") {
+		t.Errorf("log should report the breaker, got:\n%s", out)
+	}
+}
+
+func TestStitchLoop_CircuitBreakerResetOnSuccess(t *testing.T) {
+	// fail, fail, succeed, fail, fail, succeed: never three in a row.
+	results := []error{errTaskReset, errTaskReset, nil, errTaskReset, errTaskReset, nil}
+	calls := 0
+	var n int
+	var stop stitchStopReason
+	captureStderr(t, func() {
+		n, stop, _ = stitchLoop(0, 3, fakeStitchQueue(len(results)), func(stitchTask) error {
+			err := results[calls]
+			calls++
+			return err
+		})
+	})
+	if n != 2 || stop != stitchStopEmpty || calls != len(results) {
+		t.Errorf("stitchLoop = (%d, %q) after %d runs, want (2, %q) after %d", n, stop, calls, stitchStopEmpty, len(results))
+	}
+}
+
+func TestStitchLoop_CircuitBreakerDisabled(t *testing.T) {
+	var stop stitchStopReason
+	captureStderr(t, func() {
+		_, stop, _ = stitchLoop(0, 0, fakeStitchQueue(5), func(stitchTask) error { return errTaskReset })
+	})
+	if stop != stitchStopEmpty {
+		t.Errorf("stop = %q, want %q with the breaker disabled", stop, stitchStopEmpty)
+	}
+}
+
 // --- failed-task cycle tracking ---
 
 // TestRunStitchN_SkipsAlreadyFailedTask verifies the core invariant of the
@@ -222,6 +312,108 @@ func TestBuildStitchPrompt_InvalidTemplate(t *testing.T) {
 	}
 }
 
+// --- stitchPromptExtensions ---
+
+func TestStitchPromptExtensions_GlobalOnly(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	global := filepath.Join(dir, "global.txt")
+	os.WriteFile(global, []byte("global guidance"), 0o644)
+
+	got := stitchPromptExtensions([]string{global}, dir, "7")
+	if len(got) != 1 || got[0] != "global guidance" {
+		t.Errorf("got %q, want [global guidance]", got)
+	}
+}
+
+func TestStitchPromptExtensions_PerIssueOnly(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "extensions"), 0o755)
+	os.WriteFile(filepath.Join(dir, "extensions", "7.txt"), []byte("issue 7 notes"), 0o644)
+
+	got := stitchPromptExtensions(nil, dir, "7")
+	if len(got) != 1 || got[0] != "issue 7 notes" {
+		t.Errorf("got %q, want [issue 7 notes]", got)
+	}
+	if other := stitchPromptExtensions(nil, dir, "8"); len(other) != 0 {
+		t.Errorf("issue 8 got %q, want none", other)
+	}
+}
+
+func TestStitchPromptExtensions_BothCombined(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	global := filepath.Join(dir, "global.txt")
+	os.WriteFile(global, []byte("global"), 0o644)
+	os.MkdirAll(filepath.Join(dir, "extensions"), 0o755)
+	os.WriteFile(filepath.Join(dir, "extensions", "7.txt"), []byte("per-issue"), 0o644)
+
+	got := stitchPromptExtensions([]string{global}, dir, "7")
+	if len(got) != 2 || got[0] != "global" || got[1] != "per-issue" {
+		t.Errorf("got %q, want [global per-issue]", got)
+	}
+}
+
+func TestStitchPromptExtensions_MissingFilesSkipped(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	got := stitchPromptExtensions([]string{filepath.Join(dir, "nope.txt")}, dir, "7")
+	if len(got) != 0 {
+		t.Errorf("got %q, want none", got)
+	}
+}
+
+func TestBuildStitchPrompt_IncludesExtensions(t *testing.T) {
+	dir := t.TempDir()
+	global := filepath.Join(dir, "global.txt")
+	os.WriteFile(global, []byte("always run go vet"), 0o644)
+
+	cfg := Config{}
+	cfg.Cobbler.Dir = dir
+	cfg.Cobbler.StitchPromptExtensions = []string{global}
+	o := New(cfg)
+
+	out, err := o.buildStitchPrompt(stitchTask{id: "ext-01", title: "T", issueType: "code"})
+	if err != nil {
+		t.Fatalf("buildStitchPrompt: %v", err)
+	}
+	if !strings.Contains(out, "extensions:") || !strings.Contains(out, "always run go vet") {
+		t.Errorf("prompt missing extension content:\n%s", out)
+	}
+}
+
+func TestBuildStitchPrompt_Footer(t *testing.T) {
+	t.Parallel()
+	cfg := Config{}
+	cfg.Cobbler.Dir = t.TempDir()
+	o := New(cfg)
+
+	task := stitchTask{id: "footer-01", title: "T", issueType: "code", description: "do the thing"}
+	out, err := o.buildStitchPrompt(task)
+	if err != nil {
+		t.Fatalf("buildStitchPrompt: %v", err)
+	}
+	if strings.Contains(out, "footer:") {
+		t.Errorf("prompt has a footer without stitch_prompt_footer:\n%s", out)
+	}
+
+	o.cfg.Cobbler.StitchPromptFooter = "Run make lint before finishing."
+	out, err = o.buildStitchPrompt(task)
+	if err != nil {
+		t.Fatalf("buildStitchPrompt: %v", err)
+	}
+	footer := strings.Index(out, "footer: Run make lint before finishing.")
+	if footer < 0 {
+		t.Fatalf("prompt missing footer:\n%s", out)
+	}
+	for _, section := range []string{"execution_constitution:", "go_style_constitution:", "description:"} {
+		if i := strings.Index(out, section); i < 0 || i > footer {
+			t.Errorf("section %q at %d, want before footer at %d", section, i, footer)
+		}
+	}
+}
+
 // --- cleanupWorktree ---
 
 func TestCleanupWorktree_NonExistentDir_NoOp(t *testing.T) {
@@ -296,6 +488,87 @@ func TestTaskBranchName(t *testing.T) {
 	}
 }
 
+// --- compareStitchTasks ---
+
+func taskIDs(tasks []stitchTask) []string {
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.id
+	}
+	return ids
+}
+
+func TestCompareStitchTasks_EqualPrioritiesByID(t *testing.T) {
+	t.Parallel()
+	tasks := []stitchTask{
+		{id: "c", priority: 50},
+		{id: "a", priority: 50},
+		{id: "b", priority: 50},
+	}
+	slices.SortFunc(tasks, compareStitchTasks)
+	if got, want := taskIDs(tasks), []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestCompareStitchTasks_NumericIDs(t *testing.T) {
+	t.Parallel()
+	tasks := []stitchTask{
+		{id: "10", priority: 50},
+		{id: "9", priority: 50},
+		{id: "100", priority: 50},
+	}
+	slices.SortFunc(tasks, compareStitchTasks)
+	if got, want := taskIDs(tasks), []string{"9", "10", "100"}; !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestCompareStitchTasks_MixedPriorities(t *testing.T) {
+	t.Parallel()
+	tasks := []stitchTask{
+		{id: "a", priority: 50},
+		{id: "b", priority: 10},
+		{id: "c", priority: 90},
+		{id: "d", priority: 10},
+	}
+	slices.SortFunc(tasks, compareStitchTasks)
+	if got, want := taskIDs(tasks), []string{"b", "d", "a", "c"}; !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestCompareStitchTasks_SingleTask(t *testing.T) {
+	t.Parallel()
+	tasks := []stitchTask{{id: "only", priority: 7}}
+	slices.SortFunc(tasks, compareStitchTasks)
+	if got, want := taskIDs(tasks), []string{"only"}; !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+// --- parseTaskPriority ---
+
+func TestParseTaskPriority(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		desc string
+		want int
+	}{
+		{"explicit", "priority: 10\ndeliverable_type: code\n", 10},
+		{"absent", "deliverable_type: code\n", defaultTaskPriority},
+		{"empty", "", defaultTaskPriority},
+		{"invalid YAML", "{{{", defaultTaskPriority},
+		{"zero", "priority: 0\n", 0},
+	}
+	for _, tt := range tests {
+		if got := parseTaskPriority(tt.desc); got != tt.want {
+			t.Errorf("%s: parseTaskPriority = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
 // --- parseRequiredReading ---
 
 func TestParseRequiredReading_ValidYAML(t *testing.T) {
@@ -334,7 +607,7 @@ func TestCommitWorktreeChanges_NoChanges(t *testing.T) {
 		worktreeDir: dir,
 	}
 
-	if err := commitWorktreeChanges(task); err != nil {
+	if err := commitWorktreeChanges(task, commitAuthor{}); err != nil {
 		t.Errorf("commitWorktreeChanges() with no changes error = %v", err)
 	}
 }
@@ -363,7 +636,7 @@ func TestCommitWorktreeChanges_WithChanges(t *testing.T) {
 		worktreeDir: dir,
 	}
 
-	if err := commitWorktreeChanges(task); err != nil {
+	if err := commitWorktreeChanges(task, commitAuthor{}); err != nil {
 		t.Fatalf("commitWorktreeChanges() with changes error = %v", err)
 	}
 
@@ -715,6 +988,61 @@ func TestCreateWorktree_ExistingBranch(t *testing.T) {
 	}
 }
 
+// --- createWorktree (per-issue base branch) ---
+
+func TestCreateWorktree_FromBaseBranch(t *testing.T) {
+	dir := initTestGitRepo(t)
+
+	// release-01.0 has a commit that main does not.
+	gitRun(t, "checkout", "-b", "release-01.0")
+	gitRun(t, "commit", "--allow-empty", "-m", "release only")
+	releaseHead, err := gitRevParseHEAD(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, "checkout", "main")
+
+	task := stitchTask{
+		id:          "base",
+		branchName:  "task/main-base",
+		baseBranch:  "release-01.0",
+		worktreeDir: filepath.Join(dir+"-worktrees", "base"),
+	}
+	if err := checkTaskBaseBranch(task); err != nil {
+		t.Fatalf("checkTaskBaseBranch() error = %v", err)
+	}
+	if err := createWorktree(task); err != nil {
+		t.Fatalf("createWorktree() error = %v", err)
+	}
+	t.Cleanup(func() {
+		gitWorktreeRemove(task.worktreeDir, "")
+		gitForceDeleteBranch(task.branchName, "")
+	})
+
+	got, err := gitRevParseHEAD(task.worktreeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != releaseHead {
+		t.Errorf("worktree HEAD = %s, want release-01.0 head %s", got, releaseHead)
+	}
+}
+
+func TestCheckTaskBaseBranch(t *testing.T) {
+	initTestGitRepo(t)
+
+	if err := checkTaskBaseBranch(stitchTask{id: "1"}); err != nil {
+		t.Errorf("empty base branch should pass, got %v", err)
+	}
+	if err := checkTaskBaseBranch(stitchTask{id: "2", baseBranch: "main"}); err != nil {
+		t.Errorf("existing base branch should pass, got %v", err)
+	}
+	err := checkTaskBaseBranch(stitchTask{id: "3", baseBranch: "release-99.0"})
+	if err == nil || !strings.Contains(err.Error(), "release-99.0") {
+		t.Errorf("missing base branch should fail naming the branch, got %v", err)
+	}
+}
+
 // --- cleanupWorktree (real worktree) ---
 
 func TestCleanupWorktree_RealWorktree(t *testing.T) {
@@ -745,6 +1073,71 @@ func TestCleanupWorktree_RealWorktree(t *testing.T) {
 	}
 }
 
+// --- commitWorktreeChanges (author override) ---
+
+func TestCommitWorktreeChanges_PassesAuthorToCommit(t *testing.T) {
+	// Not parallel: replaces gitCommitWorktreeFn.
+	dir := t.TempDir()
+	initTestGitRepoInDir(t, dir)
+	os.WriteFile(filepath.Join(dir, "newfile.go"), []byte("package main\n"), 0o644)
+
+	orig := gitCommitWorktreeFn
+	t.Cleanup(func() { gitCommitWorktreeFn = orig })
+	var gotDir string
+	var gotAuthor commitAuthor
+	gitCommitWorktreeFn = func(dir, msg string, author commitAuthor) error {
+		gotDir, gotAuthor = dir, author
+		return nil
+	}
+
+	cfg := Config{}
+	cfg.Cobbler.CommitAuthorName = "cobbler-bot"
+	cfg.Cobbler.CommitAuthorEmail = "bot@example.com"
+	o := New(cfg)
+	task := stitchTask{id: "7", title: "authored", worktreeDir: dir}
+	if err := commitWorktreeChanges(task, o.stitchCommitAuthor()); err != nil {
+		t.Fatalf("commitWorktreeChanges: %v", err)
+	}
+	if gotDir != dir {
+		t.Errorf("commit dir = %q, want %q", gotDir, dir)
+	}
+	if want := (commitAuthor{Name: "cobbler-bot", Email: "bot@example.com"}); gotAuthor != want {
+		t.Errorf("commit author = %+v, want %+v", gotAuthor, want)
+	}
+}
+
+func TestGitCommitWorktree_AuthorOverride(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	initTestGitRepoInDir(t, dir)
+
+	author := func() string {
+		out, err := cmdGit(dir, "log", "-1", "--format=%an <%ae>").Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	os.WriteFile(filepath.Join(dir, "a.go"), []byte("package a\n"), 0o644)
+	cmdGit(dir, "add", "-A").Run()
+	if err := gitCommitWorktree(dir, "bot commit", commitAuthor{Name: "cobbler-bot", Email: "bot@example.com"}); err != nil {
+		t.Fatalf("gitCommitWorktree: %v", err)
+	}
+	if got := author(); got != "cobbler-bot <bot@example.com>" {
+		t.Errorf("author = %q, want override", got)
+	}
+
+	os.WriteFile(filepath.Join(dir, "b.go"), []byte("package a\n"), 0o644)
+	cmdGit(dir, "add", "-A").Run()
+	if err := gitCommitWorktree(dir, "ambient commit", commitAuthor{}); err != nil {
+		t.Fatalf("gitCommitWorktree: %v", err)
+	}
+	if got := author(); got != "Test <test@test.com>" {
+		t.Errorf("author = %q, want ambient git identity", got)
+	}
+}
+
 // --- commitWorktreeChanges (error path) ---
 
 func TestCommitWorktreeChanges_InvalidDir(t *testing.T) {
@@ -755,7 +1148,7 @@ func TestCommitWorktreeChanges_InvalidDir(t *testing.T) {
 		worktreeDir: "/nonexistent/dir/xyz",
 	}
 
-	err := commitWorktreeChanges(task)
+	err := commitWorktreeChanges(task, commitAuthor{})
 	if err == nil {
 		t.Error("expected error for non-existent directory")
 	}
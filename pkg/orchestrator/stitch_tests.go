@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// defaultStitchTestCommand is the command run in the stitch worktree when
+// StitchTests is set and StitchTestCommand is empty.
+var defaultStitchTestCommand = []string{"go", "test", "./..."}
+
+// stitchTestResult summarizes one test run in a stitch worktree. Package
+// counts come from the go test summary lines; Err is set when the command
+// could not start or exited non-zero.
+type stitchTestResult struct {
+	Passed  int // packages reported "ok"
+	Failed  int // packages reported "FAIL"
+	NoTests int // packages reported with no test files
+	Output  []byte
+	Err     error
+}
+
+// summary returns a one-line description of r for logs and comments.
+func (r stitchTestResult) summary() string {
+	status := "passed"
+	if r.Err != nil {
+		status = "failed"
+	}
+	return fmt.Sprintf("tests %s: %d package(s) ok, %d failed, %d without tests",
+		status, r.Passed, r.Failed, r.NoTests)
+}
+
+// runStitchTests runs command in dir and parses its combined output.
+func runStitchTests(dir string, command []string) stitchTestResult {
+	if len(command) == 0 {
+		command = defaultStitchTestCommand
+	}
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	r := parseGoTestSummary(out)
+	r.Output = out
+	if err != nil {
+		r.Err = fmt.Errorf("%s: %w", strings.Join(command, " "), err)
+	}
+	return r
+}
+
+// parseGoTestSummary counts the per-package result lines that go test
+// prints: "ok", "FAIL", and "?" for packages without test files. Output
+// from other commands yields zero counts.
+func parseGoTestSummary(out []byte) stitchTestResult {
+	var r stitchTestResult
+	for line := range strings.SplitSeq(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "ok":
+			r.Passed++
+		case "FAIL":
+			// "FAIL" alone closes a failing package's output; the
+			// summary line also names the package.
+			r.Failed++
+		case "?":
+			r.NoTests++
+		}
+	}
+	return r
+}
+
+// formatStitchTestComment returns the issue comment body recording r for
+// the task.
+func formatStitchTestComment(taskID string, r stitchTestResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Stitch task %s %s\n", taskID, r.summary())
+	if r.Err != nil {
+		fmt.Fprintf(&b, "\nError: %v\n", r.Err)
+	}
+	return b.String()
+}
+
+// markTaskForReview moves a task whose worktree tests failed out of the
+// stitch queue: the ready and in-progress labels are replaced with
+// cobbler-needs-review. The task branch is kept so the failing change
+// can be inspected; only the worktree is removed.
+func (o *Orchestrator) markTaskForReview(task stitchTask, reason string) {
+	logf("markTaskForReview: #%d needs review (%s)", task.ghNumber, reason)
+	if err := addIssueLabel(task.repo, task.ghNumber, cobblerLabelNeedsReview); err != nil {
+		logf("markTaskForReview: WARNING add %s label failed for #%d: %v", cobblerLabelNeedsReview, task.ghNumber, err)
+	}
+	for _, l := range []string{cobblerLabelReady, cobblerLabelInProgress} {
+		if err := removeIssueLabel(task.repo, task.ghNumber, l); err != nil {
+			logf("markTaskForReview: WARNING remove %s label failed for #%d: %v", l, task.ghNumber, err)
+		}
+	}
+	if err := gitWorktreeRemove(task.worktreeDir, "."); err != nil {
+		logf("markTaskForReview: worktree remove warning: %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"strings"
+	"testing"
+)
+
+// --- parseGoTestSummary ---
+
+func TestParseGoTestSummary(t *testing.T) {
+	t.Parallel()
+	out := "ok  \texample.com/a\t0.01s\n" +
+		"--- FAIL: TestB (0.00s)\n" +
+		"FAIL\n" +
+		"FAIL\texample.com/b\t0.02s\n" +
+		"?   \texample.com/c\t[no test files]\n" +
+		"ok  \texample.com/d\t(cached)\n"
+	r := parseGoTestSummary([]byte(out))
+	if r.Passed != 2 || r.Failed != 1 || r.NoTests != 1 {
+		t.Errorf("parseGoTestSummary = %d ok, %d failed, %d no tests; want 2, 1, 1", r.Passed, r.Failed, r.NoTests)
+	}
+}
+
+// --- runStitchTests ---
+
+func TestRunStitchTests_CapturesFailure(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	r := runStitchTests(dir, []string{"sh", "-c", "printf 'ok  \\tx/a\\t0.1s\\nFAIL\\tx/b\\t0.1s\\n'; exit 1"})
+	if r.Err == nil {
+		t.Fatal("expected an error for a non-zero exit")
+	}
+	if r.Passed != 1 || r.Failed != 1 {
+		t.Errorf("counts = %d ok, %d failed; want 1, 1", r.Passed, r.Failed)
+	}
+	if !strings.Contains(string(r.Output), "FAIL\tx/b") {
+		t.Errorf("output not captured: %q", r.Output)
+	}
+	if c := formatStitchTestComment("7", r); !strings.Contains(c, "tests failed: 1 package(s) ok, 1 failed") {
+		t.Errorf("comment = %q, want failure summary", c)
+	}
+}
+
+func TestRunStitchTests_Pass(t *testing.T) {
+	t.Parallel()
+	r := runStitchTests(t.TempDir(), []string{"sh", "-c", "printf 'ok  \\tx/a\\t0.1s\\n'"})
+	if r.Err != nil || r.Passed != 1 {
+		t.Errorf("runStitchTests = %+v, want one passing package and no error", r)
+	}
+	if !strings.HasPrefix(r.summary(), "tests passed") {
+		t.Errorf("summary = %q, want a passing summary", r.summary())
+	}
+}
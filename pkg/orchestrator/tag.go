@@ -21,13 +21,36 @@ import (
 //
 // Exposed as a mage target (e.g., mage tag).
 func (o *Orchestrator) Tag() error {
-	// Ensure we're on the configured base branch for doc tags.
-	current, err := gitCurrentBranch(".")
-	if err != nil {
-		return fmt.Errorf("getting current branch: %w", err)
-	}
-	if current != o.cfg.Cobbler.BaseBranch {
-		return fmt.Errorf("tag must be run from %s branch (currently on %s)", o.cfg.Cobbler.BaseBranch, current)
+	return o.TagCommit("")
+}
+
+// TagCommit creates the next documentation release tag on commit instead
+// of HEAD, for backfilling tags on earlier doc revisions. The commit must
+// be reachable from the configured base branch. Because the working tree
+// does not reflect commit, the version file update and image build are
+// skipped. An empty commit behaves exactly like Tag.
+//
+// Exposed as a mage target (e.g., mage tagAt <sha>).
+func (o *Orchestrator) TagCommit(commit string) error {
+	base := o.cfg.Cobbler.BaseBranch
+	if commit == "" {
+		// Ensure we're on the configured base branch for doc tags.
+		current, err := gitCurrentBranch(".")
+		if err != nil {
+			return fmt.Errorf("getting current branch: %w", err)
+		}
+		if current != base {
+			return fmt.Errorf("tag must be run from %s branch (currently on %s)", base, current)
+		}
+	} else {
+		sha, err := gitRevParseCommit(commit, ".")
+		if err != nil {
+			return fmt.Errorf("resolving commit %s: %w", commit, err)
+		}
+		if !gitIsAncestor(sha, base, ".") {
+			return fmt.Errorf("commit %s is not reachable from %s branch", commit, base)
+		}
+		commit = sha
 	}
 
 	// Get today's date in YYYYMMDD format.
@@ -39,10 +62,19 @@ func (o *Orchestrator) Tag() error {
 	// Create the tag name.
 	tag := fmt.Sprintf("%s%s.%d", o.cfg.Cobbler.DocTagPrefix, today, revision)
 
+	if commit != "" {
+		logf("tag: creating documentation release %s at %s", tag, truncateSHA(commit))
+		if err := o.createDocTag(tag, commit); err != nil {
+			return fmt.Errorf("creating tag %s: %w", tag, err)
+		}
+		logf("tag: done — created %s (version file and image build skipped for backfill)", tag)
+		return nil
+	}
+
 	logf("tag: creating documentation release %s", tag)
 
 	// Create the git tag.
-	if err := gitTag(tag, "."); err != nil {
+	if err := o.createDocTag(tag, ""); err != nil {
 		return fmt.Errorf("creating tag %s: %w", tag, err)
 	}
 
@@ -69,6 +101,35 @@ func (o *Orchestrator) Tag() error {
 	return nil
 }
 
+// gitTagSignedFn and gitSigningKeyFn create signed tags and look up the
+// configured signing key. Tests replace them to avoid requiring GPG.
+var (
+	gitTagSignedFn  = gitTagSigned
+	gitSigningKeyFn = gitSigningKey
+)
+
+// createDocTag creates tag at ref, or at HEAD when ref is empty. When
+// Cobbler.SignTags is set the tag is signed with Cobbler.SigningKey or,
+// failing that, git's user.signingkey; if neither is set it returns an
+// error rather than creating an unsigned tag.
+func (o *Orchestrator) createDocTag(tag, ref string) error {
+	if !o.cfg.Cobbler.SignTags {
+		if ref == "" {
+			return gitTag(tag, ".")
+		}
+		return gitTagAt(tag, ref, ".")
+	}
+	key := o.cfg.Cobbler.SigningKey
+	if key == "" {
+		key = gitSigningKeyFn(".")
+	}
+	if key == "" {
+		return fmt.Errorf("sign_tags is enabled but no signing key is configured (set cobbler.signing_key or git user.signingkey)")
+	}
+	logf("tag: signing %s with key %s", tag, key)
+	return gitTagSignedFn(tag, ref, key, "Documentation release "+tag, ".")
+}
+
 // nextDocRevision returns the next revision number for <prefix>DATE.* tags.
 // Returns 0 if no tags exist for the given date, otherwise returns the
 // highest existing revision + 1.
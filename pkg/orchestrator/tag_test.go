@@ -130,3 +130,146 @@ func TestTag_WrongBranch(t *testing.T) {
 		t.Errorf("Tag() error = %q, want it to mention the expected branch name", err.Error())
 	}
 }
+
+// --- TagCommit ---
+
+// Not parallel: uses os.Chdir.
+func TestTagCommit_TagsGivenCommit(t *testing.T) {
+	setupTagRepo(t, nil)
+	first, _ := gitRevParseHEAD(".")
+	exec.Command("git", "commit", "--allow-empty", "-m", "second").Run()
+	head, _ := gitRevParseHEAD(".")
+	if head == first {
+		t.Fatal("expected a second commit")
+	}
+	branch, _ := gitCurrentBranch(".")
+
+	cfg := Config{}
+	cfg.Cobbler.DocTagPrefix = "v0."
+	cfg.Cobbler.BaseBranch = branch
+	o := New(cfg)
+	if err := o.TagCommit(first); err != nil {
+		t.Fatalf("TagCommit() error: %v", err)
+	}
+
+	tags := gitListTags("v0.*", ".")
+	if len(tags) != 1 {
+		t.Fatalf("expected 1 tag, got %v", tags)
+	}
+	out, err := exec.Command("git", "rev-list", "-n", "1", tags[0]).Output()
+	if err != nil {
+		t.Fatalf("rev-list: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != first {
+		t.Errorf("tag %s points at %s, want %s", tags[0], got, first)
+	}
+}
+
+// Not parallel: uses os.Chdir.
+func TestTagCommit_NotReachableFromBase(t *testing.T) {
+	setupTagRepo(t, nil)
+	branch, _ := gitCurrentBranch(".")
+	exec.Command("git", "checkout", "-q", "-b", "side").Run()
+	exec.Command("git", "commit", "--allow-empty", "-m", "side work").Run()
+	side, _ := gitRevParseHEAD(".")
+	exec.Command("git", "checkout", "-q", branch).Run()
+
+	cfg := Config{}
+	cfg.Cobbler.BaseBranch = branch
+	o := New(cfg)
+	err := o.TagCommit(side)
+	if err == nil {
+		t.Fatal("TagCommit() expected error for commit off the base branch, got nil")
+	}
+	if !strings.Contains(err.Error(), "not reachable") {
+		t.Errorf("TagCommit() error = %q, want it to mention reachability", err)
+	}
+}
+
+// Not parallel: uses os.Chdir.
+func TestTagCommit_UnknownCommit(t *testing.T) {
+	setupTagRepo(t, nil)
+	branch, _ := gitCurrentBranch(".")
+	cfg := Config{}
+	cfg.Cobbler.BaseBranch = branch
+	o := New(cfg)
+	if err := o.TagCommit("deadbeefdeadbeef"); err == nil {
+		t.Fatal("TagCommit() expected error for unknown commit, got nil")
+	}
+}
+
+// --- signed tags ---
+
+// stubSignedTag replaces the signing hooks for the duration of the test.
+// It returns a pointer to the key passed to the last signed tag call.
+func stubSignedTag(t *testing.T, gitKey string) *string {
+	t.Helper()
+	origTag, origKey := gitTagSignedFn, gitSigningKeyFn
+	t.Cleanup(func() { gitTagSignedFn, gitSigningKeyFn = origTag, origKey })
+	var usedKey string
+	gitTagSignedFn = func(name, ref, key, msg, dir string) error {
+		usedKey = key
+		return gitTagAt(name, ref, dir)
+	}
+	gitSigningKeyFn = func(string) string { return gitKey }
+	return &usedKey
+}
+
+// Not parallel: uses os.Chdir and replaces package-level hooks.
+func TestTagCommit_SignedWithConfiguredKey(t *testing.T) {
+	setupTagRepo(t, nil)
+	head, _ := gitRevParseHEAD(".")
+	branch, _ := gitCurrentBranch(".")
+	usedKey := stubSignedTag(t, "GITKEY")
+
+	cfg := Config{}
+	cfg.Cobbler.BaseBranch = branch
+	cfg.Cobbler.SignTags = true
+	cfg.Cobbler.SigningKey = "CFGKEY"
+	if err := New(cfg).TagCommit(head); err != nil {
+		t.Fatalf("TagCommit() error: %v", err)
+	}
+	if *usedKey != "CFGKEY" {
+		t.Errorf("signing key = %q, want CFGKEY", *usedKey)
+	}
+}
+
+// Not parallel: uses os.Chdir and replaces package-level hooks.
+func TestTagCommit_SignedFallsBackToGitKey(t *testing.T) {
+	setupTagRepo(t, nil)
+	head, _ := gitRevParseHEAD(".")
+	branch, _ := gitCurrentBranch(".")
+	usedKey := stubSignedTag(t, "GITKEY")
+
+	cfg := Config{}
+	cfg.Cobbler.BaseBranch = branch
+	cfg.Cobbler.SignTags = true
+	if err := New(cfg).TagCommit(head); err != nil {
+		t.Fatalf("TagCommit() error: %v", err)
+	}
+	if *usedKey != "GITKEY" {
+		t.Errorf("signing key = %q, want GITKEY", *usedKey)
+	}
+}
+
+// Not parallel: uses os.Chdir and replaces package-level hooks.
+func TestTagCommit_SignedWithoutKeyFails(t *testing.T) {
+	setupTagRepo(t, nil)
+	head, _ := gitRevParseHEAD(".")
+	branch, _ := gitCurrentBranch(".")
+	stubSignedTag(t, "")
+
+	cfg := Config{}
+	cfg.Cobbler.BaseBranch = branch
+	cfg.Cobbler.SignTags = true
+	err := New(cfg).TagCommit(head)
+	if err == nil {
+		t.Fatal("TagCommit() expected error when signing has no key, got nil")
+	}
+	if !strings.Contains(err.Error(), "signing key") {
+		t.Errorf("TagCommit() error = %q, want it to mention the signing key", err)
+	}
+	if tags := gitListTags("v0.*", "."); len(tags) != 0 {
+		t.Errorf("expected no tag to be created, got %v", tags)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// testScanCacheFileName is the file in the cobbler directory that stores
+// per-directory test file counts between CodeStatus runs.
+const testScanCacheFileName = "test-scan-cache.yaml"
+
+// testScanCache maps UC prefixes (e.g. "rel01.0-uc001") to the count
+// recorded for that directory and the directory mtime it was taken at.
+// Root is the absolute directory the tests tree was scanned in, and
+// Suffixes the test file suffixes the counts were taken with.
+type testScanCache struct {
+	Root     string                        `yaml:"root,omitempty"`
+	Suffixes []string                      `yaml:"suffixes,omitempty"`
+	Entries  map[string]testScanCacheEntry `yaml:"entries"`
+}
+
+type testScanCacheEntry struct {
+	ModTime int64 `yaml:"mod_time"` // UnixNano of the UC directory
+	Count   int   `yaml:"count"`
+}
+
+// scanTests returns the scanTestDirectoriesFS result for the tests tree in
+// fsys (or the current directory), served from the test scan cache when
+// CacheTestScan is enabled. An injected fs.FS is always scanned in full:
+// it has no on-disk root to key the cache by.
+func (o *Orchestrator) scanTests(fsys []fs.FS) map[string]int {
+	root := resolveFS(fsys)
+	if !o.cfg.Cobbler.CacheTestScan || (len(fsys) > 0 && fsys[0] != nil) {
+		return scanTestDirectoriesFS(root, "tests", o.cfg.Cobbler.TestFileSuffixes)
+	}
+	rootDir, err := filepath.Abs(".")
+	if err != nil {
+		return scanTestDirectoriesFS(root, "tests", o.cfg.Cobbler.TestFileSuffixes)
+	}
+	counts, rescanned := scanTestDirectoriesCached(root, rootDir, "tests", o.cfg.Cobbler.Dir, o.cfg.Cobbler.TestFileSuffixes)
+	logf("scanTests: %d UC director(ies), %d rescanned", len(counts), len(rescanned))
+	return counts
+}
+
+// scanTestDirectoriesCached behaves like scanTestDirectoriesFS but reuses
+// the count of every UC directory whose modification time matches the
+// cached entry. A directory's mtime changes when files are added, removed,
+// or renamed in it, which is all a count depends on. rootDir is the
+// absolute directory fsys serves; a cache taken in another root or with
+// different suffixes is discarded. The cache is rewritten to hold exactly
+// the directories seen. Returns the counts and the prefixes that were
+// re-counted.
+func scanTestDirectoriesCached(fsys fs.FS, rootDir, testsRoot, cobblerDir string, suffixes []string) (map[string]int, []string) {
+	cache := loadTestScanCache(cobblerDir)
+	if cache.Root != rootDir || !slices.Equal(cache.Suffixes, suffixes) {
+		cache.Entries = map[string]testScanCacheEntry{}
+	}
+	next := testScanCache{Root: rootDir, Suffixes: suffixes, Entries: map[string]testScanCacheEntry{}}
+	result := make(map[string]int)
+	var rescanned []string
+	walkUCTestDirsFS(fsys, testsRoot, func(prefix, ucPath string) {
+		info, err := fs.Stat(fsys, ucPath)
+		if err != nil {
+			return
+		}
+		mtime := info.ModTime().UnixNano()
+		entry, ok := cache.Entries[prefix]
+		if !ok || entry.ModTime != mtime {
+			entry = testScanCacheEntry{ModTime: mtime, Count: countTestFilesFS(fsys, ucPath, suffixes)}
+			rescanned = append(rescanned, prefix)
+		}
+		next.Entries[prefix] = entry
+		if entry.Count > 0 {
+			result[prefix] = entry.Count
+		}
+	})
+	saveTestScanCache(cobblerDir, next)
+	return result, rescanned
+}
+
+// loadTestScanCache reads the cache from cobblerDir. A missing or corrupt
+// file yields an empty cache.
+func loadTestScanCache(cobblerDir string) testScanCache {
+	c := loadYAML[testScanCache](filepath.Join(cobblerDir, testScanCacheFileName))
+	if c == nil || c.Entries == nil {
+		return testScanCache{Entries: map[string]testScanCacheEntry{}}
+	}
+	return *c
+}
+
+// saveTestScanCache writes c to cobblerDir. Errors are logged, not
+// returned, because the cache is an optimization.
+func saveTestScanCache(cobblerDir string, c testScanCache) {
+	data, err := yaml.Marshal(&c)
+	if err != nil {
+		logf("saveTestScanCache: marshal: %v", err)
+		return
+	}
+	if err := os.MkdirAll(cobblerDir, 0o755); err != nil {
+		logf("saveTestScanCache: mkdir %s: %v", cobblerDir, err)
+		return
+	}
+	path := filepath.Join(cobblerDir, testScanCacheFileName)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		logf("saveTestScanCache: write %s: %v", path, err)
+	}
+}
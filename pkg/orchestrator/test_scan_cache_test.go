@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestScanTestDirectoriesCached_RescansOnlyChangedDir(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	cobblerDir := t.TempDir()
+	past := time.Now().Add(-time.Hour)
+	for _, uc := range []string{"uc001", "uc002"} {
+		dir := filepath.Join(root, "tests", "rel01.0", uc)
+		os.MkdirAll(dir, 0o755)
+		os.WriteFile(filepath.Join(dir, "a_test.go"), []byte("package x\n"), 0o644)
+		os.Chtimes(dir, past, past)
+	}
+	fsys := os.DirFS(root)
+
+	first, rescanned := scanTestDirectoriesCached(fsys, root, "tests", cobblerDir, nil)
+	if len(rescanned) != 2 {
+		t.Fatalf("cold cache: rescanned %v, want both directories", rescanned)
+	}
+	if !reflect.DeepEqual(first, scanTestDirectoriesFS(fsys, "tests", nil)) {
+		t.Errorf("cached scan %v differs from full scan", first)
+	}
+
+	if _, rescanned := scanTestDirectoriesCached(fsys, root, "tests", cobblerDir, nil); len(rescanned) != 0 {
+		t.Errorf("warm cache: rescanned %v, want none", rescanned)
+	}
+
+	uc002 := filepath.Join(root, "tests", "rel01.0", "uc002")
+	os.WriteFile(filepath.Join(uc002, "b_test.go"), []byte("package x\n"), 0o644)
+	os.Chtimes(uc002, time.Now(), time.Now())
+
+	got, rescanned := scanTestDirectoriesCached(fsys, root, "tests", cobblerDir, nil)
+	if !reflect.DeepEqual(rescanned, []string{"rel01.0-uc002"}) {
+		t.Errorf("after change: rescanned %v, want only rel01.0-uc002", rescanned)
+	}
+	if !reflect.DeepEqual(got, scanTestDirectoriesFS(fsys, "tests", nil)) || got["rel01.0-uc002"] != 2 {
+		t.Errorf("cached scan %v differs from full scan after change", got)
+	}
+}
+
+func TestScanTestDirectoriesCached_SuffixChangeRescans(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	cobblerDir := t.TempDir()
+	dir := filepath.Join(root, "tests", "rel01.0", "uc001")
+	os.MkdirAll(dir, 0o755)
+	os.WriteFile(filepath.Join(dir, "login.feature"), []byte("Feature: login\n"), 0o644)
+	fsys := os.DirFS(root)
+
+	if got, _ := scanTestDirectoriesCached(fsys, root, "tests", cobblerDir, nil); len(got) != 0 {
+		t.Fatalf("default suffixes: got %v, want no tested directories", got)
+	}
+	got, rescanned := scanTestDirectoriesCached(fsys, root, "tests", cobblerDir, []string{".feature"})
+	if len(rescanned) != 1 || got["rel01.0-uc001"] != 1 {
+		t.Errorf("new suffixes: got %v (rescanned %v), want the cached count replaced", got, rescanned)
+	}
+}
+
+func TestScanTestDirectoriesCached_RootChangeRescans(t *testing.T) {
+	t.Parallel()
+	cobblerDir := t.TempDir()
+	past := time.Now().Add(-time.Hour)
+	var roots []string
+	for _, n := range []int{1, 2} {
+		root := t.TempDir()
+		dir := filepath.Join(root, "tests", "rel01.0", "uc001")
+		os.MkdirAll(dir, 0o755)
+		for i := range n {
+			os.WriteFile(filepath.Join(dir, fmt.Sprintf("t%d_test.go", i)), []byte("package x\n"), 0o644)
+		}
+		// Same mtime in both roots, so only the root tells them apart.
+		os.Chtimes(dir, past, past)
+		roots = append(roots, root)
+	}
+
+	if got, _ := scanTestDirectoriesCached(os.DirFS(roots[0]), roots[0], "tests", cobblerDir, nil); got["rel01.0-uc001"] != 1 {
+		t.Fatalf("first root: got %v, want 1 test file", got)
+	}
+	got, rescanned := scanTestDirectoriesCached(os.DirFS(roots[1]), roots[1], "tests", cobblerDir, nil)
+	if len(rescanned) != 1 || got["rel01.0-uc001"] != 2 {
+		t.Errorf("second root: got %v (rescanned %v), want the first root's count discarded", got, rescanned)
+	}
+}
+
+func TestScanTests_InjectedFSBypassesCache(t *testing.T) {
+	t.Parallel()
+	cobblerDir := t.TempDir()
+	o := New(Config{Cobbler: CobblerConfig{Dir: cobblerDir, CacheTestScan: true}})
+	fsys := fstest.MapFS{"tests/rel01.0/uc001/a_test.go": {Data: []byte("package x\n")}}
+	if got := o.scanTests([]fs.FS{fsys}); got["rel01.0-uc001"] != 1 {
+		t.Errorf("scanTests = %v, want 1 test file", got)
+	}
+	if _, err := os.Stat(filepath.Join(cobblerDir, testScanCacheFileName)); !os.IsNotExist(err) {
+		t.Errorf("injected fs.FS should not write the test scan cache, stat err = %v", err)
+	}
+}
+
+func TestLoadTestScanCache_MissingOrCorrupt(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	if c := loadTestScanCache(dir); len(c.Entries) != 0 {
+		t.Errorf("missing cache: got %v, want empty", c.Entries)
+	}
+	os.WriteFile(filepath.Join(dir, testScanCacheFileName), []byte("entries: [oops"), 0o644)
+	if c := loadTestScanCache(dir); c.Entries == nil || len(c.Entries) != 0 {
+		t.Errorf("corrupt cache: got %v, want empty non-nil map", c.Entries)
+	}
+}
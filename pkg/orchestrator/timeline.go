@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GenerationLog prints a chronological table of every Claude invocation
+// recorded for the given generation: measure and stitch calls with their
+// issue, duration, token usage, and LOC delta. Records are read from the
+// HistoryStats files in the history directory.
+func (o *Orchestrator) GenerationLog(genID string) error {
+	if genID == "" {
+		return fmt.Errorf("generation ID is required")
+	}
+	dir := o.historyDir()
+	if dir == "" {
+		return fmt.Errorf("history directory is not configured")
+	}
+
+	stats, err := loadHistoryStats(dir)
+	if err != nil {
+		return err
+	}
+	stats = filterStatsByGeneration(stats, genID)
+	if len(stats) == 0 {
+		fmt.Printf("no invocations recorded for generation %s\n", genID)
+		return nil
+	}
+	sortStatsByStart(stats)
+	return formatGenerationLog(os.Stdout, stats, o.cfg.Claude.CostModel)
+}
+
+// loadHistoryStats reads every *-stats.yaml file in dir. Files that cannot
+// be parsed are logged and skipped.
+func loadHistoryStats(dir string) ([]HistoryStats, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*-stats.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("listing stats files: %w", err)
+	}
+	var stats []HistoryStats
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			logf("loadHistoryStats: read %s: %v", p, err)
+			continue
+		}
+		var s HistoryStats
+		if err := yaml.Unmarshal(data, &s); err != nil {
+			logf("loadHistoryStats: parse %s: %v", p, err)
+			continue
+		}
+		stats = append(stats, s)
+	}
+	return stats, nil
+}
+
+// filterStatsByGeneration returns the records whose Generation equals genID.
+func filterStatsByGeneration(stats []HistoryStats, genID string) []HistoryStats {
+	var out []HistoryStats
+	for _, s := range stats {
+		if s.Generation == genID {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// sortStatsByStart orders records by StartedAt ascending. Records with an
+// unparseable timestamp sort first, in their original order.
+func sortStatsByStart(stats []HistoryStats) {
+	sort.SliceStable(stats, func(i, j int) bool {
+		ti, _ := time.Parse(time.RFC3339, stats[i].StartedAt)
+		tj, _ := time.Parse(time.RFC3339, stats[j].StartedAt)
+		return ti.Before(tj)
+	})
+}
+
+// formatGenerationLog writes the timeline table for stats to w. Measure
+// invocations have no task ID and are shown as "all". The LOC delta is
+// omitted ("-") when no post-invocation snapshot was recorded. The
+// estimated cost column is computed from the token counts with model.
+func formatGenerationLog(w io.Writer, stats []HistoryStats, model CostModel) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Timestamp\tCaller\tIssue\tDuration\tTokens-In\tTokens-Out\tEst-Cost-USD\tLOC-Δ")
+	for _, s := range stats {
+		issue := s.TaskID
+		if issue == "" {
+			issue = "all"
+		}
+		loc := "-"
+		if s.LOCAfter != (LocSnapshot{}) {
+			delta := (s.LOCAfter.Production + s.LOCAfter.Test) -
+				(s.LOCBefore.Production + s.LOCBefore.Test)
+			loc = fmt.Sprintf("%+d", delta)
+		}
+		cost := model.Estimate(s.Tokens.Input, s.Tokens.Output, s.Tokens.CacheCreation, s.Tokens.CacheRead)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d\t$%.4f\t%s\n",
+			s.StartedAt, s.Caller, issue, formatDuration(s.DurationS),
+			s.Tokens.Input, s.Tokens.Output, cost, loc)
+	}
+	return tw.Flush()
+}
+
+// invocationCSVHeader lists the columns written by writeInvocationsCSV.
+var invocationCSVHeader = []string{
+	"issue_id", "caller", "started_at", "duration_s",
+	"input_tokens", "output_tokens",
+	"files", "insertions", "deletions",
+	"loc_prod_delta", "loc_test_delta",
+}
+
+// ExportInvocationsCSV writes every Claude invocation recorded for the
+// given generation to a CSV file at path, one row per invocation in
+// chronological order. Records are read from the same HistoryStats files
+// as GenerationLog. Values that were not recorded (no issue for measure,
+// no diff or post-invocation LOC snapshot) are written as blank cells.
+func (o *Orchestrator) ExportInvocationsCSV(genID, path string) error {
+	if genID == "" {
+		return fmt.Errorf("generation ID is required")
+	}
+	if path == "" {
+		return fmt.Errorf("output path is required")
+	}
+	dir := o.historyDir()
+	if dir == "" {
+		return fmt.Errorf("history directory is not configured")
+	}
+
+	stats, err := loadHistoryStats(dir)
+	if err != nil {
+		return err
+	}
+	stats = filterStatsByGeneration(stats, genID)
+	sortStatsByStart(stats)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	if err := writeInvocationsCSV(f, stats); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing %s: %w", path, err)
+	}
+	logf("exportInvocationsCSV: wrote %d row(s) to %s", len(stats), path)
+	return nil
+}
+
+// writeInvocationsCSV writes a header row followed by one row per record.
+func writeInvocationsCSV(w io.Writer, stats []HistoryStats) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(invocationCSVHeader); err != nil {
+		return fmt.Errorf("writing CSV header: %w", err)
+	}
+	for _, s := range stats {
+		var files, ins, del string
+		if s.Diff != (historyDiff{}) {
+			files = strconv.Itoa(s.Diff.Files)
+			ins = strconv.Itoa(s.Diff.Insertions)
+			del = strconv.Itoa(s.Diff.Deletions)
+		}
+		var prodDelta, testDelta string
+		if s.LOCAfter != (LocSnapshot{}) {
+			prodDelta = strconv.Itoa(s.LOCAfter.Production - s.LOCBefore.Production)
+			testDelta = strconv.Itoa(s.LOCAfter.Test - s.LOCBefore.Test)
+		}
+		row := []string{
+			s.TaskID, s.Caller, s.StartedAt, strconv.Itoa(s.DurationS),
+			strconv.Itoa(s.Tokens.Input), strconv.Itoa(s.Tokens.Output),
+			files, ins, del,
+			prodDelta, testDelta,
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("writing CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
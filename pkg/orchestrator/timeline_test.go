@@ -0,0 +1,206 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// --- sortStatsByStart ---
+
+func TestSortStatsByStart_Chronological(t *testing.T) {
+	t.Parallel()
+	stats := []HistoryStats{
+		{Caller: "stitch", StartedAt: "2026-03-01T12:00:00Z"},
+		{Caller: "measure", StartedAt: "2026-03-01T10:00:00Z"},
+		{Caller: "stitch", StartedAt: "2026-03-01T11:00:00Z"},
+	}
+	sortStatsByStart(stats)
+	want := []string{"2026-03-01T10:00:00Z", "2026-03-01T11:00:00Z", "2026-03-01T12:00:00Z"}
+	for i, s := range stats {
+		if s.StartedAt != want[i] {
+			t.Errorf("stats[%d].StartedAt = %q, want %q", i, s.StartedAt, want[i])
+		}
+	}
+}
+
+func TestSortStatsByStart_MixedTimezones(t *testing.T) {
+	t.Parallel()
+	stats := []HistoryStats{
+		{TaskID: "late", StartedAt: "2026-03-01T10:30:00Z"},
+		{TaskID: "early", StartedAt: "2026-03-01T11:00:00+01:00"},
+	}
+	sortStatsByStart(stats)
+	if stats[0].TaskID != "early" {
+		t.Errorf("first = %q, want %q", stats[0].TaskID, "early")
+	}
+}
+
+// --- filterStatsByGeneration ---
+
+func TestFilterStatsByGeneration(t *testing.T) {
+	t.Parallel()
+	stats := []HistoryStats{
+		{Generation: "gen-a", TaskID: "1"},
+		{Generation: "gen-b", TaskID: "2"},
+		{Generation: "gen-a", TaskID: "3"},
+		{TaskID: "4"},
+	}
+	got := filterStatsByGeneration(stats, "gen-a")
+	if len(got) != 2 || got[0].TaskID != "1" || got[1].TaskID != "3" {
+		t.Errorf("got %+v, want tasks 1 and 3", got)
+	}
+}
+
+// --- formatGenerationLog ---
+
+func TestFormatGenerationLog_Columns(t *testing.T) {
+	t.Parallel()
+	stats := []HistoryStats{
+		{
+			Caller: "measure", StartedAt: "2026-03-01T10:00:00Z", DurationS: 42,
+			Tokens: historyTokens{Input: 1000, Output: 200},
+		},
+		{
+			Caller: "stitch", TaskID: "17", StartedAt: "2026-03-01T10:05:00Z", DurationS: 125,
+			Tokens:    historyTokens{Input: 5000, Output: 900},
+			LOCBefore: LocSnapshot{Production: 100, Test: 50},
+			LOCAfter:  LocSnapshot{Production: 180, Test: 90},
+		},
+	}
+	var buf bytes.Buffer
+	if err := formatGenerationLog(&buf, stats, CostModel{InputUSDPerToken: 0.000003, OutputUSDPerToken: 0.000015}); err != nil {
+		t.Fatalf("formatGenerationLog: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3 (header + 2 rows):\n%s", len(lines), buf.String())
+	}
+	for _, col := range []string{"Timestamp", "Caller", "Issue", "Duration", "Tokens-In", "Tokens-Out", "Est-Cost-USD", "LOC-Δ"} {
+		if !strings.Contains(lines[0], col) {
+			t.Errorf("header missing column %q: %s", col, lines[0])
+		}
+	}
+	for _, want := range []string{"measure", "all", "42s", "1000", "200", "$0.0060", "-"} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("measure row missing %q: %s", want, lines[1])
+		}
+	}
+	for _, want := range []string{"stitch", "17", "2m5s", "5000", "900", "+120"} {
+		if !strings.Contains(lines[2], want) {
+			t.Errorf("stitch row missing %q: %s", want, lines[2])
+		}
+	}
+}
+
+// --- GenerationLog ---
+
+func TestGenerationLog_RequiresGenID(t *testing.T) {
+	t.Parallel()
+	o := New(Config{})
+	if err := o.GenerationLog(""); err == nil {
+		t.Error("expected error for empty generation ID")
+	}
+}
+
+func TestLoadHistoryStats_SkipsUnparseable(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	data, _ := yaml.Marshal(HistoryStats{Caller: "stitch", Generation: "gen-x"})
+	os.WriteFile(filepath.Join(dir, "2026-03-01-10-00-00-stitch-stats.yaml"), data, 0o644)
+	os.WriteFile(filepath.Join(dir, "2026-03-01-11-00-00-stitch-stats.yaml"), []byte("{{{bad"), 0o644)
+	os.WriteFile(filepath.Join(dir, "2026-03-01-10-00-00-stitch-prompt.yaml"), data, 0o644)
+
+	stats, err := loadHistoryStats(dir)
+	if err != nil {
+		t.Fatalf("loadHistoryStats: %v", err)
+	}
+	if len(stats) != 1 || stats[0].Generation != "gen-x" {
+		t.Errorf("got %+v, want one gen-x record", stats)
+	}
+}
+
+// --- ExportInvocationsCSV ---
+
+func TestWriteInvocationsCSV_BlankMissingFields(t *testing.T) {
+	t.Parallel()
+	stats := []HistoryStats{
+		{Caller: "measure", StartedAt: "2026-03-01T10:00:00Z", DurationS: 30,
+			Tokens: historyTokens{Input: 100, Output: 20}},
+		{Caller: "stitch", TaskID: "42", StartedAt: "2026-03-01T10:05:00Z", DurationS: 90,
+			Tokens:    historyTokens{Input: 300, Output: 80},
+			Diff:      historyDiff{Files: 3, Insertions: 40, Deletions: 5},
+			LOCBefore: LocSnapshot{Production: 100, Test: 50},
+			LOCAfter:  LocSnapshot{Production: 130, Test: 45}},
+	}
+	var buf bytes.Buffer
+	if err := writeInvocationsCSV(&buf, stats); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	want := [][]string{
+		invocationCSVHeader,
+		{"", "measure", "2026-03-01T10:00:00Z", "30", "100", "20", "", "", "", "", ""},
+		{"42", "stitch", "2026-03-01T10:05:00Z", "90", "300", "80", "3", "40", "5", "30", "-5"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows =\n%v\nwant\n%v", rows, want)
+	}
+}
+
+func TestExportInvocationsCSV_FiltersGeneration(t *testing.T) {
+	t.Parallel()
+	histDir := t.TempDir()
+	for name, s := range map[string]HistoryStats{
+		"2026-03-01-10-00-00-stitch-stats.yaml":  {Caller: "stitch", Generation: "gen-a", TaskID: "2", StartedAt: "2026-03-01T10:00:00Z"},
+		"2026-03-01-09-00-00-measure-stats.yaml": {Caller: "measure", Generation: "gen-a", StartedAt: "2026-03-01T09:00:00Z"},
+		"2026-03-01-11-00-00-stitch-stats.yaml":  {Caller: "stitch", Generation: "gen-b", TaskID: "9", StartedAt: "2026-03-01T11:00:00Z"},
+	} {
+		data, _ := yaml.Marshal(s)
+		os.WriteFile(filepath.Join(histDir, name), data, 0o644)
+	}
+
+	out := filepath.Join(t.TempDir(), "inv.csv")
+	o := New(Config{Cobbler: CobblerConfig{HistoryDir: histDir}})
+	if err := o.ExportInvocationsCSV("gen-a", out); err != nil {
+		t.Fatalf("ExportInvocationsCSV: %v", err)
+	}
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want header + 2: %v", len(rows), rows)
+	}
+	if rows[1][1] != "measure" || rows[2][0] != "2" {
+		t.Errorf("rows not chronological for gen-a: %v", rows[1:])
+	}
+}
+
+func TestExportInvocationsCSV_RequiresArgs(t *testing.T) {
+	t.Parallel()
+	o := New(Config{})
+	if err := o.ExportInvocationsCSV("", "x.csv"); err == nil {
+		t.Error("expected error for empty generation ID")
+	}
+	if err := o.ExportInvocationsCSV("gen-a", ""); err == nil {
+		t.Error("expected error for empty path")
+	}
+}
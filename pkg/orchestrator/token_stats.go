@@ -59,13 +59,7 @@ type promptTokenSummary struct {
 // ANTHROPIC_API_KEY to enable API counting.
 func (o *Orchestrator) TokenStats() error {
 	files := o.enumerateContextFiles()
-
-	sort.Slice(files, func(i, j int) bool {
-		if files[i].Category != files[j].Category {
-			return files[i].Category < files[j].Category
-		}
-		return files[i].Path < files[j].Path
-	})
+	sortFileTokenStats(files)
 
 	totalBytes := 0
 	catBytes := map[string]int{}
@@ -92,7 +86,7 @@ func (o *Orchestrator) TokenStats() error {
 
 	ps := promptTokenSummary{
 		Bytes:           len(prompt),
-		EstimatedTokens: len(prompt) / 4,
+		EstimatedTokens: estimatePromptTokens(prompt),
 	}
 
 	apiKey := os.Getenv("ANTHROPIC_API_KEY")
@@ -140,6 +134,47 @@ func (o *Orchestrator) enumerateContextFiles() []FileTokenStat {
 	return files
 }
 
+// ContextInventory prints the files enumerateContextFiles reports,
+// grouped by category with each file's byte size and a per-category
+// total. Categories and paths are sorted alphabetically. Files are
+// resolved from the current working directory.
+//
+// Exposed as a mage target (mage stats:context).
+func (o *Orchestrator) ContextInventory() error {
+	writeContextInventory(os.Stdout, o.enumerateContextFiles())
+	return nil
+}
+
+// writeContextInventory writes the grouped inventory of files to w.
+func writeContextInventory(w io.Writer, files []FileTokenStat) {
+	sortFileTokenStats(files)
+	totalBytes := 0
+	for i := 0; i < len(files); {
+		cat := files[i].Category
+		j, catBytes := i, 0
+		for ; j < len(files) && files[j].Category == cat; j++ {
+			catBytes += files[j].Bytes
+		}
+		fmt.Fprintf(w, "%s (%d files, %d bytes)\n", cat, j-i, catBytes)
+		for _, f := range files[i:j] {
+			fmt.Fprintf(w, "  %-60s %8d\n", f.Path, f.Bytes)
+		}
+		totalBytes += catBytes
+		i = j
+	}
+	fmt.Fprintf(w, "\nTotal: %d files, %d bytes\n", len(files), totalBytes)
+}
+
+// sortFileTokenStats orders files by category, then path.
+func sortFileTokenStats(files []FileTokenStat) {
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Category != files[j].Category {
+			return files[i].Category < files[j].Category
+		}
+		return files[i].Path < files[j].Path
+	})
+}
+
 // sortedKeys returns the keys of a map sorted alphabetically.
 func sortedKeys(m map[string]int) []string {
 	keys := make([]string, 0, len(m))
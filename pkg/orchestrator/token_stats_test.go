@@ -4,6 +4,7 @@
 package orchestrator
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
@@ -94,3 +95,56 @@ func TestEnumerateContextFiles_NoPromptFilesAbsent(t *testing.T) {
 		}
 	}
 }
+
+// --- ContextInventory ---
+
+func TestWriteContextInventory_GroupsAndTotals(t *testing.T) {
+	t.Parallel()
+	files := []FileTokenStat{
+		{Category: "source", Path: "pkg/b.go", Bytes: 20},
+		{Category: "docs", Path: "docs/VISION.yaml", Bytes: 5},
+		{Category: "source", Path: "pkg/a.go", Bytes: 10},
+	}
+	var buf bytes.Buffer
+	writeContextInventory(&buf, files)
+	out := buf.String()
+
+	for _, want := range []string{
+		"docs (1 files, 5 bytes)",
+		"source (2 files, 30 bytes)",
+		"Total: 3 files, 35 bytes",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "docs (") > strings.Index(out, "source (") {
+		t.Errorf("categories should be sorted alphabetically:\n%s", out)
+	}
+	if strings.Index(out, "pkg/a.go") > strings.Index(out, "pkg/b.go") {
+		t.Errorf("paths should be sorted within a category:\n%s", out)
+	}
+}
+
+// Not parallel: uses os.Chdir.
+func TestWriteContextInventory_HonorsExclude(t *testing.T) {
+	dir := chdirTemp(t)
+	os.MkdirAll(filepath.Join(dir, "pkg"), 0o755)
+	os.WriteFile(filepath.Join(dir, "pkg", "keep.go"), []byte("package pkg\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "pkg", "skip.go"), []byte("package pkg\n"), 0o644)
+
+	o := New(Config{Project: ProjectConfig{
+		GoSourceDirs:   []string{"pkg"},
+		ContextExclude: "pkg/skip.go",
+	}})
+	var buf bytes.Buffer
+	writeContextInventory(&buf, o.enumerateContextFiles())
+	out := buf.String()
+
+	if !strings.Contains(out, "pkg/keep.go") {
+		t.Errorf("expected pkg/keep.go in inventory:\n%s", out)
+	}
+	if strings.Contains(out, "pkg/skip.go") {
+		t.Errorf("excluded pkg/skip.go should not appear:\n%s", out)
+	}
+}
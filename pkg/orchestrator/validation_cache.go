@@ -0,0 +1,233 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// validationCacheFileName is the file in the cobbler directory that stores
+// per-issue validation results between runs.
+const validationCacheFileName = "validation-cache.yaml"
+
+// orchestratorModulePath is the module that implements the validation
+// rules. Its version, as recorded in the build info, identifies the rule
+// code a cached result was computed by.
+const orchestratorModulePath = "github.com/mesh-intelligence/cobbler-scaffold"
+
+// validationCache maps issue hashes to their validation results. RuleKey
+// records the rule set the results were computed under; a mismatch
+// invalidates every entry.
+type validationCache struct {
+	RuleKey string                          `yaml:"rule_key"`
+	Entries map[string]validationCacheEntry `yaml:"entries"`
+}
+
+type validationCacheEntry struct {
+	Warnings []string `yaml:"warnings,omitempty"`
+	Errors   []string `yaml:"errors,omitempty"`
+}
+
+// validationRuleKey returns a string identifying the rule set in effect
+// for the given validation parameters: a SHA-256 over the rules source
+// version and the rules struct encoded as JSON with sorted keys, so the
+// key does not depend on field order. Custom rules are left out because
+// their results are never cached.
+func validationRuleKey(rules measureRules) string {
+	rules.Custom = nil
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00", validationRulesSource())
+	h.Write(canonicalJSON(rules))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// canonicalJSON encodes v as JSON with object keys sorted. It returns nil
+// if v cannot be encoded.
+func canonicalJSON(v any) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil
+	}
+	// encoding/json writes map keys in sorted order.
+	data, err = json.Marshal(generic)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// validationRulesSource identifies the build of the validation rule code.
+// It is computed once per process.
+var validationRulesSource = sync.OnceValue(func() string {
+	info, _ := debug.ReadBuildInfo()
+	return rulesSourceVersion(info, executableModTime())
+})
+
+// rulesSourceVersion returns the version of the code that implements the
+// validation rules. A released or pseudo-versioned orchestrator dependency
+// is identified by its module version; a main-module build by its VCS
+// revision. A build from a modified tree, a locally replaced dependency,
+// or one without version information falls back to the executable's
+// modification time, so every rebuild during development discards cached
+// results.
+func rulesSourceVersion(info *debug.BuildInfo, exeModTime string) string {
+	if info == nil {
+		return "exe:" + exeModTime
+	}
+	var mod *debug.Module
+	if info.Main.Path == orchestratorModulePath {
+		mod = &info.Main
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == orchestratorModulePath {
+			mod = dep
+			if dep.Replace != nil {
+				mod = dep.Replace
+			}
+		}
+	}
+	if mod != nil && mod.Version != "" && mod.Version != "(devel)" {
+		return "mod:" + mod.Version
+	}
+	var revision string
+	modified := false
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.modified":
+			modified = s.Value == "true"
+		}
+	}
+	if info.Main.Path == orchestratorModulePath && revision != "" && !modified {
+		return "vcs:" + revision
+	}
+	return "exe:" + exeModTime
+}
+
+// executableModTime returns the modification time of the running
+// executable, or "" if it cannot be determined.
+func executableModTime() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	fi, err := os.Stat(exe)
+	if err != nil {
+		return ""
+	}
+	return fi.ModTime().UTC().Format("20060102T150405.000000000")
+}
+
+// hashProposedIssue returns a hex SHA-256 over the fields that appear in
+// validation messages or influence validation.
+func hashProposedIssue(issue ProposedIssue) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\x00%s\x00%s\x00%s", issue.Index, issue.Title, issue.Description, issue.Effort)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// loadValidationCache reads the cache from cobblerDir. A missing or corrupt
+// file, or one written under a different rule key, yields an empty cache.
+func loadValidationCache(cobblerDir, ruleKey string) validationCache {
+	empty := validationCache{RuleKey: ruleKey, Entries: map[string]validationCacheEntry{}}
+	data, err := os.ReadFile(filepath.Join(cobblerDir, validationCacheFileName))
+	if err != nil {
+		return empty
+	}
+	var c validationCache
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		logf("loadValidationCache: ignoring corrupt cache: %v", err)
+		return empty
+	}
+	if c.RuleKey != ruleKey || c.Entries == nil {
+		logf("loadValidationCache: rule set changed (%q -> %q), discarding cache", c.RuleKey, ruleKey)
+		return empty
+	}
+	return c
+}
+
+// saveValidationCache writes c to cobblerDir. Errors are logged, not
+// returned, because the cache is an optimization.
+func saveValidationCache(cobblerDir string, c validationCache) {
+	data, err := yaml.Marshal(&c)
+	if err != nil {
+		logf("saveValidationCache: marshal: %v", err)
+		return
+	}
+	path := filepath.Join(cobblerDir, validationCacheFileName)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		logf("saveValidationCache: write %s: %v", path, err)
+	}
+}
+
+// validateMeasureOutputCached behaves like validateMeasureOutput but skips
+// issues whose hash is already in the cache under the same rule set. The
+// cache is rewritten to hold exactly the issues passed in, so entries for
+// issues no longer present are dropped. Cross-issue checks are never
+// cached. Returns the combined result and the number of issues served from
+// the cache.
+func validateMeasureOutputCached(cobblerDir string, issues []ProposedIssue, rules measureRules) (ValidationResult, int) {
+	ruleKey := validationRuleKey(rules)
+	cache := loadValidationCache(cobblerDir, ruleKey)
+	next := validationCache{RuleKey: ruleKey, Entries: make(map[string]validationCacheEntry, len(issues))}
+
+	var result ValidationResult
+	hits := 0
+	for _, issue := range issues {
+		key := hashProposedIssue(issue)
+		entry, ok := cache.Entries[key]
+		if ok {
+			hits++
+		} else {
+			r := validateProposedIssue(issue, rules)
+			entry = validationCacheEntry{Warnings: r.Warnings, Errors: r.Errors}
+		}
+		next.Entries[key] = entry
+		result.merge(ValidationResult{Warnings: entry.Warnings, Errors: entry.Errors})
+	}
+	result.merge(validateCrossIssue(issues, rules))
+
+	saveValidationCache(cobblerDir, next)
+	return result, hits
+}
+
+// RevalidateMeasure re-runs measure validation over every issue recorded in
+// the artifacts directory's measure.yaml and prints the findings to stdout.
+// Unchanged issues are served from the validation cache.
+func (o *Orchestrator) RevalidateMeasure() error {
+	logPath := o.measureLogPath()
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", logPath, err)
+	}
+	var issues []ProposedIssue
+	if err := yaml.Unmarshal(data, &issues); err != nil {
+		return fmt.Errorf("parsing %s: %w", logPath, err)
+	}
+
+	vr, hits := validateMeasureOutputCached(o.cfg.Cobbler.Dir, issues, o.measureRules())
+	fmt.Printf("%d issue(s) validated (%d from cache): %d error(s), %d warning(s)\n",
+		len(issues), hits, len(vr.Errors), len(vr.Warnings))
+	for _, e := range vr.Errors {
+		fmt.Printf("  error: %s\n", e)
+	}
+	for _, w := range vr.Warnings {
+		fmt.Printf("  warning: %s\n", w)
+	}
+	return nil
+}
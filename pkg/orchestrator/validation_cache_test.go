@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Petar Djukic. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package orchestrator
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func cacheTestIssues() []ProposedIssue {
+	return []ProposedIssue{
+		{Index: 1, Title: "a", Description: "deliverable_type: code\nrequirements:\n  - id: R1\n    text: r\n"},
+		{Index: 2, Title: "b", Description: "deliverable_type: documentation\n"},
+	}
+}
+
+func TestValidateMeasureOutputCached_MatchesUncached(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	issues := cacheTestIssues()
+
+	want := validateMeasureOutput(issues, measureRules{})
+	got, hits := validateMeasureOutputCached(dir, issues, measureRules{})
+	if hits != 0 {
+		t.Errorf("first run hits = %d, want 0", hits)
+	}
+	if len(got.Errors) != len(want.Errors) || len(got.Warnings) != len(want.Warnings) {
+		t.Errorf("cached result %+v differs from uncached %+v", got, want)
+	}
+}
+
+func TestValidateMeasureOutputCached_UnchangedServedFromCache(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	issues := cacheTestIssues()
+
+	validateMeasureOutputCached(dir, issues, measureRules{})
+	_, hits := validateMeasureOutputCached(dir, issues, measureRules{})
+	if hits != 2 {
+		t.Errorf("second run hits = %d, want 2", hits)
+	}
+}
+
+func TestValidateMeasureOutputCached_ChangedDescriptionRevalidated(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	issues := cacheTestIssues()
+	validateMeasureOutputCached(dir, issues, measureRules{})
+
+	issues[0].Description = "deliverable_type: documentation\n"
+	vr, hits := validateMeasureOutputCached(dir, issues, measureRules{})
+	if hits != 1 {
+		t.Errorf("hits = %d, want 1 (only the unchanged issue)", hits)
+	}
+	want := validateMeasureOutput(issues, measureRules{})
+	if len(vr.Errors) != len(want.Errors) {
+		t.Errorf("errors = %v, want %v", vr.Errors, want.Errors)
+	}
+}
+
+func TestValidateMeasureOutputCached_RuleChangeInvalidates(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	issues := cacheTestIssues()
+	validateMeasureOutputCached(dir, issues, measureRules{})
+
+	_, hits := validateMeasureOutputCached(dir, issues, measureRules{MaxReqs: 3})
+	if hits != 0 {
+		t.Errorf("hits after maxReqs change = %d, want 0", hits)
+	}
+}
+
+func TestValidationRuleKey_StableAndRuleSensitive(t *testing.T) {
+	t.Parallel()
+	rules := measureRules{MaxReqs: 3, Extensions: map[string][]string{"code": {".go"}, "documentation": {".md"}}}
+	if validationRuleKey(rules) != validationRuleKey(rules) {
+		t.Error("rule key should be deterministic")
+	}
+	custom := rules
+	custom.Custom = []ValidationRule{func([]ProposedIssue) ValidationResult { return ValidationResult{} }}
+	if validationRuleKey(custom) != validationRuleKey(rules) {
+		t.Error("custom rules should not affect the rule key")
+	}
+	changed := rules
+	changed.Extensions = map[string][]string{"code": {".go", ".mod"}, "documentation": {".md"}}
+	if validationRuleKey(changed) == validationRuleKey(rules) {
+		t.Error("rule key should change when deliverable extensions change")
+	}
+}
+
+func TestRulesSourceVersion(t *testing.T) {
+	t.Parallel()
+	vcs := []debug.BuildSetting{{Key: "vcs.revision", Value: "abc123"}, {Key: "vcs.modified", Value: "false"}}
+	dirty := []debug.BuildSetting{{Key: "vcs.revision", Value: "abc123"}, {Key: "vcs.modified", Value: "true"}}
+	cases := []struct {
+		name string
+		info *debug.BuildInfo
+		want string
+	}{
+		{name: "no build info", info: nil, want: "exe:t"},
+		{
+			name: "released dependency",
+			info: &debug.BuildInfo{Main: debug.Module{Path: "example.com/app", Version: "(devel)"}, Deps: []*debug.Module{{Path: orchestratorModulePath, Version: "v1.2.0"}}, Settings: vcs},
+			want: "mod:v1.2.0",
+		},
+		{
+			name: "locally replaced dependency",
+			info: &debug.BuildInfo{Main: debug.Module{Path: "example.com/app"}, Deps: []*debug.Module{{Path: orchestratorModulePath, Version: "v1.2.0", Replace: &debug.Module{Path: "../cobbler-scaffold"}}}, Settings: vcs},
+			want: "exe:t",
+		},
+		{
+			name: "clean main module",
+			info: &debug.BuildInfo{Main: debug.Module{Path: orchestratorModulePath, Version: "(devel)"}, Settings: vcs},
+			want: "vcs:abc123",
+		},
+		{
+			name: "modified main module",
+			info: &debug.BuildInfo{Main: debug.Module{Path: orchestratorModulePath, Version: "(devel)"}, Settings: dirty},
+			want: "exe:t",
+		},
+	}
+	for _, tc := range cases {
+		if got := rulesSourceVersion(tc.info, "t"); got != tc.want {
+			t.Errorf("%s: rulesSourceVersion = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestHashProposedIssue_SensitiveToDescription(t *testing.T) {
+	t.Parallel()
+	a := ProposedIssue{Index: 1, Title: "t", Description: "x"}
+	b := a
+	b.Description = "y"
+	if hashProposedIssue(a) == hashProposedIssue(b) {
+		t.Error("hash should differ when description changes")
+	}
+	if hashProposedIssue(a) != hashProposedIssue(a) {
+		t.Error("hash should be stable")
+	}
+	c := a
+	c.Effort = "L"
+	if hashProposedIssue(a) == hashProposedIssue(c) {
+		t.Error("hash should differ when effort changes")
+	}
+}
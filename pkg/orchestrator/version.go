@@ -9,12 +9,19 @@ import (
 	"regexp"
 )
 
-// versionConstRe matches a Go const declaration like:
+// versionConstRe matches a Go const declaration in either the inline form:
 //
 //	const Version = "v1.20260212.0"
 //
-// It captures the quoted value.
-var versionConstRe = regexp.MustCompile(`(?m)^const\s+Version\s*=\s*"([^"]*)"`)
+// or as a member of a parenthesized const block:
+//
+//	const (
+//		Version = "v1.20260212.0"
+//	)
+//
+// Group 1 captures everything before the opening quote so the original
+// form is preserved on rewrite; group 2 captures the quoted value.
+var versionConstRe = regexp.MustCompile(`(?m)(^const\s+Version\s*=\s*|^const\s*\([^)]*?^\s*Version\s*=\s*)"([^"]*)"`)
 
 // readVersionConst reads the Version constant from a Go source file.
 // Returns "" if the file does not exist or has no Version constant.
@@ -27,22 +34,29 @@ func readVersionConst(filePath string) string {
 	if m == nil {
 		return ""
 	}
-	return string(m[1])
+	return string(m[2])
 }
 
 // writeVersionConst updates the Version constant in a Go source file.
-// The file must already exist and contain a `const Version = "..."` line.
+// The file must already contain a Version constant in either the inline or
+// the parenthesized form; the form is preserved. When the file has more
+// than one match, only the first is updated.
 func writeVersionConst(filePath, version string) error {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return fmt.Errorf("reading version file: %w", err)
 	}
 
-	if !versionConstRe.Match(data) {
+	loc := versionConstRe.FindSubmatchIndex(data)
+	if loc == nil {
 		return fmt.Errorf("no Version constant found in %s", filePath)
 	}
 
-	updated := versionConstRe.ReplaceAll(data, []byte(fmt.Sprintf(`const Version = "%s"`, version)))
+	// Replace only the quoted value (group 2) of the first match.
+	var updated []byte
+	updated = append(updated, data[:loc[4]]...)
+	updated = append(updated, version...)
+	updated = append(updated, data[loc[5]:]...)
 	if err := os.WriteFile(filePath, updated, 0o644); err != nil {
 		return fmt.Errorf("writing version file: %w", err)
 	}
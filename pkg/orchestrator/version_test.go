@@ -6,6 +6,7 @@ package orchestrator
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -89,3 +90,92 @@ const AppName = "myapp"
 		t.Error("expected error when no Version const, got nil")
 	}
 }
+
+// --- parenthesized const blocks ---
+
+func writeVersionFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "version.go")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadVersionConst_GroupedSingle(t *testing.T) {
+	t.Parallel()
+	path := writeVersionFile(t, "package main\n\nconst (\n\tVersion = \"v1.2.3\"\n)\n")
+	if got := readVersionConst(path); got != "v1.2.3" {
+		t.Errorf("readVersionConst() = %q, want %q", got, "v1.2.3")
+	}
+}
+
+func TestWriteVersionConst_GroupedSinglePreservesForm(t *testing.T) {
+	t.Parallel()
+	path := writeVersionFile(t, "package main\n\nconst (\n\tVersion = \"v1.2.3\"\n)\n")
+	if err := writeVersionConst(path, "v1.2.4"); err != nil {
+		t.Fatalf("writeVersionConst: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	want := "package main\n\nconst (\n\tVersion = \"v1.2.4\"\n)\n"
+	if string(data) != want {
+		t.Errorf("file =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestWriteVersionConst_GroupedMultipleOnlyVersionUpdated(t *testing.T) {
+	t.Parallel()
+	path := writeVersionFile(t, `package main
+
+const (
+	AppName    = "myapp"
+	MaxVersion = "v9.9.9"
+	Version    = "v1.0.0"
+	Build      = "dev"
+)
+`)
+	if got := readVersionConst(path); got != "v1.0.0" {
+		t.Fatalf("readVersionConst() = %q, want %q", got, "v1.0.0")
+	}
+	if err := writeVersionConst(path, "v2.0.0"); err != nil {
+		t.Fatalf("writeVersionConst: %v", err)
+	}
+	want := `package main
+
+const (
+	AppName    = "myapp"
+	MaxVersion = "v9.9.9"
+	Version    = "v2.0.0"
+	Build      = "dev"
+)
+`
+	data, _ := os.ReadFile(path)
+	if string(data) != want {
+		t.Errorf("file =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestWriteVersionConst_BothFormsFirstMatchWins(t *testing.T) {
+	t.Parallel()
+	path := writeVersionFile(t, `package main
+
+const (
+	Version = "v1.0.0"
+)
+
+const Version = "v0.0.1"
+`)
+	if got := readVersionConst(path); got != "v1.0.0" {
+		t.Fatalf("readVersionConst() = %q, want %q (first match)", got, "v1.0.0")
+	}
+	if err := writeVersionConst(path, "v3.0.0"); err != nil {
+		t.Fatalf("writeVersionConst: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "\tVersion = \"v3.0.0\"") {
+		t.Errorf("grouped Version not updated:\n%s", data)
+	}
+	if !strings.Contains(string(data), "const Version = \"v0.0.1\"") {
+		t.Errorf("second (inline) Version should be untouched:\n%s", data)
+	}
+}